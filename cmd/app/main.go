@@ -2,31 +2,60 @@ package main
 
 import (
 	"context"
-	"log"
+	"log/slog"
+	"os"
 	"time"
 
 	"github.com/SeiFlow-3P2/board_service/internal/app"
+	"github.com/SeiFlow-3P2/board_service/internal/interceptor"
+	"github.com/SeiFlow-3P2/board_service/internal/logging"
 	"github.com/SeiFlow-3P2/board_service/pkg/env"
 )
 
 func main() {
 	if err := env.LoadEnv(); err != nil {
-		log.Fatalf("Failed to load env: %v", err)
+		slog.Error("failed to load env", "error", err)
+		os.Exit(1)
 	}
 
+	logger := logging.New(os.Stdout, env.GetLogLevel())
+	slog.SetDefault(logger)
+
 	cfg := &app.Config{
-		AppName:      env.GetAppName(),
-		Port:         env.GetPort(),
-		ReadTimeout:  5 * time.Second,
-		WriteTimeout: 10 * time.Second,
-		IdleTimeout:  120 * time.Second,
-		MongoURL:     env.GetMongoURL(),
-		MongoDB:      env.GetMongoName(),
+		AppName:          env.GetAppName(),
+		Port:             env.GetPort(),
+		HTTPPort:         env.GetHTTPPort(),
+		MetricsPort:      env.GetMetricsPort(),
+		ReadTimeout:      5 * time.Second,
+		WriteTimeout:     10 * time.Second,
+		IdleTimeout:      120 * time.Second,
+		MongoURL:         env.GetMongoURL(),
+		MongoDB:          env.GetMongoName(),
+		EventPublisher:   env.GetEventPublisher(),
+		KafkaBrokers:     env.GetKafkaBrokers(),
+		KafkaGroupID:     env.GetKafkaGroupID(),
+		CalendarTopic:    env.GetCalendarTopic(),
+		UserDeletedTopic: env.GetUserDeletedTopic(),
+		Interceptors:     env.GetInterceptors(),
+		RedisAddr:        env.GetRedisAddr(),
+		RedisPassword:    env.GetRedisPassword(),
+		CacheTTL:         env.GetCacheTTL(),
+		Auth: interceptor.AuthConfig{
+			Mode: env.GetAuthMode(),
+			JWT: interceptor.JWTConfig{
+				HMACSecret: env.GetJWTHMACSecret(),
+				JWKSFile:   env.GetJWTJWKSFile(),
+				JWKSURL:    env.GetJWTJWKSURL(),
+				Issuer:     env.GetJWTIssuer(),
+				Audience:   env.GetJWTAudience(),
+			},
+		},
 	}
 
-	app := app.New(cfg)
+	app := app.New(cfg, logger)
 
 	if err := app.Start(context.Background()); err != nil {
-		log.Fatalf("Failed to start server: %v", err)
+		logger.Error("failed to start server", "error", err)
+		os.Exit(1)
 	}
 }
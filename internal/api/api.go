@@ -10,17 +10,20 @@ import (
 type Handler struct {
 	pb.UnimplementedBoardServiceServer
 	boardHandler  *BoardServiceHandler
+	memberHandler *MemberServiceHandler
 	columnHandler *ColumnServiceHandler
 	taskHandler   *TaskServiceHandler
 }
 
 func NewHandler(
 	boardHandler *BoardServiceHandler,
+	memberHandler *MemberServiceHandler,
 	columnHandler *ColumnServiceHandler,
 	taskHandler *TaskServiceHandler,
 ) *Handler {
 	return &Handler{
 		boardHandler:  boardHandler,
+		memberHandler: memberHandler,
 		columnHandler: columnHandler,
 		taskHandler:   taskHandler,
 	}
@@ -47,6 +50,27 @@ func (h *Handler) DeleteBoard(ctx context.Context, req *pb.DeleteBoardRequest) (
 	return h.boardHandler.DeleteBoard(ctx, req)
 }
 
+func (h *Handler) WatchBoard(req *pb.WatchBoardRequest, stream pb.BoardService_WatchBoardServer) error {
+	return h.boardHandler.WatchBoard(req, stream)
+}
+
+// Member methods
+func (h *Handler) AddBoardMember(ctx context.Context, req *pb.AddBoardMemberRequest) (*pb.BoardMemberResponse, error) {
+	return h.memberHandler.AddBoardMember(ctx, req)
+}
+
+func (h *Handler) RemoveBoardMember(ctx context.Context, req *pb.RemoveBoardMemberRequest) (*emptypb.Empty, error) {
+	return h.memberHandler.RemoveBoardMember(ctx, req)
+}
+
+func (h *Handler) ListBoardMembers(ctx context.Context, req *pb.ListBoardMembersRequest) (*pb.BoardMembersListResponse, error) {
+	return h.memberHandler.ListBoardMembers(ctx, req)
+}
+
+func (h *Handler) UpdateMemberRole(ctx context.Context, req *pb.UpdateMemberRoleRequest) (*pb.BoardMemberResponse, error) {
+	return h.memberHandler.UpdateMemberRole(ctx, req)
+}
+
 // Column methods
 func (h *Handler) CreateColumn(ctx context.Context, req *pb.CreateColumnRequest) (*pb.ColumnResponse, error) {
 	return h.columnHandler.CreateColumn(ctx, req)
@@ -56,6 +80,10 @@ func (h *Handler) UpdateColumn(ctx context.Context, req *pb.UpdateColumnRequest)
 	return h.columnHandler.UpdateColumn(ctx, req)
 }
 
+func (h *Handler) MoveColumn(ctx context.Context, req *pb.MoveColumnRequest) (*pb.ColumnResponse, error) {
+	return h.columnHandler.MoveColumn(ctx, req)
+}
+
 func (h *Handler) DeleteColumn(ctx context.Context, req *pb.DeleteColumnRequest) (*emptypb.Empty, error) {
 	return h.columnHandler.DeleteColumn(ctx, req)
 }
@@ -76,3 +104,7 @@ func (h *Handler) UpdateTask(ctx context.Context, req *pb.UpdateTaskRequest) (*p
 func (h *Handler) DeleteTask(ctx context.Context, req *pb.DeleteTaskRequest) (*emptypb.Empty, error) {
 	return h.taskHandler.DeleteTask(ctx, req)
 }
+
+func (h *Handler) SearchTasks(ctx context.Context, req *pb.SearchTasksRequest) (*pb.SearchTasksResponse, error) {
+	return h.taskHandler.SearchTasks(ctx, req)
+}
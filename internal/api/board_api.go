@@ -2,26 +2,28 @@ package api
 
 import (
 	"context"
+	"log/slog"
 	"time"
 
 	"github.com/SeiFlow-3P2/board_service/internal/models"
 	"github.com/SeiFlow-3P2/board_service/internal/service"
+	"github.com/SeiFlow-3P2/board_service/internal/watch"
 	pb "github.com/SeiFlow-3P2/board_service/pkg/proto/v1"
 	"github.com/SeiFlow-3P2/shared/telemetry"
 	"github.com/google/uuid"
 
 	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 type BoardServiceHandler struct {
 	boardService *service.BoardService
+	logger       *slog.Logger
 }
 
-func NewBoardServiceHandler(boardService *service.BoardService) *BoardServiceHandler {
-	return &BoardServiceHandler{boardService: boardService}
+func NewBoardServiceHandler(boardService *service.BoardService, logger *slog.Logger) *BoardServiceHandler {
+	return &BoardServiceHandler{boardService: boardService, logger: logger}
 }
 
 func (h *BoardServiceHandler) boardToGetInfoResponse(board *models.Board) *pb.GetBoardInfoResponse {
@@ -36,6 +38,7 @@ func (h *BoardServiceHandler) boardToGetInfoResponse(board *models.Board) *pb.Ge
 				Deadline:    task.Deadline.Format(time.RFC3339),
 				InCalendar:  task.In_Calendar,
 				ColumnId:    task.Column_id.String(),
+				Rank:        task.Rank,
 			})
 		}
 
@@ -71,28 +74,28 @@ func (h *BoardServiceHandler) CreateBoard(ctx context.Context, req *pb.CreateBoa
 	defer span.End()
 
 	if req.Name == "" {
-		err := status.Error(codes.InvalidArgument, "title is required")
+		err := invalidArgument("name", "title is required")
 		telemetry.RecordError(span, err)
 		return nil, err
 	}
 	if req.Description == "" {
-		err := status.Error(codes.InvalidArgument, "description is required")
+		err := invalidArgument("description", "description is required")
 		telemetry.RecordError(span, err)
 		return nil, err
 	}
 	if req.Methodology == "" {
-		err := status.Error(codes.InvalidArgument, "methodology is required")
+		err := invalidArgument("methodology", "methodology is required")
 		telemetry.RecordError(span, err)
 		return nil, err
 	}
 	if req.Category == "" {
-		err := status.Error(codes.InvalidArgument, "category is required")
+		err := invalidArgument("category", "category is required")
 		telemetry.RecordError(span, err)
 		return nil, err
 	}
 
 	if req.Methodology != "kanban" && req.Methodology != "simple" {
-		err := status.Error(codes.InvalidArgument, "methotodology must be kanban or simple")
+		err := invalidArgument("methodology", "methodology must be kanban or simple")
 		telemetry.RecordError(span, err)
 		return nil, err
 	}
@@ -104,20 +107,9 @@ func (h *BoardServiceHandler) CreateBoard(ctx context.Context, req *pb.CreateBoa
 		Category:    req.Category,
 	})
 	if err != nil {
-		switch {
-		case err == service.ErrUserNotInContext:
-			err := status.Error(codes.InvalidArgument, err.Error())
-			telemetry.RecordError(span, err)
-			return nil, err
-		case err == service.ErrBoardExists:
-			err := status.Error(codes.AlreadyExists, err.Error())
-			telemetry.RecordError(span, err)
-			return nil, err
-		default:
-			err := status.Error(codes.Internal, err.Error())
-			telemetry.RecordError(span, err)
-			return nil, err
-		}
+		err := statusError(ctx, h.logger, err)
+		telemetry.RecordError(span, err)
+		return nil, err
 	}
 	return h.boardToGetInfoResponse(board), nil
 }
@@ -127,26 +119,21 @@ func (h *BoardServiceHandler) GetBoardInfo(ctx context.Context, req *pb.GetBoard
 	defer span.End()
 
 	if req.Id == "" {
-		err := status.Error(codes.InvalidArgument, "board ID is required")
+		err := invalidArgument("id", "board ID is required")
 		telemetry.RecordError(span, err)
 		return nil, err
 	}
 
 	boardID, err := uuid.Parse(req.Id)
 	if err != nil {
-		err := status.Error(codes.InvalidArgument, "invalid board ID")
+		err := invalidArgument("id", "invalid board ID")
 		telemetry.RecordError(span, err)
 		return nil, err
 	}
 
 	board, err := h.boardService.GetBoardInfo(ctx, boardID)
 	if err != nil {
-		if err == service.ErrBoardNotFound {
-			err := status.Error(codes.NotFound, "board not found")
-			telemetry.RecordError(span, err)
-			return nil, err
-		}
-		err := status.Error(codes.Internal, err.Error())
+		err := statusError(ctx, h.logger, err)
 		telemetry.RecordError(span, err)
 		return nil, err
 	}
@@ -158,23 +145,55 @@ func (h *BoardServiceHandler) GetBoards(ctx context.Context, req *pb.GetBoardsRe
 	ctx, span := telemetry.StartSpan(ctx, "BoardHandler.GetBoards")
 	defer span.End()
 
-	boards, err := h.boardService.GetBoards(ctx)
+	input := service.GetBoardsInput{
+		PageSize:  int(req.PageSize),
+		PageToken: req.PageToken,
+		Favorite:  req.Favorite,
+	}
+	if req.Category != "" {
+		input.Category = &req.Category
+	}
+	if req.Methodology != "" {
+		input.Methodology = &req.Methodology
+	}
+
+	switch req.SortBy {
+	case pb.BoardSortField_BOARD_SORT_FIELD_UNSPECIFIED, pb.BoardSortField_BOARD_SORT_FIELD_UPDATED_AT:
+		input.SortBy = "updated_at"
+	case pb.BoardSortField_BOARD_SORT_FIELD_CREATED_AT:
+		input.SortBy = "created_at"
+	case pb.BoardSortField_BOARD_SORT_FIELD_TITLE:
+		input.SortBy = "title"
+	default:
+		err := invalidArgument("sort_by", "unknown sort field")
+		telemetry.RecordError(span, err)
+		return nil, err
+	}
+
+	switch req.SortOrder {
+	case pb.SortOrder_SORT_ORDER_UNSPECIFIED:
+	case pb.SortOrder_SORT_ORDER_ASC, pb.SortOrder_SORT_ORDER_DESC:
+		descending := req.SortOrder == pb.SortOrder_SORT_ORDER_DESC
+		input.Descending = &descending
+	default:
+		err := invalidArgument("sort_order", "unknown sort order")
+		telemetry.RecordError(span, err)
+		return nil, err
+	}
+
+	page, err := h.boardService.GetBoards(ctx, input)
 	if err != nil {
-		if err == service.ErrUserNotInContext {
-			err := status.Error(codes.InvalidArgument, err.Error())
-			telemetry.RecordError(span, err)
-			return nil, err
-		}
-		err := status.Error(codes.Internal, err.Error())
+		err := statusError(ctx, h.logger, err)
 		telemetry.RecordError(span, err)
 		return nil, err
 	}
 
 	response := &pb.BoardsListResponse{
-		Boards: make([]*pb.BoardResponse, 0, len(boards)),
+		Boards:        make([]*pb.BoardResponse, 0, len(page.Boards)),
+		NextPageToken: page.NextPageToken,
 	}
 
-	for _, board := range boards {
+	for _, board := range page.Boards {
 		pbBoard := &pb.BoardResponse{
 			Id:          board.ID.String(),
 			Name:        board.Title,
@@ -196,18 +215,18 @@ func (h *BoardServiceHandler) UpdateBoard(ctx context.Context, req *pb.UpdateBoa
 	defer span.End()
 
 	if req.Id == "" {
-		err := status.Error(codes.InvalidArgument, "board ID is required")
+		err := invalidArgument("id", "board ID is required")
 		telemetry.RecordError(span, err)
 		return nil, err
 	}
 	boardID, err := uuid.Parse(req.Id)
 	if err != nil {
-		err := status.Error(codes.InvalidArgument, "invalid board ID")
+		err := invalidArgument("id", "invalid board ID")
 		telemetry.RecordError(span, err)
 		return nil, err
 	}
 	if req.Name == nil && req.Description == nil && req.Progress == nil && req.Favorite == nil {
-		err := status.Error(codes.InvalidArgument, "at least one field is required")
+		err := invalidArgument("", "at least one field is required")
 		telemetry.RecordError(span, err)
 		return nil, err
 	}
@@ -243,12 +262,7 @@ func (h *BoardServiceHandler) UpdateBoard(ctx context.Context, req *pb.UpdateBoa
 
 	board, err := h.boardService.UpdateBoard(ctx, updates)
 	if err != nil {
-		if err == service.ErrBoardNotFound {
-			err := status.Error(codes.NotFound, "board not found")
-			telemetry.RecordError(span, err)
-			return nil, err
-		}
-		err := status.Error(codes.Internal, err.Error())
+		err := statusError(ctx, h.logger, err)
 		telemetry.RecordError(span, err)
 		return nil, err
 	}
@@ -262,22 +276,106 @@ func (h *BoardServiceHandler) DeleteBoard(ctx context.Context, req *pb.DeleteBoa
 
 	boardID, err := uuid.Parse(req.Id)
 	if err != nil {
-		err := status.Error(codes.InvalidArgument, "invalid board ID")
+		err := invalidArgument("id", "invalid board ID")
 		telemetry.RecordError(span, err)
 		return nil, err
 	}
 
 	err = h.boardService.DeleteBoard(ctx, boardID)
 	if err != nil {
-		if err == service.ErrBoardNotFound {
-			err := status.Error(codes.NotFound, "board not found")
-			telemetry.RecordError(span, err)
-			return nil, err
-		}
-		err := status.Error(codes.Internal, err.Error())
+		err := statusError(ctx, h.logger, err)
 		telemetry.RecordError(span, err)
 		return nil, err
 	}
 
 	return &emptypb.Empty{}, nil
 }
+
+var changeTypes = map[watch.ChangeType]pb.BoardChangeType{
+	watch.ColumnCreated: pb.BoardChangeType_BOARD_CHANGE_TYPE_COLUMN_CREATED,
+	watch.ColumnRenamed: pb.BoardChangeType_BOARD_CHANGE_TYPE_COLUMN_RENAMED,
+	watch.ColumnMoved:   pb.BoardChangeType_BOARD_CHANGE_TYPE_COLUMN_MOVED,
+	watch.ColumnDeleted: pb.BoardChangeType_BOARD_CHANGE_TYPE_COLUMN_DELETED,
+	watch.TaskCreated:   pb.BoardChangeType_BOARD_CHANGE_TYPE_TASK_CREATED,
+	watch.TaskUpdated:   pb.BoardChangeType_BOARD_CHANGE_TYPE_TASK_UPDATED,
+	watch.TaskMoved:     pb.BoardChangeType_BOARD_CHANGE_TYPE_TASK_MOVED,
+	watch.TaskDeleted:   pb.BoardChangeType_BOARD_CHANGE_TYPE_TASK_DELETED,
+	watch.BoardDeleted:  pb.BoardChangeType_BOARD_CHANGE_TYPE_BOARD_DELETED,
+	watch.TasksReranked: pb.BoardChangeType_BOARD_CHANGE_TYPE_TASKS_RERANKED,
+}
+
+func changeToResponse(change watch.Change) *pb.BoardChange {
+	response := &pb.BoardChange{
+		Type:       changeTypes[change.Type],
+		BoardId:    change.BoardID.String(),
+		UserId:     change.UserID,
+		OccurredAt: timestamppb.New(change.OccurredAt),
+	}
+	switch {
+	case change.Column != nil:
+		response.Subject = &pb.BoardChange_Column{Column: &pb.ColumnResponse{
+			Id:          change.Column.ID.String(),
+			Name:        change.Column.Name,
+			BoardId:     change.Column.Desk_id.String(),
+			OrderNumber: int64(change.Column.Order_number),
+		}}
+	case change.Task != nil:
+		var deadline string
+		if !change.Task.Deadline.IsZero() {
+			deadline = change.Task.Deadline.Format(time.RFC3339)
+		}
+		response.Subject = &pb.BoardChange_Task{Task: &pb.TaskResponse{
+			Id:          change.Task.ID.String(),
+			Name:        change.Task.Title,
+			Description: change.Task.Description,
+			Deadline:    deadline,
+			InCalendar:  change.Task.In_Calendar,
+			ColumnId:    change.Task.Column_id.String(),
+			Rank:        change.Task.Rank,
+		}}
+	}
+	return response
+}
+
+func (h *BoardServiceHandler) WatchBoard(req *pb.WatchBoardRequest, stream pb.BoardService_WatchBoardServer) error {
+	ctx, span := telemetry.StartSpan(stream.Context(), "BoardHandler.WatchBoard")
+	defer span.End()
+
+	boardID, err := uuid.Parse(req.BoardId)
+	if err != nil {
+		err := invalidArgument("board_id", "invalid board ID")
+		telemetry.RecordError(span, err)
+		return err
+	}
+
+	sub, err := h.boardService.WatchBoard(ctx, boardID)
+	if err != nil {
+		err := statusError(ctx, h.logger, err)
+		telemetry.RecordError(span, err)
+		return err
+	}
+	defer sub.Close()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case change, ok := <-sub.Changes():
+			if !ok {
+				err := newStatusError(codes.Aborted, "WATCHER_FELL_BEHIND", "", "watcher fell behind, reload the board and watch again")
+				if sub.Err() == watch.ErrRevoked {
+					err = statusError(ctx, h.logger, service.ErrPermissionDenied)
+				}
+				telemetry.RecordError(span, err)
+				return err
+			}
+			if err := stream.Send(changeToResponse(change)); err != nil {
+				telemetry.RecordError(span, err)
+				return err
+			}
+			if change.Type == watch.BoardDeleted {
+				return nil
+			}
+		}
+	}
+}
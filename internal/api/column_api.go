@@ -2,39 +2,37 @@ package api
 
 import (
 	"context"
-	"fmt"
+	"log/slog"
 
 	"github.com/SeiFlow-3P2/board_service/internal/service"
 	pb "github.com/SeiFlow-3P2/board_service/pkg/proto/v1"
 	"github.com/SeiFlow-3P2/shared/telemetry"
 	"github.com/google/uuid"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
 )
 
 type ColumnServiceHandler struct {
 	columnService *service.ColumnService
+	logger        *slog.Logger
 }
 
-func NewColumnServiceHandler(columnService *service.ColumnService) *ColumnServiceHandler {
-	return &ColumnServiceHandler{columnService: columnService}
+func NewColumnServiceHandler(columnService *service.ColumnService, logger *slog.Logger) *ColumnServiceHandler {
+	return &ColumnServiceHandler{columnService: columnService, logger: logger}
 }
 
 func (h *ColumnServiceHandler) CreateColumn(ctx context.Context, req *pb.CreateColumnRequest) (*pb.ColumnResponse, error) {
 	ctx, span := telemetry.StartSpan(ctx, "ColumnHandler.CreateColumn")
 	defer span.End()
 
-	fmt.Println("CreateColumnRequest", req)
 	if req.Name == "" {
-		err := status.Error(codes.InvalidArgument, "name is required")
+		err := invalidArgument("name", "name is required")
 		telemetry.RecordError(span, err)
 		return nil, err
 	}
 
 	boardID, err := uuid.Parse(req.BoardId)
 	if err != nil {
-		err := status.Error(codes.InvalidArgument, "invalid board ID")
+		err := invalidArgument("board_id", "invalid board ID")
 		telemetry.RecordError(span, err)
 		return nil, err
 	}
@@ -44,20 +42,9 @@ func (h *ColumnServiceHandler) CreateColumn(ctx context.Context, req *pb.CreateC
 		DeskID: boardID,
 	})
 	if err != nil {
-		switch {
-		case err.Error() == "board not found":
-			err := status.Error(codes.NotFound, "board not found")
-			telemetry.RecordError(span, err)
-			return nil, err
-		case err.Error() == service.ErrColumnExists.Error():
-			err := status.Error(codes.AlreadyExists, "column with this name already exists")
-			telemetry.RecordError(span, err)
-			return nil, err
-		default:
-			err := status.Error(codes.Internal, err.Error())
-			telemetry.RecordError(span, err)
-			return nil, err
-		}
+		err := statusError(ctx, h.logger, err)
+		telemetry.RecordError(span, err)
+		return nil, err
 	}
 
 	return &pb.ColumnResponse{
@@ -73,14 +60,14 @@ func (h *ColumnServiceHandler) UpdateColumn(ctx context.Context, req *pb.UpdateC
 	defer span.End()
 
 	if req.Name == nil || req.Name.Value == "" {
-		err := status.Error(codes.InvalidArgument, "name is required")
+		err := invalidArgument("name", "name is required")
 		telemetry.RecordError(span, err)
 		return nil, err
 	}
 
 	columnID, err := uuid.Parse(req.Id)
 	if err != nil {
-		err := status.Error(codes.InvalidArgument, "invalid column ID")
+		err := invalidArgument("id", "invalid column ID")
 		telemetry.RecordError(span, err)
 		return nil, err
 	}
@@ -91,20 +78,38 @@ func (h *ColumnServiceHandler) UpdateColumn(ctx context.Context, req *pb.UpdateC
 		OrderNumber: nil,
 	})
 	if err != nil {
-		switch {
-		case err.Error() == "column not found":
-			err := status.Error(codes.NotFound, "column not found")
-			telemetry.RecordError(span, err)
-			return nil, err
-		case err.Error() == service.ErrColumnExists.Error():
-			err := status.Error(codes.AlreadyExists, "column with this name already exists")
-			telemetry.RecordError(span, err)
-			return nil, err
-		default:
-			err := status.Error(codes.Internal, err.Error())
-			telemetry.RecordError(span, err)
-			return nil, err
-		}
+		err := statusError(ctx, h.logger, err)
+		telemetry.RecordError(span, err)
+		return nil, err
+	}
+
+	return &pb.ColumnResponse{
+		Id:          column.ID.String(),
+		Name:        column.Name,
+		BoardId:     column.Desk_id.String(),
+		OrderNumber: int64(column.Order_number),
+	}, nil
+}
+
+func (h *ColumnServiceHandler) MoveColumn(ctx context.Context, req *pb.MoveColumnRequest) (*pb.ColumnResponse, error) {
+	ctx, span := telemetry.StartSpan(ctx, "ColumnHandler.MoveColumn")
+	defer span.End()
+
+	columnID, err := uuid.Parse(req.Id)
+	if err != nil {
+		err := invalidArgument("id", "invalid column ID")
+		telemetry.RecordError(span, err)
+		return nil, err
+	}
+
+	column, err := h.columnService.MoveColumn(ctx, service.MoveColumnInput{
+		ID:          columnID,
+		OrderNumber: int(req.NewOrderNumber),
+	})
+	if err != nil {
+		err := statusError(ctx, h.logger, err)
+		telemetry.RecordError(span, err)
+		return nil, err
 	}
 
 	return &pb.ColumnResponse{
@@ -121,25 +126,36 @@ func (h *ColumnServiceHandler) DeleteColumn(ctx context.Context, req *pb.DeleteC
 
 	columnID, err := uuid.Parse(req.Id)
 	if err != nil {
-		err := status.Error(codes.InvalidArgument, "invalid column ID")
+		err := invalidArgument("id", "invalid column ID")
 		telemetry.RecordError(span, err)
 		return nil, err
 	}
 
-	err = h.columnService.DeleteColumn(ctx, service.DeleteColumnInput{
+	input := service.DeleteColumnInput{
 		ID: columnID,
-	})
-	if err != nil {
-		switch {
-		case err.Error() == "column not found":
-			err := status.Error(codes.NotFound, "column not found")
-			telemetry.RecordError(span, err)
-			return nil, err
-		default:
-			err := status.Error(codes.Internal, err.Error())
+	}
+
+	switch req.Mode {
+	case pb.DeleteColumnMode_DELETE_COLUMN_MODE_UNSPECIFIED, pb.DeleteColumnMode_DELETE_COLUMN_MODE_DELETE_TASKS:
+	case pb.DeleteColumnMode_DELETE_COLUMN_MODE_MOVE_TASKS:
+		targetID, err := uuid.Parse(req.TargetColumnId)
+		if err != nil {
+			err := invalidArgument("target_column_id", "invalid target column ID")
 			telemetry.RecordError(span, err)
 			return nil, err
 		}
+		input.MoveTasksTo = &targetID
+	default:
+		err := invalidArgument("mode", "unknown delete mode")
+		telemetry.RecordError(span, err)
+		return nil, err
+	}
+
+	err = h.columnService.DeleteColumn(ctx, input)
+	if err != nil {
+		err := statusError(ctx, h.logger, err)
+		telemetry.RecordError(span, err)
+		return nil, err
 	}
 
 	return &emptypb.Empty{}, nil
@@ -0,0 +1,117 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/SeiFlow-3P2/board_service/internal/service"
+	"go.mongodb.org/mongo-driver/mongo"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+)
+
+// errorDomain is the domain of the ErrorInfo details attached to errors.
+const errorDomain = "board.seiflow"
+
+// serviceError describes how a service error is returned to clients. Reason
+// is a stable identifier for clients to switch on; Field names the request
+// field at fault, if any.
+type serviceError struct {
+	err    error
+	code   codes.Code
+	reason string
+	field  string
+}
+
+var serviceErrors = []serviceError{
+	{service.ErrUserNotInContext, codes.Unauthenticated, "UNAUTHENTICATED", ""},
+	{service.ErrPermissionDenied, codes.PermissionDenied, "PERMISSION_DENIED", ""},
+
+	{service.ErrBoardNotFound, codes.NotFound, "BOARD_NOT_FOUND", ""},
+	{service.ErrColumnNotFound, codes.NotFound, "COLUMN_NOT_FOUND", ""},
+	{service.ErrNewColumnNotFound, codes.NotFound, "COLUMN_NOT_FOUND", "new_column_id"},
+	{service.ErrTaskNotFound, codes.NotFound, "TASK_NOT_FOUND", ""},
+	{service.ErrMemberNotFound, codes.NotFound, "MEMBER_NOT_FOUND", "user_id"},
+
+	{service.ErrBoardExists, codes.AlreadyExists, "BOARD_EXISTS", "name"},
+	{service.ErrColumnExists, codes.AlreadyExists, "COLUMN_EXISTS", "name"},
+	{service.ErrMemberExists, codes.AlreadyExists, "MEMBER_EXISTS", "user_id"},
+
+	{service.ErrInvalidPageSize, codes.InvalidArgument, "INVALID_PAGE_SIZE", "page_size"},
+	{service.ErrInvalidPageToken, codes.InvalidArgument, "INVALID_PAGE_TOKEN", "page_token"},
+	{service.ErrInvalidSortField, codes.InvalidArgument, "INVALID_SORT_FIELD", "sort_by"},
+	{service.ErrEmptyName, codes.InvalidArgument, "EMPTY_NAME", "name"},
+	{service.ErrEmptyDeskID, codes.InvalidArgument, "EMPTY_BOARD_ID", "board_id"},
+	{service.ErrEmptyOrderNumber, codes.InvalidArgument, "EMPTY_ORDER_NUMBER", "new_order_number"},
+	{service.ErrInvalidOrder, codes.InvalidArgument, "INVALID_ORDER_NUMBER", "new_order_number"},
+	{service.ErrInvalidTarget, codes.InvalidArgument, "INVALID_TARGET_COLUMN", "target_column_id"},
+	{service.ErrEmptyUserID, codes.InvalidArgument, "EMPTY_USER_ID", "user_id"},
+	{service.ErrInvalidRole, codes.InvalidArgument, "INVALID_ROLE", "role"},
+	{service.ErrEmptyTitle, codes.InvalidArgument, "EMPTY_NAME", "name"},
+	{service.ErrEmptyDescription, codes.InvalidArgument, "EMPTY_DESCRIPTION", "description"},
+	{service.ErrInvalidDeadline, codes.InvalidArgument, "INVALID_DEADLINE", "deadline"},
+	{service.ErrEmptyID, codes.InvalidArgument, "EMPTY_ID", "id"},
+	{service.ErrInvalidPosition, codes.InvalidArgument, "INVALID_POSITION", "position"},
+	{service.ErrAnchorTaskInvalid, codes.InvalidArgument, "INVALID_ANCHOR_TASK", "placement"},
+	{service.ErrEmptyQuery, codes.InvalidArgument, "EMPTY_QUERY", "query"},
+	{service.ErrInvalidDeadlines, codes.InvalidArgument, "INVALID_DEADLINE_RANGE", "deadline_to"},
+}
+
+// statusError translates an error returned by a service into a gRPC status
+// with an ErrorInfo detail, and a BadRequest detail for invalid arguments.
+// Errors that are not expected are logged and returned as Internal without
+// their message, which may expose internals.
+func statusError(ctx context.Context, logger *slog.Logger, err error) error {
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	for _, e := range serviceErrors {
+		if errors.Is(err, e.err) {
+			return newStatusError(e.code, e.reason, e.field, err.Error())
+		}
+	}
+
+	switch {
+	case errors.Is(err, mongo.ErrNoDocuments):
+		return newStatusError(codes.NotFound, "NOT_FOUND", "", "not found")
+	case mongo.IsDuplicateKeyError(err):
+		return newStatusError(codes.AlreadyExists, "ALREADY_EXISTS", "", "already exists")
+	case errors.Is(err, context.Canceled):
+		return newStatusError(codes.Canceled, "CANCELED", "", "request canceled")
+	case errors.Is(err, context.DeadlineExceeded), mongo.IsTimeout(err):
+		return newStatusError(codes.DeadlineExceeded, "DEADLINE_EXCEEDED", "", "request timed out")
+	case mongo.IsNetworkError(err):
+		return newStatusError(codes.Unavailable, "UNAVAILABLE", "", "database is unavailable")
+	}
+
+	logger.ErrorContext(ctx, "internal error", "error", err)
+	return newStatusError(codes.Internal, "INTERNAL", "", "internal error")
+}
+
+// invalidArgument reports a request field that failed validation.
+func invalidArgument(field, description string) error {
+	return newStatusError(codes.InvalidArgument, "INVALID_ARGUMENT", field, description)
+}
+
+func newStatusError(code codes.Code, reason, field, message string) error {
+	info := &errdetails.ErrorInfo{Reason: reason, Domain: errorDomain}
+	if field != "" {
+		info.Metadata = map[string]string{"field": field}
+	}
+	details := []protoadapt.MessageV1{info}
+	if code == codes.InvalidArgument && field != "" {
+		details = append(details, &errdetails.BadRequest{
+			FieldViolations: []*errdetails.BadRequest_FieldViolation{{Field: field, Description: message}},
+		})
+	}
+
+	st := status.New(code, message)
+	if withDetails, err := st.WithDetails(details...); err == nil {
+		st = withDetails
+	}
+	return st.Err()
+}
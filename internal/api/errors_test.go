@@ -0,0 +1,75 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"testing"
+
+	"github.com/SeiFlow-3P2/board_service/internal/service"
+	"go.mongodb.org/mongo-driver/mongo"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var discard = slog.New(slog.DiscardHandler)
+
+func TestStatusError(t *testing.T) {
+	tests := []struct {
+		err    error
+		code   codes.Code
+		reason string
+	}{
+		{service.ErrUserNotInContext, codes.Unauthenticated, "UNAUTHENTICATED"},
+		{service.ErrPermissionDenied, codes.PermissionDenied, "PERMISSION_DENIED"},
+		{service.ErrNewColumnNotFound, codes.NotFound, "COLUMN_NOT_FOUND"},
+		{fmt.Errorf("failed to delete column: %w", service.ErrInvalidTarget), codes.InvalidArgument, "INVALID_TARGET_COLUMN"},
+		{service.ErrColumnExists, codes.AlreadyExists, "COLUMN_EXISTS"},
+		{mongo.ErrNoDocuments, codes.NotFound, "NOT_FOUND"},
+		{fmt.Errorf("%w: %w", service.ErrGetColumnInfo, mongo.ErrNoDocuments), codes.NotFound, "NOT_FOUND"},
+		{context.DeadlineExceeded, codes.DeadlineExceeded, "DEADLINE_EXCEEDED"},
+		{errors.New("connection pool closed"), codes.Internal, "INTERNAL"},
+	}
+	for _, tt := range tests {
+		st := status.Convert(statusError(context.Background(), discard, tt.err))
+		if st.Code() != tt.code {
+			t.Fatalf("%v: expected %s, got %s", tt.err, tt.code, st.Code())
+		}
+		var info *errdetails.ErrorInfo
+		for _, detail := range st.Details() {
+			if d, ok := detail.(*errdetails.ErrorInfo); ok {
+				info = d
+			}
+		}
+		if info == nil || info.Reason != tt.reason || info.Domain != errorDomain {
+			t.Fatalf("%v: expected reason %s, got %v", tt.err, tt.reason, info)
+		}
+	}
+
+	if st := status.Convert(statusError(context.Background(), discard, errors.New("secret dsn"))); st.Message() != "internal error" {
+		t.Fatalf("expected internal errors to be hidden, got %q", st.Message())
+	}
+	already := status.Error(codes.Aborted, "aborted")
+	if statusError(context.Background(), discard, already) != already {
+		t.Fatal("expected status errors to pass through")
+	}
+}
+
+func TestInvalidArgumentHasFieldViolation(t *testing.T) {
+	st := status.Convert(invalidArgument("deadline", "deadline must be in the future"))
+	if st.Code() != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument, got %s", st.Code())
+	}
+	for _, detail := range st.Details() {
+		if badRequest, ok := detail.(*errdetails.BadRequest); ok {
+			violations := badRequest.GetFieldViolations()
+			if len(violations) != 1 || violations[0].GetField() != "deadline" {
+				t.Fatalf("unexpected violations %v", violations)
+			}
+			return
+		}
+	}
+	t.Fatal("expected a BadRequest detail")
+}
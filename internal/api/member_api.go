@@ -0,0 +1,134 @@
+package api
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/SeiFlow-3P2/board_service/internal/models"
+	"github.com/SeiFlow-3P2/board_service/internal/service"
+	pb "github.com/SeiFlow-3P2/board_service/pkg/proto/v1"
+	"github.com/SeiFlow-3P2/shared/telemetry"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type MemberServiceHandler struct {
+	memberService *service.MemberService
+	logger        *slog.Logger
+}
+
+func NewMemberServiceHandler(memberService *service.MemberService, logger *slog.Logger) *MemberServiceHandler {
+	return &MemberServiceHandler{memberService: memberService, logger: logger}
+}
+
+func memberToResponse(member *models.BoardMember) *pb.BoardMemberResponse {
+	return &pb.BoardMemberResponse{
+		BoardId:   member.Board_id.String(),
+		UserId:    member.User_id,
+		Role:      string(member.Role),
+		CreatedAt: timestamppb.New(member.Created_at),
+	}
+}
+
+func (h *MemberServiceHandler) AddBoardMember(ctx context.Context, req *pb.AddBoardMemberRequest) (*pb.BoardMemberResponse, error) {
+	ctx, span := telemetry.StartSpan(ctx, "MemberHandler.AddBoardMember")
+	defer span.End()
+
+	boardID, err := uuid.Parse(req.BoardId)
+	if err != nil {
+		err := invalidArgument("board_id", "invalid board ID")
+		telemetry.RecordError(span, err)
+		return nil, err
+	}
+
+	member, err := h.memberService.AddBoardMember(ctx, service.AddMemberInput{
+		BoardID: boardID,
+		UserID:  req.UserId,
+		Role:    models.Role(req.Role),
+	})
+	if err != nil {
+		err := statusError(ctx, h.logger, err)
+		telemetry.RecordError(span, err)
+		return nil, err
+	}
+
+	return memberToResponse(member), nil
+}
+
+func (h *MemberServiceHandler) RemoveBoardMember(ctx context.Context, req *pb.RemoveBoardMemberRequest) (*emptypb.Empty, error) {
+	ctx, span := telemetry.StartSpan(ctx, "MemberHandler.RemoveBoardMember")
+	defer span.End()
+
+	boardID, err := uuid.Parse(req.BoardId)
+	if err != nil {
+		err := invalidArgument("board_id", "invalid board ID")
+		telemetry.RecordError(span, err)
+		return nil, err
+	}
+
+	err = h.memberService.RemoveBoardMember(ctx, service.RemoveMemberInput{
+		BoardID: boardID,
+		UserID:  req.UserId,
+	})
+	if err != nil {
+		err := statusError(ctx, h.logger, err)
+		telemetry.RecordError(span, err)
+		return nil, err
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (h *MemberServiceHandler) ListBoardMembers(ctx context.Context, req *pb.ListBoardMembersRequest) (*pb.BoardMembersListResponse, error) {
+	ctx, span := telemetry.StartSpan(ctx, "MemberHandler.ListBoardMembers")
+	defer span.End()
+
+	boardID, err := uuid.Parse(req.BoardId)
+	if err != nil {
+		err := invalidArgument("board_id", "invalid board ID")
+		telemetry.RecordError(span, err)
+		return nil, err
+	}
+
+	members, err := h.memberService.ListBoardMembers(ctx, boardID)
+	if err != nil {
+		err := statusError(ctx, h.logger, err)
+		telemetry.RecordError(span, err)
+		return nil, err
+	}
+
+	response := &pb.BoardMembersListResponse{
+		Members: make([]*pb.BoardMemberResponse, 0, len(members)),
+	}
+	for _, member := range members {
+		response.Members = append(response.Members, memberToResponse(member))
+	}
+
+	return response, nil
+}
+
+func (h *MemberServiceHandler) UpdateMemberRole(ctx context.Context, req *pb.UpdateMemberRoleRequest) (*pb.BoardMemberResponse, error) {
+	ctx, span := telemetry.StartSpan(ctx, "MemberHandler.UpdateMemberRole")
+	defer span.End()
+
+	boardID, err := uuid.Parse(req.BoardId)
+	if err != nil {
+		err := invalidArgument("board_id", "invalid board ID")
+		telemetry.RecordError(span, err)
+		return nil, err
+	}
+
+	member, err := h.memberService.UpdateMemberRole(ctx, service.UpdateMemberRoleInput{
+		BoardID: boardID,
+		UserID:  req.UserId,
+		Role:    models.Role(req.Role),
+	})
+	if err != nil {
+		err := statusError(ctx, h.logger, err)
+		telemetry.RecordError(span, err)
+		return nil, err
+	}
+
+	return memberToResponse(member), nil
+}
@@ -2,6 +2,7 @@ package api
 
 import (
 	"context"
+	"log/slog"
 	"strings"
 	"time"
 
@@ -9,17 +10,16 @@ import (
 	pb "github.com/SeiFlow-3P2/board_service/pkg/proto/v1"
 	"github.com/SeiFlow-3P2/shared/telemetry"
 	"github.com/google/uuid"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
 )
 
 type TaskServiceHandler struct {
 	taskService *service.TaskService
+	logger      *slog.Logger
 }
 
-func NewTaskServiceHandler(taskService *service.TaskService) *TaskServiceHandler {
-	return &TaskServiceHandler{taskService: taskService}
+func NewTaskServiceHandler(taskService *service.TaskService, logger *slog.Logger) *TaskServiceHandler {
+	return &TaskServiceHandler{taskService: taskService, logger: logger}
 }
 
 func (h *TaskServiceHandler) CreateTask(ctx context.Context, req *pb.CreateTaskRequest) (*pb.TaskResponse, error) {
@@ -27,33 +27,33 @@ func (h *TaskServiceHandler) CreateTask(ctx context.Context, req *pb.CreateTaskR
 	defer span.End()
 
 	if strings.TrimSpace(req.Name) == "" {
-		err := status.Error(codes.InvalidArgument, "name is required")
+		err := invalidArgument("name", "name is required")
 		telemetry.RecordError(span, err)
 		return nil, err
 	}
 
 	if strings.TrimSpace(req.Description) == "" {
-		err := status.Error(codes.InvalidArgument, "description is required")
+		err := invalidArgument("description", "description is required")
 		telemetry.RecordError(span, err)
 		return nil, err
 	}
 
 	columnID, err := uuid.Parse(req.ColumnId)
 	if err != nil {
-		err := status.Error(codes.InvalidArgument, "invalid column ID")
+		err := invalidArgument("column_id", "invalid column ID")
 		telemetry.RecordError(span, err)
 		return nil, err
 	}
 
 	deadline, err := time.Parse(time.RFC3339, req.Deadline)
 	if err != nil {
-		err := status.Error(codes.InvalidArgument, "invalid deadline format")
+		err := invalidArgument("deadline", "invalid deadline format")
 		telemetry.RecordError(span, err)
 		return nil, err
 	}
 
 	if deadline.Before(time.Now()) {
-		err := status.Error(codes.InvalidArgument, "deadline must be in the future")
+		err := invalidArgument("deadline", "deadline must be in the future")
 		telemetry.RecordError(span, err)
 		return nil, err
 	}
@@ -66,7 +66,7 @@ func (h *TaskServiceHandler) CreateTask(ctx context.Context, req *pb.CreateTaskR
 		InCalendar:  req.InCalendar,
 	})
 	if err != nil {
-		err := status.Error(codes.Internal, err.Error())
+		err := statusError(ctx, h.logger, err)
 		telemetry.RecordError(span, err)
 		return nil, err
 	}
@@ -78,6 +78,7 @@ func (h *TaskServiceHandler) CreateTask(ctx context.Context, req *pb.CreateTaskR
 		Deadline:    task.Deadline.Format(time.RFC3339),
 		InCalendar:  task.In_Calendar,
 		ColumnId:    task.Column_id.String(),
+		Rank:        task.Rank,
 	}, nil
 }
 
@@ -86,49 +87,63 @@ func (h *TaskServiceHandler) MoveTask(ctx context.Context, req *pb.MoveTaskReque
 	defer span.End()
 
 	if req.TaskId == "" {
-		err := status.Error(codes.InvalidArgument, "task ID is required")
+		err := invalidArgument("task_id", "task ID is required")
 		telemetry.RecordError(span, err)
 		return nil, err
 	}
 
 	taskID, err := uuid.Parse(req.TaskId)
 	if err != nil {
-		err := status.Error(codes.InvalidArgument, "invalid task ID")
+		err := invalidArgument("task_id", "invalid task ID")
 		telemetry.RecordError(span, err)
 		return nil, err
 	}
 
 	newColumnID, err := uuid.Parse(req.NewColumnId)
 	if err != nil {
-		err := status.Error(codes.InvalidArgument, "invalid column ID")
+		err := invalidArgument("new_column_id", "invalid column ID")
 		telemetry.RecordError(span, err)
 		return nil, err
 	}
 
-	task, err := h.taskService.MoveTask(ctx, service.MoveTaskInput{
+	input := service.MoveTaskInput{
 		TaskID:      taskID,
 		NewColumnID: newColumnID,
-	})
-	if err != nil {
-		switch {
-		case err == service.ErrTaskNotFound:
-			err := status.Error(codes.NotFound, "task not found")
-			telemetry.RecordError(span, err)
-			return nil, err
-		case err.Error() == "new column not found":
-			err := status.Error(codes.NotFound, "new column not found")
+	}
+
+	switch placement := req.Placement.(type) {
+	case *pb.MoveTaskRequest_Position:
+		position := int(placement.Position)
+		input.Position = &position
+	case *pb.MoveTaskRequest_BeforeTaskId:
+		beforeID, err := uuid.Parse(placement.BeforeTaskId)
+		if err != nil {
+			err := invalidArgument("before_task_id", "invalid before task ID")
 			telemetry.RecordError(span, err)
 			return nil, err
-		default:
-			err := status.Error(codes.Internal, err.Error())
+		}
+		input.BeforeTaskID = &beforeID
+	case *pb.MoveTaskRequest_AfterTaskId:
+		afterID, err := uuid.Parse(placement.AfterTaskId)
+		if err != nil {
+			err := invalidArgument("after_task_id", "invalid after task ID")
 			telemetry.RecordError(span, err)
 			return nil, err
 		}
+		input.AfterTaskID = &afterID
+	}
+
+	task, err := h.taskService.MoveTask(ctx, input)
+	if err != nil {
+		err := statusError(ctx, h.logger, err)
+		telemetry.RecordError(span, err)
+		return nil, err
 	}
 
 	return &pb.MoveTaskResponse{
 		TaskId:      task.ID.String(),
 		NewColumnId: newColumnID.String(),
+		Rank:        task.Rank,
 	}, nil
 }
 
@@ -137,28 +152,44 @@ func (h *TaskServiceHandler) UpdateTask(ctx context.Context, req *pb.UpdateTaskR
 	defer span.End()
 
 	if req.Id == "" {
-		err := status.Error(codes.InvalidArgument, "task ID is required")
+		err := invalidArgument("id", "task ID is required")
 		telemetry.RecordError(span, err)
 		return nil, err
 	}
 
 	taskID, err := uuid.Parse(req.Id)
 	if err != nil {
-		err := status.Error(codes.InvalidArgument, "invalid task ID")
+		err := invalidArgument("id", "invalid task ID")
 		telemetry.RecordError(span, err)
 		return nil, err
 	}
 
 	if req.Name != nil {
 		if strings.TrimSpace(req.Name.Value) == "" {
-			return nil, status.Error(codes.InvalidArgument, "name is required")
+			return nil, invalidArgument("name", "name is required")
 		}
 	}
 
 	if req.Description != nil {
 		if strings.TrimSpace(req.Description.Value) == "" {
-			return nil, status.Error(codes.InvalidArgument, "description is required")
+			return nil, invalidArgument("description", "description is required")
+		}
+	}
+
+	var deadline *time.Time
+	if req.Deadline != nil {
+		parsed, err := time.Parse(time.RFC3339, req.Deadline.Value)
+		if err != nil {
+			err := invalidArgument("deadline", "invalid deadline format")
+			telemetry.RecordError(span, err)
+			return nil, err
+		}
+		if parsed.Before(time.Now()) {
+			err := invalidArgument("deadline", "deadline must be in the future")
+			telemetry.RecordError(span, err)
+			return nil, err
 		}
+		deadline = &parsed
 	}
 
 	var title, description *string
@@ -168,18 +199,19 @@ func (h *TaskServiceHandler) UpdateTask(ctx context.Context, req *pb.UpdateTaskR
 	if req.Description != nil {
 		description = &req.Description.Value
 	}
+	var inCalendar *bool
+	if req.InCalendar != nil {
+		inCalendar = &req.InCalendar.Value
+	}
 	task, err := h.taskService.UpdateTask(ctx, service.UpdateTaskInput{
 		TaskID:      taskID,
 		Title:       title,
 		Description: description,
+		Deadline:    deadline,
+		InCalendar:  inCalendar,
 	})
 	if err != nil {
-		if err == service.ErrTaskNotFound {
-			err := status.Error(codes.NotFound, "task not found")
-			telemetry.RecordError(span, err)
-			return nil, err
-		}
-		err := status.Error(codes.Internal, err.Error())
+		err := statusError(ctx, h.logger, err)
 		telemetry.RecordError(span, err)
 		return nil, err
 	}
@@ -191,6 +223,7 @@ func (h *TaskServiceHandler) UpdateTask(ctx context.Context, req *pb.UpdateTaskR
 		Deadline:    task.Deadline.Format(time.RFC3339),
 		InCalendar:  task.In_Calendar,
 		ColumnId:    task.Column_id.String(),
+		Rank:        task.Rank,
 	}, nil
 }
 
@@ -199,14 +232,14 @@ func (h *TaskServiceHandler) DeleteTask(ctx context.Context, req *pb.DeleteTaskR
 	defer span.End()
 
 	if req.Id == "" {
-		err := status.Error(codes.InvalidArgument, "task ID is required")
+		err := invalidArgument("id", "task ID is required")
 		telemetry.RecordError(span, err)
 		return nil, err
 	}
 
 	taskID, err := uuid.Parse(req.Id)
 	if err != nil {
-		err := status.Error(codes.InvalidArgument, "invalid task ID")
+		err := invalidArgument("id", "invalid task ID")
 		telemetry.RecordError(span, err)
 		return nil, err
 	}
@@ -215,15 +248,97 @@ func (h *TaskServiceHandler) DeleteTask(ctx context.Context, req *pb.DeleteTaskR
 		TaskID: taskID,
 	})
 	if err != nil {
-		if err == service.ErrTaskNotFound {
-			err := status.Error(codes.NotFound, "task not found")
+		err := statusError(ctx, h.logger, err)
+		telemetry.RecordError(span, err)
+		return nil, err
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (h *TaskServiceHandler) SearchTasks(ctx context.Context, req *pb.SearchTasksRequest) (*pb.SearchTasksResponse, error) {
+	ctx, span := telemetry.StartSpan(ctx, "TaskHandler.SearchTasks")
+	defer span.End()
+
+	if strings.TrimSpace(req.Query) == "" {
+		err := invalidArgument("query", "query is required")
+		telemetry.RecordError(span, err)
+		return nil, err
+	}
+
+	input := service.SearchTasksInput{
+		Query:      req.Query,
+		InCalendar: req.InCalendar,
+		PageSize:   int(req.PageSize),
+		PageToken:  req.PageToken,
+	}
+
+	if req.BoardId != "" {
+		boardID, err := uuid.Parse(req.BoardId)
+		if err != nil {
+			err := invalidArgument("board_id", "invalid board ID")
+			telemetry.RecordError(span, err)
+			return nil, err
+		}
+		input.BoardID = &boardID
+	}
+
+	if req.ColumnId != "" {
+		columnID, err := uuid.Parse(req.ColumnId)
+		if err != nil {
+			err := invalidArgument("column_id", "invalid column ID")
+			telemetry.RecordError(span, err)
+			return nil, err
+		}
+		input.ColumnID = &columnID
+	}
+
+	if req.DeadlineFrom != "" {
+		from, err := time.Parse(time.RFC3339, req.DeadlineFrom)
+		if err != nil {
+			err := invalidArgument("deadline_from", "invalid deadline format")
 			telemetry.RecordError(span, err)
 			return nil, err
 		}
-		err := status.Error(codes.Internal, err.Error())
+		input.DeadlineFrom = &from
+	}
+
+	if req.DeadlineTo != "" {
+		to, err := time.Parse(time.RFC3339, req.DeadlineTo)
+		if err != nil {
+			err := invalidArgument("deadline_to", "invalid deadline format")
+			telemetry.RecordError(span, err)
+			return nil, err
+		}
+		input.DeadlineTo = &to
+	}
+
+	page, err := h.taskService.SearchTasks(ctx, input)
+	if err != nil {
+		err := statusError(ctx, h.logger, err)
 		telemetry.RecordError(span, err)
 		return nil, err
 	}
 
-	return &emptypb.Empty{}, nil
+	response := &pb.SearchTasksResponse{
+		Results:       make([]*pb.TaskSearchResult, 0, len(page.Tasks)),
+		NextPageToken: page.NextPageToken,
+	}
+	for _, match := range page.Tasks {
+		response.Results = append(response.Results, &pb.TaskSearchResult{
+			Task: &pb.TaskResponse{
+				Id:          match.ID.String(),
+				Name:        match.Title,
+				Description: match.Description,
+				Deadline:    match.Deadline.Format(time.RFC3339),
+				InCalendar:  match.In_Calendar,
+				ColumnId:    match.Column_id.String(),
+				Rank:        match.Rank,
+			},
+			BoardId:    match.Board.ID.String(),
+			BoardName:  match.Board.Title,
+			ColumnName: match.Column.Name,
+		})
+	}
+	return response, nil
 }
@@ -3,43 +3,78 @@ package app
 import (
 	"context"
 	"fmt"
-	"log"
+	"log/slog"
 	"net"
+	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
 	"time"
 
 	"github.com/SeiFlow-3P2/board_service/internal/api"
+	"github.com/SeiFlow-3P2/board_service/internal/cache"
 	"github.com/SeiFlow-3P2/board_service/internal/config"
+	"github.com/SeiFlow-3P2/board_service/internal/consumer"
+	"github.com/SeiFlow-3P2/board_service/internal/events"
+	"github.com/SeiFlow-3P2/board_service/internal/gateway"
+	"github.com/SeiFlow-3P2/board_service/internal/health"
 	"github.com/SeiFlow-3P2/board_service/internal/interceptor"
+	"github.com/SeiFlow-3P2/board_service/internal/metrics"
+	"github.com/SeiFlow-3P2/board_service/internal/outbox"
 	"github.com/SeiFlow-3P2/board_service/internal/repository"
 	"github.com/SeiFlow-3P2/board_service/internal/service"
+	"github.com/SeiFlow-3P2/board_service/internal/watch"
 	"github.com/SeiFlow-3P2/board_service/pkg/env"
 	pb "github.com/SeiFlow-3P2/board_service/pkg/proto/v1"
 	"github.com/SeiFlow-3P2/shared/kafka"
 	"github.com/SeiFlow-3P2/shared/telemetry"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
 	"google.golang.org/grpc"
+	grpchealth "google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/reflection"
 )
 
 type Config struct {
-	AppName      string
-	Port         string
+	AppName  string
+	Port     string
+	HTTPPort string
+	// MetricsPort serves /metrics, apart from the public gateway.
+	MetricsPort  string
 	ReadTimeout  time.Duration
 	WriteTimeout time.Duration
 	IdleTimeout  time.Duration
 	MongoURL     string
 	MongoDB      string
+	// EventPublisher is kafka, memory or noop.
+	EventPublisher string
+	KafkaBrokers   []string
+	KafkaGroupID   string
+	// CalendarTopic carries the changes the calendar service makes to the
+	// calendar entries of tasks.
+	CalendarTopic string
+	// UserDeletedTopic carries the user accounts deleted in the auth service.
+	UserDeletedTopic string
+	// Interceptors names the gRPC interceptors from the outermost to the
+	// innermost, see interceptor.ServerOptions.
+	Interceptors []string
+	Auth         interceptor.AuthConfig
+	// RedisAddr is the Redis server that caches board trees. The cache is
+	// disabled if it is empty.
+	RedisAddr     string
+	RedisPassword string
+	CacheTTL      time.Duration
 }
 
 type App struct {
 	config *Config
+	logger *slog.Logger
 }
 
-func New(cfg *Config) *App {
+func New(cfg *Config, logger *slog.Logger) *App {
 	return &App{
 		config: cfg,
+		logger: logger,
 	}
 }
 
@@ -49,6 +84,12 @@ func (a *App) Start(ctx context.Context) error {
 		return fmt.Errorf("failed to connect to MongoDB: %v", err)
 	}
 	db := client.Database(a.config.MongoDB)
+	if err := repository.MigrateEmbeddedColumns(ctx, db, a.logger); err != nil {
+		return err
+	}
+	if err := repository.EnsureIndexes(ctx, db, a.logger); err != nil {
+		return err
+	}
 
 	shutdownTracer, err := telemetry.NewTracerProvider(
 		ctx,
@@ -60,39 +101,127 @@ func (a *App) Start(ctx context.Context) error {
 	}
 	defer func() {
 		if err := shutdownTracer(ctx); err != nil {
-			log.Printf("failed to shutdown tracer: %v", err)
+			a.logger.Error("failed to shutdown tracer", "error", err)
 		}
 	}()
 
-	boardRepo := repository.NewBoardRepository(db)
-	columnRepo := repository.NewColumnRepository(db)
-	taskRepo := repository.NewTaskRepository(db)
+	boardRepo := repository.NewBoardRepository(db, a.logger)
+	columnRepo := repository.NewColumnRepository(db, a.logger)
+	taskRepo := repository.NewTaskRepository(db, a.logger)
+	memberRepo := repository.NewMemberRepository(db, a.logger)
+	outboxRepo := repository.NewOutboxRepository(db, a.logger)
 
-	boardService := service.NewBoardService(boardRepo)
-	columnService := service.NewColumnService(columnRepo, boardRepo)
+	if a.config.RedisAddr != "" {
+		store := cache.NewRedisStore(a.config.RedisAddr, a.config.RedisPassword)
+		defer store.Close()
 
-	p, err := kafka.NewProducer(
-		env.GetKafkaBrokers(),
-	)
+		boardCache := cache.NewBoardCache(store, a.config.CacheTTL, a.logger)
+		boardRepo = boardCache.Boards(boardRepo)
+		taskRepo = boardCache.Tasks(taskRepo, columnRepo)
+		columnRepo = boardCache.Columns(columnRepo)
+	}
+
+	authorizer := service.NewAuthorizer(boardRepo, columnRepo, taskRepo, memberRepo)
+	changes := watch.NewBus()
+
+	publisher, err := events.New(events.Config{
+		Kind:         a.config.EventPublisher,
+		KafkaBrokers: a.config.KafkaBrokers,
+	})
 	if err != nil {
-		return fmt.Errorf("failed to create kafka producer: %v", err)
+		return fmt.Errorf("failed to create event publisher: %v", err)
 	}
-	defer p.Close()
+	defer publisher.Close()
+
+	relayCtx, stopRelay := context.WithCancel(context.Background())
+	relayDone := make(chan struct{})
+	go func() {
+		defer close(relayDone)
+		outbox.NewRelay(outboxRepo, publisher, outbox.DefaultConfig(), a.logger).Run(relayCtx)
+	}()
+	// The publisher is closed after the relay has stopped using it.
+	defer func() {
+		stopRelay()
+		<-relayDone
+	}()
 
-	taskService := service.NewTaskService(taskRepo, columnRepo, p)
+	// Consumers need brokers to read from, so they only run with Kafka.
+	if a.config.EventPublisher == events.PublisherKafka {
+		consumers := map[string]kafka.Handler{
+			a.config.CalendarTopic: consumer.NewCalendarHandler(
+				a.config.CalendarTopic,
+				service.NewCalendarSyncService(taskRepo, authorizer, changes, a.logger),
+				consumer.NewDeadLetterQueue(publisher, consumer.DeadLetterTopic(a.config.CalendarTopic), a.logger),
+				consumer.DefaultRetryConfig(),
+				a.logger,
+			),
+			a.config.UserDeletedTopic: consumer.NewUserDeletedHandler(
+				a.config.UserDeletedTopic,
+				service.NewUserPurgeService(boardRepo, memberRepo, changes, a.logger),
+				consumer.NewDeadLetterQueue(publisher, consumer.DeadLetterTopic(a.config.UserDeletedTopic), a.logger),
+				consumer.DefaultRetryConfig(),
+				a.logger,
+			),
+		}
+		for topic, handler := range consumers {
+			c, err := kafka.NewConsumer(handler, a.config.KafkaBrokers, topic, a.config.KafkaGroupID, 1)
+			if err != nil {
+				return fmt.Errorf("failed to create %s consumer: %v", topic, err)
+			}
+			go c.Start()
+			defer func() {
+				if err := c.Stop(); err != nil {
+					a.logger.Error("failed to stop consumer", "topic", topic, "error", err)
+				}
+			}()
+		}
+	}
 
-	boardServiceHandler := api.NewBoardServiceHandler(boardService)
-	columnServiceHandler := api.NewColumnServiceHandler(columnService)
-	taskServiceHandler := api.NewTaskServiceHandler(taskService)
+	boardService := service.NewBoardService(boardRepo, memberRepo, authorizer, changes, a.logger)
+	memberService := service.NewMemberService(memberRepo, authorizer, changes, a.logger)
+	columnService := service.NewColumnService(columnRepo, authorizer, changes, a.logger)
+	taskService := service.NewTaskService(taskRepo, columnRepo, authorizer, changes, a.logger)
 
-	handler := api.NewHandler(boardServiceHandler, columnServiceHandler, taskServiceHandler)
+	boardServiceHandler := api.NewBoardServiceHandler(boardService, a.logger)
+	memberServiceHandler := api.NewMemberServiceHandler(memberService, a.logger)
+	columnServiceHandler := api.NewColumnServiceHandler(columnService, a.logger)
+	taskServiceHandler := api.NewTaskServiceHandler(taskService, a.logger)
 
-	grpcServer := grpc.NewServer(
-		grpc.UnaryInterceptor(interceptor.AuthUnaryServerInterceptor()),
+	handler := api.NewHandler(
+		boardServiceHandler,
+		memberServiceHandler,
+		columnServiceHandler,
+		taskServiceHandler,
 	)
 
+	authenticator, err := interceptor.NewAuthenticator(a.config.Auth, a.logger)
+	if err != nil {
+		return fmt.Errorf("failed to set up auth: %v", err)
+	}
+	serverOptions, err := interceptor.ServerOptions(a.config.Interceptors, authenticator, a.logger)
+	if err != nil {
+		return fmt.Errorf("failed to set up interceptors: %v", err)
+	}
+	grpcServer := grpc.NewServer(serverOptions...)
+
 	pb.RegisterBoardServiceServer(grpcServer, handler)
 
+	healthServer := grpchealth.NewServer()
+	healthpb.RegisterHealthServer(grpcServer, healthServer)
+
+	checks := map[string]health.Check{
+		"mongo": func(ctx context.Context) error {
+			return client.Ping(ctx, readpref.Primary())
+		},
+	}
+	if checker, ok := publisher.(events.Checker); ok {
+		checks["kafka"] = checker.Check
+	}
+	monitor := health.NewMonitor(healthServer, checks, []string{pb.BoardService_ServiceDesc.ServiceName}, health.DefaultConfig(), a.logger)
+	monitorCtx, stopMonitor := context.WithCancel(ctx)
+	defer stopMonitor()
+	go monitor.Run(monitorCtx)
+
 	reflection.Register(grpcServer)
 
 	l, err := net.Listen("tcp", ":"+a.config.Port)
@@ -102,20 +231,92 @@ func (a *App) Start(ctx context.Context) error {
 
 	serverError := make(chan error, 1)
 	go func() {
-		log.Printf("Starting gRPC server on port %s", a.config.Port)
+		a.logger.Info("starting gRPC server", "port", a.config.Port)
 		serverError <- grpcServer.Serve(l)
 	}()
 
+	gatewayCtx, cancelGateway := context.WithCancel(ctx)
+	defer cancelGateway()
+
+	// Only the trusted gateway mode takes the caller from x-user-id.
+	forwardUserID := a.config.Auth.Mode == interceptor.AuthTrustedGateway
+	gatewayHandler, err := gateway.NewHandler(gatewayCtx, "localhost:"+a.config.Port, forwardUserID, a.logger)
+	if err != nil {
+		grpcServer.Stop()
+		return fmt.Errorf("failed to create gateway: %v", err)
+	}
+
+	httpServer := &http.Server{
+		Addr:         ":" + a.config.HTTPPort,
+		Handler:      gatewayHandler,
+		ReadTimeout:  a.config.ReadTimeout,
+		WriteTimeout: a.config.WriteTimeout,
+		IdleTimeout:  a.config.IdleTimeout,
+	}
+
+	httpServerError := make(chan error, 1)
+	go func() {
+		a.logger.Info("starting HTTP gateway", "port", a.config.HTTPPort)
+		if err := httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			httpServerError <- err
+		}
+	}()
+
+	// Metrics get their own listener, so that they are not exposed through
+	// the public gateway.
+	metricsMux := http.NewServeMux()
+	metricsMux.Handle("/metrics", metrics.Handler())
+	metricsServer := &http.Server{
+		Addr:         ":" + a.config.MetricsPort,
+		Handler:      metricsMux,
+		ReadTimeout:  a.config.ReadTimeout,
+		WriteTimeout: a.config.WriteTimeout,
+		IdleTimeout:  a.config.IdleTimeout,
+	}
+	defer a.shutdownHTTP(metricsServer, "metrics server")
+
+	metricsServerError := make(chan error, 1)
+	go func() {
+		a.logger.Info("starting metrics server", "port", a.config.MetricsPort)
+		if err := metricsServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			metricsServerError <- err
+		}
+	}()
+
 	shutdown := make(chan os.Signal, 1)
 	signal.Notify(shutdown, syscall.SIGINT, syscall.SIGTERM)
 
 	select {
 	case err := <-serverError:
+		healthServer.Shutdown()
+		a.shutdownHTTP(httpServer, "HTTP gateway")
 		return fmt.Errorf("grpc server error: %v", err)
+	case err := <-httpServerError:
+		healthServer.Shutdown()
+		grpcServer.GracefulStop()
+		return fmt.Errorf("http server error: %v", err)
+	case err := <-metricsServerError:
+		healthServer.Shutdown()
+		a.shutdownHTTP(httpServer, "HTTP gateway")
+		grpcServer.GracefulStop()
+		return fmt.Errorf("metrics server error: %v", err)
 	case <-shutdown:
-		log.Println("Shutting down gRPC server...")
+		// Report NOT_SERVING first, so that probes stop routing new calls
+		// here while the running ones drain.
+		healthServer.Shutdown()
+		a.shutdownHTTP(httpServer, "HTTP gateway")
+		a.logger.Info("shutting down gRPC server")
 		grpcServer.GracefulStop()
-		log.Println("gRPC server stopped")
+		a.logger.Info("gRPC server stopped")
 		return nil
 	}
 }
+
+func (a *App) shutdownHTTP(srv *http.Server, name string) {
+	a.logger.Info("shutting down " + name)
+	ctx, cancel := context.WithTimeout(context.Background(), a.config.WriteTimeout)
+	defer cancel()
+	if err := srv.Shutdown(ctx); err != nil {
+		a.logger.Error("failed to shutdown "+name, "error", err)
+	}
+}
@@ -0,0 +1,216 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"time"
+
+	"github.com/SeiFlow-3P2/board_service/internal/models"
+	"github.com/SeiFlow-3P2/board_service/internal/repository"
+	"github.com/SeiFlow-3P2/shared/telemetry"
+	"github.com/google/uuid"
+)
+
+// BoardCache keeps the board trees returned by GetBoardInfo in a Store.
+// Boards, Columns and Tasks wrap the repositories so that every change to a
+// board, its columns or its tasks drops the cached tree. All repositories
+// that change boards must be wrapped, otherwise the cache serves stale trees
+// until they expire. The board of a change is looked up before the change,
+// since deleted columns and tasks cannot be found afterwards, and its tree is
+// dropped after it.
+//
+// The cache is best effort: when the store fails, reads go to the wrapped
+// repository and the error is only logged. A read that races with a change
+// may store the tree from before the change; ttl bounds how long it stays.
+type BoardCache struct {
+	store  Store
+	ttl    time.Duration
+	logger *slog.Logger
+}
+
+func NewBoardCache(store Store, ttl time.Duration, logger *slog.Logger) *BoardCache {
+	return &BoardCache{store: store, ttl: ttl, logger: logger}
+}
+
+func boardKey(id uuid.UUID) string {
+	return "board:" + id.String()
+}
+
+func (c *BoardCache) get(ctx context.Context, id uuid.UUID) (*models.Board, bool) {
+	value, ok, err := c.store.Get(ctx, boardKey(id))
+	if err != nil {
+		c.logger.WarnContext(ctx, "failed to read board from cache", "board_id", id, "error", err)
+		return nil, false
+	}
+	if !ok {
+		return nil, false
+	}
+	var board models.Board
+	if err := json.Unmarshal(value, &board); err != nil {
+		c.logger.WarnContext(ctx, "failed to decode cached board", "board_id", id, "error", err)
+		return nil, false
+	}
+	return &board, true
+}
+
+func (c *BoardCache) set(ctx context.Context, board *models.Board) {
+	value, err := json.Marshal(board)
+	if err != nil {
+		c.logger.WarnContext(ctx, "failed to encode board", "board_id", board.ID, "error", err)
+		return
+	}
+	if err := c.store.Set(ctx, boardKey(board.ID), value, c.ttl); err != nil {
+		c.logger.WarnContext(ctx, "failed to write board to cache", "board_id", board.ID, "error", err)
+	}
+}
+
+// invalidate drops the cached trees of the given boards. Zero IDs, left by
+// failed lookups, are skipped.
+func (c *BoardCache) invalidate(ctx context.Context, ids ...uuid.UUID) {
+	keys := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if id != uuid.Nil {
+			keys = append(keys, boardKey(id))
+		}
+	}
+	if err := c.store.Delete(ctx, keys...); err != nil {
+		c.logger.WarnContext(ctx, "failed to invalidate cached boards", "board_ids", ids, "error", err)
+	}
+}
+
+// Boards wraps repo so that GetBoardInfo reads through the cache.
+func (c *BoardCache) Boards(repo repository.BoardRepository) repository.BoardRepository {
+	return &boardRepository{BoardRepository: repo, cache: c}
+}
+
+// Columns wraps repo so that column changes invalidate their board.
+func (c *BoardCache) Columns(repo repository.ColumnRepository) repository.ColumnRepository {
+	return &columnRepository{ColumnRepository: repo, cache: c}
+}
+
+// Tasks wraps repo so that task changes invalidate their board. The board of
+// a task is looked up through columns.
+func (c *BoardCache) Tasks(repo repository.TaskRepository, columns repository.ColumnRepository) repository.TaskRepository {
+	return &taskRepository{TaskRepository: repo, columns: columns, cache: c}
+}
+
+type boardRepository struct {
+	repository.BoardRepository
+	cache *BoardCache
+}
+
+func (r *boardRepository) GetBoardInfo(ctx context.Context, id uuid.UUID) (*models.Board, error) {
+	ctx, span := telemetry.StartSpan(ctx, "BoardCache.GetBoardInfo")
+	defer span.End()
+
+	if board, ok := r.cache.get(ctx, id); ok {
+		return board, nil
+	}
+	board, err := r.BoardRepository.GetBoardInfo(ctx, id)
+	if err != nil {
+		telemetry.RecordError(span, err)
+		return nil, err
+	}
+	r.cache.set(ctx, board)
+	return board, nil
+}
+
+func (r *boardRepository) UpdateBoard(ctx context.Context, id uuid.UUID, updates *repository.BoardUpdates) (*models.Board, error) {
+	defer r.cache.invalidate(ctx, id)
+	return r.BoardRepository.UpdateBoard(ctx, id, updates)
+}
+
+func (r *boardRepository) DeleteBoard(ctx context.Context, id uuid.UUID, deleteEvents repository.DeleteEvents) error {
+	defer r.cache.invalidate(ctx, id)
+	return r.BoardRepository.DeleteBoard(ctx, id, deleteEvents)
+}
+
+type columnRepository struct {
+	repository.ColumnRepository
+	cache *BoardCache
+}
+
+// boardOf returns the board of the column, or uuid.Nil if it is not found.
+func (r *columnRepository) boardOf(ctx context.Context, id uuid.UUID) uuid.UUID {
+	column, err := r.ColumnRepository.GetColumnInfo(ctx, id)
+	if err != nil {
+		return uuid.Nil
+	}
+	return column.Desk_id
+}
+
+func (r *columnRepository) CreateColumn(ctx context.Context, column *models.Column) (*models.Column, error) {
+	defer r.cache.invalidate(ctx, column.Desk_id)
+	return r.ColumnRepository.CreateColumn(ctx, column)
+}
+
+func (r *columnRepository) UpdateColumn(ctx context.Context, id uuid.UUID, updates *repository.ColumnUpdates) (*models.Column, error) {
+	defer r.cache.invalidate(ctx, r.boardOf(ctx, id))
+	return r.ColumnRepository.UpdateColumn(ctx, id, updates)
+}
+
+func (r *columnRepository) DeleteColumn(ctx context.Context, id uuid.UUID, moveTasksTo *uuid.UUID, deleteEvents repository.DeleteEvents) error {
+	defer r.cache.invalidate(ctx, r.boardOf(ctx, id))
+	return r.ColumnRepository.DeleteColumn(ctx, id, moveTasksTo, deleteEvents)
+}
+
+func (r *columnRepository) MoveColumn(ctx context.Context, id uuid.UUID, orderNumber int) (*models.Column, error) {
+	defer r.cache.invalidate(ctx, r.boardOf(ctx, id))
+	return r.ColumnRepository.MoveColumn(ctx, id, orderNumber)
+}
+
+type taskRepository struct {
+	repository.TaskRepository
+	columns repository.ColumnRepository
+	cache   *BoardCache
+}
+
+// columnBoard returns the board of the column, or uuid.Nil if it is not
+// found.
+func (r *taskRepository) columnBoard(ctx context.Context, columnID uuid.UUID) uuid.UUID {
+	column, err := r.columns.GetColumnInfo(ctx, columnID)
+	if err != nil {
+		return uuid.Nil
+	}
+	return column.Desk_id
+}
+
+// taskBoard returns the board of the task, or uuid.Nil if it is not found.
+func (r *taskRepository) taskBoard(ctx context.Context, id uuid.UUID) uuid.UUID {
+	task, err := r.TaskRepository.GetTask(ctx, id)
+	if err != nil {
+		return uuid.Nil
+	}
+	return r.columnBoard(ctx, task.Column_id)
+}
+
+func (r *taskRepository) CreateTask(ctx context.Context, task *models.Task, events ...*models.OutboxEvent) (*models.Task, error) {
+	defer r.cache.invalidate(ctx, r.columnBoard(ctx, task.Column_id))
+	return r.TaskRepository.CreateTask(ctx, task, events...)
+}
+
+func (r *taskRepository) MoveTask(ctx context.Context, id uuid.UUID, newColumnID uuid.UUID, rank string, events ...*models.OutboxEvent) error {
+	defer r.cache.invalidate(ctx, r.taskBoard(ctx, id), r.columnBoard(ctx, newColumnID))
+	return r.TaskRepository.MoveTask(ctx, id, newColumnID, rank, events...)
+}
+
+func (r *taskRepository) UpdateRanks(ctx context.Context, columnID uuid.UUID, ranks map[uuid.UUID]string) error {
+	defer r.cache.invalidate(ctx, r.columnBoard(ctx, columnID))
+	return r.TaskRepository.UpdateRanks(ctx, columnID, ranks)
+}
+
+func (r *taskRepository) UpdateTask(ctx context.Context, id uuid.UUID, updates *repository.TaskUpdates, events ...*models.OutboxEvent) (*models.Task, error) {
+	defer r.cache.invalidate(ctx, r.taskBoard(ctx, id))
+	return r.TaskRepository.UpdateTask(ctx, id, updates, events...)
+}
+
+func (r *taskRepository) DeleteTask(ctx context.Context, id uuid.UUID, events ...*models.OutboxEvent) error {
+	defer r.cache.invalidate(ctx, r.taskBoard(ctx, id))
+	return r.TaskRepository.DeleteTask(ctx, id, events...)
+}
+
+func (r *taskRepository) ApplyEventOnce(ctx context.Context, eventID uuid.UUID, id uuid.UUID, updates *repository.TaskUpdates) error {
+	defer r.cache.invalidate(ctx, r.taskBoard(ctx, id))
+	return r.TaskRepository.ApplyEventOnce(ctx, eventID, id, updates)
+}
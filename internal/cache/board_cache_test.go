@@ -0,0 +1,278 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/SeiFlow-3P2/board_service/internal/models"
+	"github.com/SeiFlow-3P2/board_service/internal/repository"
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+var testLogger = slog.New(slog.DiscardHandler)
+
+// fakeRepos implements the board, column and task repositories on maps and
+// counts the GetBoardInfo calls and lookups that reach it. Methods the cache
+// does not override are not implemented.
+type fakeRepos struct {
+	boards      map[uuid.UUID]*models.Board
+	columns     map[uuid.UUID]*models.Column
+	tasks       map[uuid.UUID]*models.Task
+	infoCalls   int
+	lookupCalls int
+}
+
+type fakeBoardRepo struct {
+	repository.BoardRepository
+	*fakeRepos
+}
+
+func (r fakeBoardRepo) GetBoardInfo(ctx context.Context, id uuid.UUID) (*models.Board, error) {
+	r.infoCalls++
+	board, ok := r.boards[id]
+	if !ok {
+		return nil, mongo.ErrNoDocuments
+	}
+	tree := *board
+	tree.Columns = nil
+	for _, column := range r.columns {
+		if column.Desk_id != id {
+			continue
+		}
+		withTasks := *column
+		for _, task := range r.tasks {
+			if task.Column_id == column.ID {
+				withTasks.Tasks = append(withTasks.Tasks, *task)
+			}
+		}
+		tree.Columns = append(tree.Columns, withTasks)
+	}
+	return &tree, nil
+}
+
+func (r fakeBoardRepo) UpdateBoard(ctx context.Context, id uuid.UUID, updates *repository.BoardUpdates) (*models.Board, error) {
+	r.boards[id].Title = *updates.Title
+	return r.boards[id], nil
+}
+
+type fakeColumnRepo struct {
+	repository.ColumnRepository
+	*fakeRepos
+}
+
+func (r fakeColumnRepo) GetColumnInfo(ctx context.Context, id uuid.UUID) (*models.Column, error) {
+	r.lookupCalls++
+	column, ok := r.columns[id]
+	if !ok {
+		return nil, mongo.ErrNoDocuments
+	}
+	return column, nil
+}
+
+func (r fakeColumnRepo) DeleteColumn(ctx context.Context, id uuid.UUID, moveTasksTo *uuid.UUID, deleteEvents repository.DeleteEvents) error {
+	delete(r.columns, id)
+	return nil
+}
+
+type fakeTaskRepo struct {
+	repository.TaskRepository
+	*fakeRepos
+}
+
+func (r fakeTaskRepo) GetTask(ctx context.Context, id uuid.UUID) (*models.Task, error) {
+	r.lookupCalls++
+	task, ok := r.tasks[id]
+	if !ok {
+		return nil, mongo.ErrNoDocuments
+	}
+	return task, nil
+}
+
+func (r fakeTaskRepo) CreateTask(ctx context.Context, task *models.Task, events ...*models.OutboxEvent) (*models.Task, error) {
+	r.tasks[task.ID] = task
+	return task, nil
+}
+
+func (r fakeTaskRepo) UpdateRanks(ctx context.Context, columnID uuid.UUID, ranks map[uuid.UUID]string) error {
+	for id, rank := range ranks {
+		r.tasks[id].Rank = rank
+	}
+	return nil
+}
+
+func (r fakeTaskRepo) DeleteTask(ctx context.Context, id uuid.UUID, events ...*models.OutboxEvent) error {
+	delete(r.tasks, id)
+	return nil
+}
+
+type cacheFixture struct {
+	repos    *fakeRepos
+	store    *MemoryStore
+	boards   repository.BoardRepository
+	columns  repository.ColumnRepository
+	tasks    repository.TaskRepository
+	boardID  uuid.UUID
+	columnID uuid.UUID
+	taskID   uuid.UUID
+}
+
+func newCacheFixture(store Store) *cacheFixture {
+	repos := &fakeRepos{
+		boards:  make(map[uuid.UUID]*models.Board),
+		columns: make(map[uuid.UUID]*models.Column),
+		tasks:   make(map[uuid.UUID]*models.Task),
+	}
+	f := &cacheFixture{repos: repos, boardID: uuid.New(), columnID: uuid.New(), taskID: uuid.New()}
+	repos.boards[f.boardID] = &models.Board{ID: f.boardID, Title: "board"}
+	repos.columns[f.columnID] = &models.Column{ID: f.columnID, Name: "To Do", Desk_id: f.boardID}
+	repos.tasks[f.taskID] = &models.Task{ID: f.taskID, Title: "task", Column_id: f.columnID}
+
+	if memory, ok := store.(*MemoryStore); ok {
+		f.store = memory
+	}
+	cache := NewBoardCache(store, time.Minute, testLogger)
+	columns := fakeColumnRepo{fakeRepos: repos}
+	f.boards = cache.Boards(fakeBoardRepo{fakeRepos: repos})
+	f.columns = cache.Columns(columns)
+	f.tasks = cache.Tasks(fakeTaskRepo{fakeRepos: repos}, columns)
+	return f
+}
+
+func (f *cacheFixture) boardInfo(t *testing.T) *models.Board {
+	t.Helper()
+
+	board, err := f.boards.GetBoardInfo(context.Background(), f.boardID)
+	if err != nil {
+		t.Fatalf("GetBoardInfo(): %v", err)
+	}
+	return board
+}
+
+func TestGetBoardInfoReadsThrough(t *testing.T) {
+	f := newCacheFixture(NewMemoryStore())
+
+	first := f.boardInfo(t)
+	second := f.boardInfo(t)
+	if f.repos.infoCalls != 1 {
+		t.Fatalf("expected one repository call, got %d", f.repos.infoCalls)
+	}
+	if len(second.Columns) != 1 || len(second.Columns[0].Tasks) != 1 || second.Columns[0].Tasks[0].ID != first.Columns[0].Tasks[0].ID {
+		t.Fatalf("cached tree differs from the loaded one: %+v", second)
+	}
+
+	if _, err := f.boards.GetBoardInfo(context.Background(), uuid.New()); !errors.Is(err, mongo.ErrNoDocuments) {
+		t.Fatalf("expected ErrNoDocuments for a missing board, got %v", err)
+	}
+	if f.store.Len() != 1 {
+		t.Fatalf("expected only the found board to be cached, got %d entries", f.store.Len())
+	}
+}
+
+func TestMutationsInvalidateTheBoard(t *testing.T) {
+	ctx := context.Background()
+	title := "renamed"
+
+	mutations := map[string]func(f *cacheFixture) error{
+		"UpdateBoard": func(f *cacheFixture) error {
+			_, err := f.boards.UpdateBoard(ctx, f.boardID, &repository.BoardUpdates{Title: &title})
+			return err
+		},
+		"DeleteColumn": func(f *cacheFixture) error {
+			return f.columns.DeleteColumn(ctx, f.columnID, nil, nil)
+		},
+		"CreateTask": func(f *cacheFixture) error {
+			_, err := f.tasks.CreateTask(ctx, &models.Task{ID: uuid.New(), Column_id: f.columnID})
+			return err
+		},
+		"DeleteTask": func(f *cacheFixture) error {
+			return f.tasks.DeleteTask(ctx, f.taskID)
+		},
+	}
+	for name, mutate := range mutations {
+		t.Run(name, func(t *testing.T) {
+			f := newCacheFixture(NewMemoryStore())
+			before := f.boardInfo(t)
+			if err := mutate(f); err != nil {
+				t.Fatalf("%s(): %v", name, err)
+			}
+			if f.store.Len() != 0 {
+				t.Fatalf("%s() left the board cached", name)
+			}
+			after := f.boardInfo(t)
+			if f.repos.infoCalls != 2 {
+				t.Fatalf("expected the board to be loaded again, got %d calls", f.repos.infoCalls)
+			}
+			if after.Title == before.Title && len(after.Columns) == len(before.Columns) &&
+				(len(after.Columns) == 0 || len(after.Columns[0].Tasks) == len(before.Columns[0].Tasks)) {
+				t.Fatalf("%s(): board did not change", name)
+			}
+		})
+	}
+}
+
+func TestUpdateRanksLooksUpTheBoardOnce(t *testing.T) {
+	f := newCacheFixture(NewMemoryStore())
+	ranks := map[uuid.UUID]string{f.taskID: "n"}
+	for i := 0; i < 10; i++ {
+		id := uuid.New()
+		f.repos.tasks[id] = &models.Task{ID: id, Column_id: f.columnID}
+		ranks[id] = "n"
+	}
+	f.boardInfo(t)
+
+	if err := f.tasks.UpdateRanks(context.Background(), f.columnID, ranks); err != nil {
+		t.Fatalf("UpdateRanks(): %v", err)
+	}
+	if f.store.Len() != 0 {
+		t.Fatal("UpdateRanks() left the board cached")
+	}
+	if f.repos.lookupCalls != 1 {
+		t.Fatalf("expected one lookup of the board, got %d", f.repos.lookupCalls)
+	}
+}
+
+// failingStore fails every call, like an unreachable Redis.
+type failingStore struct{}
+
+func (failingStore) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	return nil, false, errors.New("connection refused")
+}
+
+func (failingStore) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	return errors.New("connection refused")
+}
+
+func (failingStore) Delete(ctx context.Context, keys ...string) error {
+	return errors.New("connection refused")
+}
+
+func TestStoreFailuresFallBackToRepository(t *testing.T) {
+	f := newCacheFixture(failingStore{})
+
+	f.boardInfo(t)
+	if err := f.tasks.DeleteTask(context.Background(), f.taskID); err != nil {
+		t.Fatalf("DeleteTask(): %v", err)
+	}
+	if board := f.boardInfo(t); len(board.Columns[0].Tasks) != 0 {
+		t.Fatal("expected the board to be loaded from the repository")
+	}
+	if f.repos.infoCalls != 2 {
+		t.Fatalf("expected every read to reach the repository, got %d calls", f.repos.infoCalls)
+	}
+}
+
+func TestMemoryStoreExpires(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore()
+
+	if err := store.Set(ctx, "key", []byte("value"), -time.Second); err != nil {
+		t.Fatalf("Set(): %v", err)
+	}
+	if _, ok, _ := store.Get(ctx, "key"); ok {
+		t.Fatal("expected the expired value to be gone")
+	}
+}
@@ -0,0 +1,72 @@
+package consumer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+
+	"github.com/SeiFlow-3P2/board_service/internal/models"
+	"github.com/SeiFlow-3P2/board_service/internal/service"
+	"github.com/SeiFlow-3P2/shared/telemetry"
+	"github.com/confluentinc/confluent-kafka-go/kafka"
+)
+
+// CalendarApplier applies calendar events to tasks. *service.CalendarSyncService
+// implements it.
+type CalendarApplier interface {
+	ApplyCalendarEvent(ctx context.Context, event models.CalendarEvent) error
+}
+
+// CalendarHandler consumes the events of the calendar service. Messages that
+// cannot be decoded or applied are moved to the dead-letter queue rather
+// than blocking the partition; failures that may be temporary, such as
+// database errors, are retried first.
+type CalendarHandler struct {
+	topic       string
+	applier     CalendarApplier
+	deadLetters *DeadLetterQueue
+	retry       RetryConfig
+	logger      *slog.Logger
+}
+
+func NewCalendarHandler(topic string, applier CalendarApplier, deadLetters *DeadLetterQueue, retry RetryConfig, logger *slog.Logger) *CalendarHandler {
+	return &CalendarHandler{
+		topic:       topic,
+		applier:     applier,
+		deadLetters: deadLetters,
+		retry:       retry,
+		logger:      logger.With("consumer", "calendar"),
+	}
+}
+
+// HandleMessage implements the Handler of the shared kafka consumer. An
+// error is only returned if the message could not be dead-lettered either.
+// The consumer logs it and goes on, so the message is not redelivered.
+func (h *CalendarHandler) HandleMessage(ctx context.Context, message []byte, offset kafka.Offset, consumerNumber int) error {
+	ctx, span := telemetry.StartSpan(ctx, "CalendarHandler.HandleMessage")
+	defer span.End()
+
+	err := h.handle(ctx, message)
+	if err == nil {
+		return nil
+	}
+	telemetry.RecordError(span, err)
+
+	if err := h.deadLetters.deadLetter(ctx, "calendar", h.topic, int64(offset), message, err); err != nil {
+		telemetry.RecordError(span, err)
+		return err
+	}
+	return nil
+}
+
+func (h *CalendarHandler) handle(ctx context.Context, message []byte) error {
+	var event models.CalendarEvent
+	if err := json.Unmarshal(message, &event); err != nil {
+		return fmt.Errorf("%w: %v", service.ErrInvalidCalendarEvent, err)
+	}
+
+	return h.retry.do(ctx, h.logger, service.ErrInvalidCalendarEvent, func() error {
+		return h.applier.ApplyCalendarEvent(ctx, event)
+	})
+}
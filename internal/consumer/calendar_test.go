@@ -0,0 +1,121 @@
+package consumer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"testing"
+
+	"github.com/SeiFlow-3P2/board_service/internal/events"
+	"github.com/SeiFlow-3P2/board_service/internal/models"
+	"github.com/SeiFlow-3P2/board_service/internal/service"
+	"github.com/google/uuid"
+)
+
+var testLogger = slog.New(slog.DiscardHandler)
+
+// fakeApplier returns the errors in errs one by one and nil after that.
+type fakeApplier struct {
+	errs    []error
+	applied []models.CalendarEvent
+}
+
+func (a *fakeApplier) ApplyCalendarEvent(ctx context.Context, event models.CalendarEvent) error {
+	if len(a.errs) > 0 {
+		err := a.errs[0]
+		a.errs = a.errs[1:]
+		return err
+	}
+	a.applied = append(a.applied, event)
+	return nil
+}
+
+func newTestHandler(applier CalendarApplier) (*CalendarHandler, *events.MemoryPublisher) {
+	publisher := events.NewMemoryPublisher()
+	deadLetters := NewDeadLetterQueue(publisher, DeadLetterTopic("calendar.event"), testLogger)
+	return NewCalendarHandler("calendar.event", applier, deadLetters, RetryConfig{Attempts: 3}, testLogger), publisher
+}
+
+func calendarMessage(t *testing.T) []byte {
+	t.Helper()
+
+	message, err := json.Marshal(models.CalendarEvent{EventID: uuid.New(), EventType: models.EventTypeDelete, TaskID: uuid.New()})
+	if err != nil {
+		t.Fatalf("failed to encode event: %v", err)
+	}
+	return message
+}
+
+func deadLetters(t *testing.T, publisher *events.MemoryPublisher) []DeadLetter {
+	t.Helper()
+
+	var letters []DeadLetter
+	for _, message := range publisher.Messages() {
+		if message.Topic != "calendar.event.dlq" {
+			t.Fatalf("unexpected topic %q", message.Topic)
+		}
+		var letter DeadLetter
+		if err := json.Unmarshal(message.Value, &letter); err != nil {
+			t.Fatalf("failed to decode dead letter: %v", err)
+		}
+		letters = append(letters, letter)
+	}
+	return letters
+}
+
+func TestCalendarHandler(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("applies events", func(t *testing.T) {
+		applier := &fakeApplier{}
+		handler, publisher := newTestHandler(applier)
+		if err := handler.HandleMessage(ctx, calendarMessage(t), 1, 0); err != nil {
+			t.Fatalf("HandleMessage(): %v", err)
+		}
+		if len(applier.applied) != 1 || len(publisher.Messages()) != 0 {
+			t.Fatalf("expected the event to be applied, got %d applied and %d dead letters", len(applier.applied), len(publisher.Messages()))
+		}
+	})
+
+	t.Run("retries temporary failures", func(t *testing.T) {
+		applier := &fakeApplier{errs: []error{errors.New("connection reset"), errors.New("connection reset")}}
+		handler, publisher := newTestHandler(applier)
+		if err := handler.HandleMessage(ctx, calendarMessage(t), 1, 0); err != nil {
+			t.Fatalf("HandleMessage(): %v", err)
+		}
+		if len(applier.applied) != 1 || len(publisher.Messages()) != 0 {
+			t.Fatal("expected the event to be applied on the third attempt")
+		}
+	})
+
+	t.Run("dead-letters after the last attempt", func(t *testing.T) {
+		down := errors.New("connection reset")
+		applier := &fakeApplier{errs: []error{down, down, down}}
+		handler, publisher := newTestHandler(applier)
+		message := calendarMessage(t)
+		if err := handler.HandleMessage(ctx, message, 7, 0); err != nil {
+			t.Fatalf("HandleMessage(): %v", err)
+		}
+		letters := deadLetters(t, publisher)
+		if len(letters) != 1 || letters[0].Offset != 7 || letters[0].Message != string(message) || letters[0].Error != down.Error() {
+			t.Fatalf("unexpected dead letters %+v", letters)
+		}
+	})
+
+	t.Run("dead-letters invalid messages at once", func(t *testing.T) {
+		invalid := fmt.Errorf("%w: unknown event type", service.ErrInvalidCalendarEvent)
+		applier := &fakeApplier{errs: []error{invalid}}
+		handler, publisher := newTestHandler(applier)
+		if err := handler.HandleMessage(ctx, calendarMessage(t), 1, 0); err != nil {
+			t.Fatalf("HandleMessage(): %v", err)
+		}
+		if err := handler.HandleMessage(ctx, []byte("not json"), 2, 0); err != nil {
+			t.Fatalf("HandleMessage(): %v", err)
+		}
+		if letters := deadLetters(t, publisher); len(letters) != 2 || len(applier.applied) != 0 {
+			t.Fatalf("expected two dead letters and no retries, got %+v", letters)
+		}
+	})
+}
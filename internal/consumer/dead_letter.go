@@ -0,0 +1,62 @@
+package consumer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/SeiFlow-3P2/board_service/internal/events"
+)
+
+// DeadLetter wraps a message that could not be processed together with the
+// reason, so that it can be inspected and replayed by hand.
+type DeadLetter struct {
+	Topic    string    `json:"topic"`
+	Offset   int64     `json:"offset"`
+	Message  string    `json:"message"`
+	Error    string    `json:"error"`
+	FailedAt time.Time `json:"failed_at"`
+}
+
+// DeadLetterQueue publishes the messages of a topic that could not be
+// processed to a companion topic.
+type DeadLetterQueue struct {
+	publisher events.EventPublisher
+	topic     string
+	logger    *slog.Logger
+}
+
+func NewDeadLetterQueue(publisher events.EventPublisher, topic string, logger *slog.Logger) *DeadLetterQueue {
+	return &DeadLetterQueue{publisher: publisher, topic: topic, logger: logger}
+}
+
+// DeadLetterTopic returns the dead-letter topic for topic.
+func DeadLetterTopic(topic string) string {
+	return topic + ".dlq"
+}
+
+func (q *DeadLetterQueue) Publish(ctx context.Context, topic string, offset int64, message []byte, reason error) error {
+	letter := DeadLetter{
+		Topic:    topic,
+		Offset:   offset,
+		Message:  string(message),
+		Error:    reason.Error(),
+		FailedAt: time.Now(),
+	}
+	value, err := json.Marshal(letter)
+	if err != nil {
+		return err
+	}
+	return q.publisher.Publish(ctx, q.topic, topic, value)
+}
+
+// deadLetter moves a message that failed with reason to the queue.
+func (q *DeadLetterQueue) deadLetter(ctx context.Context, consumer, topic string, offset int64, message []byte, reason error) error {
+	q.logger.WarnContext(ctx, "dead-lettering message", "consumer", consumer, "topic", topic, "offset", offset, "error", reason)
+	if err := q.Publish(ctx, topic, offset, message, reason); err != nil {
+		return fmt.Errorf("failed to dead-letter message at offset %d: %w", offset, err)
+	}
+	return nil
+}
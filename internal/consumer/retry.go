@@ -0,0 +1,44 @@
+package consumer
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+)
+
+type RetryConfig struct {
+	// Attempts is the number of tries before a message is dead-lettered.
+	Attempts int
+	// Backoff is the pause before the second try and doubles after that.
+	Backoff time.Duration
+}
+
+func DefaultRetryConfig() RetryConfig {
+	return RetryConfig{Attempts: 3, Backoff: 500 * time.Millisecond}
+}
+
+// do calls fn until it succeeds, fails with permanent or the attempts run
+// out, and returns the last error. Failed attempts are logged to logger.
+func (c RetryConfig) do(ctx context.Context, logger *slog.Logger, permanent error, fn func() error) error {
+	backoff := c.Backoff
+	var err error
+	for attempt := 1; attempt <= c.Attempts; attempt++ {
+		err = fn()
+		if err == nil || errors.Is(err, permanent) {
+			return err
+		}
+		if attempt == c.Attempts {
+			break
+		}
+
+		logger.WarnContext(ctx, "attempt failed", "attempt", attempt, "error", err)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+	return err
+}
@@ -0,0 +1,73 @@
+package consumer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+
+	"github.com/SeiFlow-3P2/board_service/internal/models"
+	"github.com/SeiFlow-3P2/board_service/internal/service"
+	"github.com/SeiFlow-3P2/shared/telemetry"
+	"github.com/confluentinc/confluent-kafka-go/kafka"
+)
+
+// UserPurger removes the data of a deleted user. *service.UserPurgeService
+// implements it.
+type UserPurger interface {
+	PurgeUser(ctx context.Context, userID string, progress func(service.PurgeProgress)) error
+}
+
+// UserDeletedHandler consumes the user.deleted events of the auth service
+// and purges the boards of the deleted users. A purge is safe to repeat, so
+// a redelivered event or a replayed dead letter finishes an interrupted one.
+type UserDeletedHandler struct {
+	topic       string
+	purger      UserPurger
+	deadLetters *DeadLetterQueue
+	retry       RetryConfig
+	logger      *slog.Logger
+}
+
+func NewUserDeletedHandler(topic string, purger UserPurger, deadLetters *DeadLetterQueue, retry RetryConfig, logger *slog.Logger) *UserDeletedHandler {
+	return &UserDeletedHandler{
+		topic:       topic,
+		purger:      purger,
+		deadLetters: deadLetters,
+		retry:       retry,
+		logger:      logger.With("consumer", "user"),
+	}
+}
+
+// HandleMessage implements the Handler of the shared kafka consumer in the
+// same way as CalendarHandler.HandleMessage.
+func (h *UserDeletedHandler) HandleMessage(ctx context.Context, message []byte, offset kafka.Offset, consumerNumber int) error {
+	ctx, span := telemetry.StartSpan(ctx, "UserDeletedHandler.HandleMessage")
+	defer span.End()
+
+	err := h.handle(ctx, message)
+	if err == nil {
+		return nil
+	}
+	telemetry.RecordError(span, err)
+
+	if err := h.deadLetters.deadLetter(ctx, "user", h.topic, int64(offset), message, err); err != nil {
+		telemetry.RecordError(span, err)
+		return err
+	}
+	return nil
+}
+
+func (h *UserDeletedHandler) handle(ctx context.Context, message []byte) error {
+	var event models.UserDeletedEvent
+	if err := json.Unmarshal(message, &event); err != nil {
+		return fmt.Errorf("%w: %v", service.ErrInvalidUserEvent, err)
+	}
+
+	h.logger.InfoContext(ctx, "purging boards of user", "user_id", event.UserID)
+	return h.retry.do(ctx, h.logger, service.ErrInvalidUserEvent, func() error {
+		return h.purger.PurgeUser(ctx, event.UserID, func(p service.PurgeProgress) {
+			h.logger.InfoContext(ctx, "deleted board of user", "user_id", p.UserID, "board_id", p.BoardID, "deleted", p.Deleted, "total", p.Total)
+		})
+	})
+}
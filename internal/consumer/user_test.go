@@ -0,0 +1,64 @@
+package consumer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/SeiFlow-3P2/board_service/internal/events"
+	"github.com/SeiFlow-3P2/board_service/internal/models"
+	"github.com/SeiFlow-3P2/board_service/internal/service"
+	"github.com/google/uuid"
+)
+
+// fakePurger fails with the errors in errs one by one and records the users
+// it purged after that.
+type fakePurger struct {
+	errs   []error
+	purged []string
+}
+
+func (p *fakePurger) PurgeUser(ctx context.Context, userID string, progress func(service.PurgeProgress)) error {
+	if len(p.errs) > 0 {
+		err := p.errs[0]
+		p.errs = p.errs[1:]
+		return err
+	}
+	if userID == "" {
+		return service.ErrInvalidUserEvent
+	}
+	p.purged = append(p.purged, userID)
+	progress(service.PurgeProgress{UserID: userID, BoardID: uuid.New(), Deleted: 1, Total: 1})
+	return nil
+}
+
+func TestUserDeletedHandler(t *testing.T) {
+	ctx := context.Background()
+	publisher := events.NewMemoryPublisher()
+	purger := &fakePurger{errs: []error{errors.New("connection reset")}}
+	handler := NewUserDeletedHandler("user.deleted", purger, NewDeadLetterQueue(publisher, DeadLetterTopic("user.deleted"), testLogger), RetryConfig{Attempts: 2}, testLogger)
+
+	message, err := json.Marshal(models.UserDeletedEvent{EventID: uuid.New(), UserID: "owner"})
+	if err != nil {
+		t.Fatalf("failed to encode event: %v", err)
+	}
+	if err := handler.HandleMessage(ctx, message, 1, 0); err != nil {
+		t.Fatalf("HandleMessage(): %v", err)
+	}
+	if len(purger.purged) != 1 || purger.purged[0] != "owner" {
+		t.Fatalf("expected owner to be purged after a retry, got %v", purger.purged)
+	}
+
+	for i, message := range []string{"not json", `{"event_id":"` + uuid.NewString() + `"}`} {
+		if err := handler.HandleMessage(ctx, []byte(message), 2, 0); err != nil {
+			t.Fatalf("HandleMessage(): %v", err)
+		}
+		if got := len(publisher.Messages()); got != i+1 {
+			t.Fatalf("expected %d dead letters, got %d", i+1, got)
+		}
+	}
+	if publisher.Messages()[0].Topic != "user.deleted.dlq" {
+		t.Fatalf("unexpected dead-letter topic %q", publisher.Messages()[0].Topic)
+	}
+}
@@ -0,0 +1,156 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/SeiFlow-3P2/board_service/internal/metrics"
+	"github.com/SeiFlow-3P2/shared/kafka"
+	ckafka "github.com/confluentinc/confluent-kafka-go/kafka"
+)
+
+const (
+	PublisherKafka  = "kafka"
+	PublisherMemory = "memory"
+	PublisherNoop   = "noop"
+)
+
+// defaultTimeout bounds a Kafka publish when the context has no deadline.
+const defaultTimeout = 10 * time.Second
+
+// EventPublisher publishes messages to a topic of the message broker.
+type EventPublisher interface {
+	Publish(ctx context.Context, topic, key string, value []byte) error
+	Close()
+}
+
+type Config struct {
+	// Kind is kafka, memory or noop.
+	Kind         string
+	KafkaBrokers []string
+}
+
+// New returns the publisher selected by cfg.Kind. The memory and noop
+// publishers let the service run without Kafka.
+func New(cfg Config) (EventPublisher, error) {
+	switch cfg.Kind {
+	case PublisherKafka:
+		return NewKafkaPublisher(cfg.KafkaBrokers)
+	case PublisherMemory:
+		return NewMemoryPublisher(), nil
+	case PublisherNoop:
+		return NewNoopPublisher(), nil
+	}
+	return nil, fmt.Errorf("unknown event publisher %q, expected kafka, memory or noop", cfg.Kind)
+}
+
+// Checker is implemented by publishers that can tell whether the broker is
+// reachable.
+type Checker interface {
+	Check(ctx context.Context) error
+}
+
+type kafkaPublisher struct {
+	producer *kafka.Producer
+	// admin reads the cluster metadata for Check, which the shared producer
+	// does not expose.
+	admin *ckafka.AdminClient
+}
+
+func NewKafkaPublisher(brokers []string) (EventPublisher, error) {
+	producer, err := kafka.NewProducer(brokers)
+	if err != nil {
+		return nil, err
+	}
+	admin, err := ckafka.NewAdminClient(&ckafka.ConfigMap{
+		"bootstrap.servers": strings.Join(brokers, ","),
+	})
+	if err != nil {
+		producer.Close()
+		return nil, fmt.Errorf("failed to create admin client: %w", err)
+	}
+	return &kafkaPublisher{producer: producer, admin: admin}, nil
+}
+
+// Publish waits until Kafka acknowledged the message, at most until the
+// context's deadline.
+func (p *kafkaPublisher) Publish(ctx context.Context, topic, key string, value []byte) error {
+	timeout := defaultTimeout
+	if deadline, ok := ctx.Deadline(); ok {
+		timeout = time.Until(deadline)
+	}
+	err := p.producer.Produce(ctx, string(value), topic, key, timeout)
+	metrics.KafkaPublished(topic, err)
+	return err
+}
+
+// Check fetches the cluster metadata, at most until the context's deadline.
+func (p *kafkaPublisher) Check(ctx context.Context) error {
+	timeout := defaultTimeout
+	if deadline, ok := ctx.Deadline(); ok {
+		timeout = time.Until(deadline)
+	}
+	metadata, err := p.admin.GetMetadata(nil, false, int(timeout.Milliseconds()))
+	if err != nil {
+		return err
+	}
+	if len(metadata.Brokers) == 0 {
+		return errors.New("no Kafka brokers available")
+	}
+	return nil
+}
+
+func (p *kafkaPublisher) Close() {
+	p.admin.Close()
+	p.producer.Close()
+}
+
+// Message is a message recorded by MemoryPublisher.
+type Message struct {
+	Topic string
+	Key   string
+	Value []byte
+}
+
+// MemoryPublisher records the published messages instead of sending them.
+type MemoryPublisher struct {
+	mu       sync.Mutex
+	messages []Message
+}
+
+func NewMemoryPublisher() *MemoryPublisher {
+	return &MemoryPublisher{}
+}
+
+func (p *MemoryPublisher) Publish(ctx context.Context, topic, key string, value []byte) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.messages = append(p.messages, Message{Topic: topic, Key: key, Value: value})
+	return nil
+}
+
+// Messages returns the messages published so far, oldest first.
+func (p *MemoryPublisher) Messages() []Message {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]Message(nil), p.messages...)
+}
+
+func (p *MemoryPublisher) Close() {}
+
+type noopPublisher struct{}
+
+// NewNoopPublisher returns a publisher that drops every message.
+func NewNoopPublisher() EventPublisher {
+	return noopPublisher{}
+}
+
+func (noopPublisher) Publish(ctx context.Context, topic, key string, value []byte) error {
+	return nil
+}
+
+func (noopPublisher) Close() {}
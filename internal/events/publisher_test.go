@@ -0,0 +1,35 @@
+package events
+
+import (
+	"context"
+	"testing"
+)
+
+func TestNew(t *testing.T) {
+	for _, kind := range []string{PublisherMemory, PublisherNoop} {
+		publisher, err := New(Config{Kind: kind})
+		if err != nil {
+			t.Fatalf("New(%s): %v", kind, err)
+		}
+		if err := publisher.Publish(context.Background(), "topic", "key", []byte("value")); err != nil {
+			t.Fatalf("%s Publish(): %v", kind, err)
+		}
+		publisher.Close()
+	}
+
+	if _, err := New(Config{Kind: "redis"}); err == nil {
+		t.Fatal("expected an error for an unknown publisher")
+	}
+}
+
+func TestMemoryPublisherRecordsMessages(t *testing.T) {
+	publisher := NewMemoryPublisher()
+	ctx := context.Background()
+	publisher.Publish(ctx, "board.event", "a", []byte("first"))
+	publisher.Publish(ctx, "board.event", "b", []byte("second"))
+
+	messages := publisher.Messages()
+	if len(messages) != 2 || string(messages[0].Value) != "first" || messages[1].Key != "b" {
+		t.Fatalf("unexpected messages %+v", messages)
+	}
+}
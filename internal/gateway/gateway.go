@@ -0,0 +1,114 @@
+package gateway
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	pb "github.com/SeiFlow-3P2/board_service/pkg/proto/v1"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// userIDHeader names the caller in the trusted gateway auth mode.
+const userIDHeader = "x-user-id"
+
+// forwardedHeaders are passed to the gRPC server as metadata under the same key.
+var forwardedHeaders = map[string]struct{}{
+	userIDHeader:   {},
+	"x-request-id": {},
+}
+
+// NewHandler returns an HTTP handler that transcodes REST/JSON requests into
+// calls against the gRPC server listening on grpcAddr.
+//
+// GET /healthz answers with the gRPC health status, for probes that speak
+// HTTP only.
+//
+// The x-user-id header is only forwarded if forwardUserID is set. Otherwise
+// it is dropped, also when sent as Grpc-Metadata-X-User-Id, so that callers
+// cannot name a user of their choice.
+func NewHandler(ctx context.Context, grpcAddr string, forwardUserID bool, logger *slog.Logger) (http.Handler, error) {
+	conn, err := grpc.NewClient(grpcAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, err
+	}
+	go func() {
+		<-ctx.Done()
+		if err := conn.Close(); err != nil {
+			logger.Error("failed to close gateway connection", "addr", grpcAddr, "error", err)
+		}
+	}()
+
+	mux := runtime.NewServeMux(
+		runtime.WithHealthzEndpoint(healthpb.NewHealthClient(conn)),
+		runtime.WithIncomingHeaderMatcher(headerMatcher(forwardUserID)),
+		runtime.WithErrorHandler(errorHandler(logger)),
+		runtime.WithMarshalerOption(runtime.MIMEWildcard, &runtime.JSONPb{
+			MarshalOptions: protojson.MarshalOptions{
+				UseProtoNames:   true,
+				EmitUnpopulated: true,
+			},
+			UnmarshalOptions: protojson.UnmarshalOptions{
+				DiscardUnknown: true,
+			},
+		}),
+	)
+
+	if err := pb.RegisterBoardServiceHandler(ctx, mux, conn); err != nil {
+		return nil, err
+	}
+
+	return streamingHandler(mux, logger), nil
+}
+
+// streamingHandler lifts the server's write timeout for the watch streams,
+// which stay open as long as the client listens.
+func streamingHandler(next http.Handler, logger *slog.Logger) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/watch") {
+			if err := http.NewResponseController(w).SetWriteDeadline(time.Time{}); err != nil {
+				logger.WarnContext(r.Context(), "failed to clear write deadline", "path", r.URL.Path, "error", err)
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func headerMatcher(forwardUserID bool) runtime.HeaderMatcherFunc {
+	return func(key string) (string, bool) {
+		lower := strings.ToLower(key)
+		if !forwardUserID && strings.TrimPrefix(lower, strings.ToLower(runtime.MetadataHeaderPrefix)) == userIDHeader {
+			return "", false
+		}
+		if _, ok := forwardedHeaders[lower]; ok {
+			return lower, true
+		}
+		return runtime.DefaultHeaderMatcher(key)
+	}
+}
+
+// errorHandler writes gRPC errors using the standard code to HTTP status
+// mapping and logs the ones that end up as server errors.
+func errorHandler(logger *slog.Logger) runtime.ErrorHandlerFunc {
+	return func(
+		ctx context.Context,
+		mux *runtime.ServeMux,
+		marshaler runtime.Marshaler,
+		w http.ResponseWriter,
+		r *http.Request,
+		err error,
+	) {
+		st := status.Convert(err)
+		if runtime.HTTPStatusFromCode(st.Code()) >= http.StatusInternalServerError {
+			logger.ErrorContext(ctx, "gateway request failed", "method", r.Method, "path", r.URL.Path, "error", st.Message())
+		}
+		runtime.DefaultHTTPErrorHandler(ctx, mux, marshaler, w, r, err)
+	}
+}
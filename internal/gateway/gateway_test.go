@@ -0,0 +1,23 @@
+package gateway
+
+import "testing"
+
+func TestHeaderMatcher(t *testing.T) {
+	tests := []struct {
+		header        string
+		forwardUserID bool
+		want          string
+		ok            bool
+	}{
+		{header: "X-User-Id", forwardUserID: true, want: "x-user-id", ok: true},
+		{header: "X-User-Id", forwardUserID: false},
+		{header: "Grpc-Metadata-X-User-Id", forwardUserID: false},
+		{header: "X-Request-Id", forwardUserID: false, want: "x-request-id", ok: true},
+	}
+	for _, tt := range tests {
+		got, ok := headerMatcher(tt.forwardUserID)(tt.header)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("headerMatcher(%v)(%q) = %q, %v, want %q, %v", tt.forwardUserID, tt.header, got, ok, tt.want, tt.ok)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package health
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// Check reports whether a dependency of the service works.
+type Check func(ctx context.Context) error
+
+type Config struct {
+	// Interval is the time between two rounds of checks.
+	Interval time.Duration
+	// Timeout bounds each check.
+	Timeout time.Duration
+}
+
+func DefaultConfig() Config {
+	return Config{
+		Interval: 10 * time.Second,
+		Timeout:  3 * time.Second,
+	}
+}
+
+// Monitor runs the checks periodically and reports SERVING through the
+// health server while all of them pass, NOT_SERVING otherwise. The status
+// is set for the whole server ("") and for each of services.
+type Monitor struct {
+	server   *health.Server
+	checks   map[string]Check
+	services []string
+	config   Config
+	logger   *slog.Logger
+}
+
+// NewMonitor reports NOT_SERVING until the first round of checks passed.
+func NewMonitor(server *health.Server, checks map[string]Check, services []string, cfg Config, logger *slog.Logger) *Monitor {
+	m := &Monitor{
+		server:   server,
+		checks:   checks,
+		services: append([]string{""}, services...),
+		config:   cfg,
+		logger:   logger,
+	}
+	for _, service := range m.services {
+		server.SetServingStatus(service, healthpb.HealthCheckResponse_NOT_SERVING)
+	}
+	return m
+}
+
+// Run checks at once and then every interval until ctx is done.
+func (m *Monitor) Run(ctx context.Context) {
+	ticker := time.NewTicker(m.config.Interval)
+	defer ticker.Stop()
+
+	var failing map[string]error
+	for {
+		failing = m.update(ctx, failing)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// update runs the checks, sets the status and logs the checks that started
+// or stopped failing since the previous round. It returns the failing
+// checks.
+func (m *Monitor) update(ctx context.Context, previous map[string]error) map[string]error {
+	failing := m.CheckAll(ctx)
+
+	status := healthpb.HealthCheckResponse_SERVING
+	if len(failing) > 0 {
+		status = healthpb.HealthCheckResponse_NOT_SERVING
+	}
+	for _, service := range m.services {
+		m.server.SetServingStatus(service, status)
+	}
+
+	for name, err := range failing {
+		if _, ok := previous[name]; !ok {
+			m.logger.WarnContext(ctx, "health check failed", "check", name, "error", err)
+		}
+	}
+	for name := range previous {
+		if _, ok := failing[name]; !ok {
+			m.logger.InfoContext(ctx, "health check recovered", "check", name)
+		}
+	}
+	return failing
+}
+
+// CheckAll runs the checks concurrently and returns the errors of the
+// failing ones by name.
+func (m *Monitor) CheckAll(ctx context.Context) map[string]error {
+	type result struct {
+		name string
+		err  error
+	}
+	results := make(chan result, len(m.checks))
+	for name, check := range m.checks {
+		go func() {
+			ctx, cancel := context.WithTimeout(ctx, m.config.Timeout)
+			defer cancel()
+			results <- result{name: name, err: check(ctx)}
+		}()
+	}
+
+	failing := make(map[string]error)
+	for range m.checks {
+		if r := <-results; r.err != nil {
+			failing[r.name] = r.err
+		}
+	}
+	return failing
+}
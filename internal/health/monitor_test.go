@@ -0,0 +1,77 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+var testLogger = slog.New(slog.DiscardHandler)
+
+func status(t *testing.T, server *health.Server, service string) healthpb.HealthCheckResponse_ServingStatus {
+	t.Helper()
+
+	resp, err := server.Check(context.Background(), &healthpb.HealthCheckRequest{Service: service})
+	if err != nil {
+		t.Fatalf("Check(%q): %v", service, err)
+	}
+	return resp.GetStatus()
+}
+
+func TestMonitor(t *testing.T) {
+	ctx := context.Background()
+	server := health.NewServer()
+	var kafkaErr error
+	checks := map[string]Check{
+		"mongo": func(ctx context.Context) error { return nil },
+		"kafka": func(ctx context.Context) error { return kafkaErr },
+	}
+	m := NewMonitor(server, checks, []string{"board_v1.BoardService"}, Config{Interval: time.Hour, Timeout: time.Second}, testLogger)
+
+	if got := status(t, server, ""); got != healthpb.HealthCheckResponse_NOT_SERVING {
+		t.Fatalf("expected NOT_SERVING before the first check, got %s", got)
+	}
+
+	failing := m.update(ctx, nil)
+	for _, service := range []string{"", "board_v1.BoardService"} {
+		if got := status(t, server, service); got != healthpb.HealthCheckResponse_SERVING {
+			t.Fatalf("%q: expected SERVING, got %s", service, got)
+		}
+	}
+
+	kafkaErr = errors.New("no brokers")
+	failing = m.update(ctx, failing)
+	if _, ok := failing["kafka"]; !ok || len(failing) != 1 {
+		t.Fatalf("expected only kafka to fail, got %v", failing)
+	}
+	if got := status(t, server, "board_v1.BoardService"); got != healthpb.HealthCheckResponse_NOT_SERVING {
+		t.Fatalf("expected NOT_SERVING with a failing check, got %s", got)
+	}
+
+	kafkaErr = nil
+	m.update(ctx, failing)
+	server.Shutdown()
+	m.update(ctx, nil)
+	if got := status(t, server, ""); got != healthpb.HealthCheckResponse_NOT_SERVING {
+		t.Fatalf("expected NOT_SERVING after shutdown, got %s", got)
+	}
+}
+
+func TestCheckAllTimesOut(t *testing.T) {
+	checks := map[string]Check{
+		"slow": func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		},
+	}
+	m := NewMonitor(health.NewServer(), checks, nil, Config{Interval: time.Hour, Timeout: 10 * time.Millisecond}, testLogger)
+
+	if err := m.CheckAll(context.Background())["slow"]; !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the check to time out, got %v", err)
+	}
+}
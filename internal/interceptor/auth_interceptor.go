@@ -2,7 +2,11 @@ package interceptor
 
 import (
 	"context"
+	"fmt"
+	"log/slog"
+	"strings"
 
+	"github.com/SeiFlow-3P2/board_service/internal/logging"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
@@ -13,29 +17,127 @@ type contextKey string
 
 const UserIDKey contextKey = "userID"
 
-func AuthUnaryServerInterceptor() grpc.UnaryServerInterceptor {
+// Auth modes.
+const (
+	// AuthTrustedGateway takes the caller from the x-user-id header. Use it
+	// only behind a gateway that authenticates users and sets the header.
+	AuthTrustedGateway = "trusted_gateway"
+	// AuthJWT verifies a bearer token in the authorization header.
+	AuthJWT = "jwt"
+)
+
+// Identity is the authenticated caller.
+type Identity struct {
+	UserID string
+}
+
+// Authenticator identifies the caller of a request from its metadata.
+type Authenticator interface {
+	Authenticate(ctx context.Context) (*Identity, error)
+}
+
+type AuthConfig struct {
+	// Mode is AuthTrustedGateway or AuthJWT.
+	Mode string
+	JWT  JWTConfig
+}
+
+func NewAuthenticator(cfg AuthConfig, logger *slog.Logger) (Authenticator, error) {
+	switch cfg.Mode {
+	case AuthTrustedGateway:
+		return TrustedGatewayAuthenticator{}, nil
+	case AuthJWT:
+		return NewJWTAuthenticator(cfg.JWT, logger)
+	}
+	return nil, fmt.Errorf("unknown auth mode %q", cfg.Mode)
+}
+
+// publicServices are served without authentication, so that probes can
+// check the service's health.
+var publicServices = []string{"/grpc.health.v1.Health/"}
+
+func isPublic(fullMethod string) bool {
+	for _, prefix := range publicServices {
+		if strings.HasPrefix(fullMethod, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func AuthUnaryServerInterceptor(auth Authenticator) grpc.UnaryServerInterceptor {
 	return func(
 		ctx context.Context,
 		req any,
 		info *grpc.UnaryServerInfo,
 		handler grpc.UnaryHandler,
 	) (any, error) {
-		md, ok := metadata.FromIncomingContext(ctx)
-		if !ok {
-			return nil, status.Errorf(codes.Unauthenticated, "metadata is not provided")
+		if isPublic(info.FullMethod) {
+			return handler(ctx, req)
 		}
-
-		userIDValues := md.Get("x-user-id")
-		if len(userIDValues) == 0 {
-			return nil, status.Errorf(codes.Unauthenticated, "x-user-id is not provided")
+		ctx, err := authenticate(ctx, auth)
+		if err != nil {
+			return nil, err
 		}
 
-		var userID string
-		if len(userIDValues) > 0 {
-			userID = userIDValues[0]
-			ctx = context.WithValue(ctx, UserIDKey, userID)
+		return handler(ctx, req)
+	}
+}
+
+func AuthStreamServerInterceptor(auth Authenticator) grpc.StreamServerInterceptor {
+	return func(
+		srv any,
+		ss grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		if isPublic(info.FullMethod) {
+			return handler(srv, ss)
+		}
+		ctx, err := authenticate(ss.Context(), auth)
+		if err != nil {
+			return err
 		}
 
-		return handler(ctx, req)
+		return handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// authenticate stores the caller's user ID in the context.
+func authenticate(ctx context.Context, auth Authenticator) (context.Context, error) {
+	identity, err := auth.Authenticate(ctx)
+	if err != nil {
+		return nil, err
 	}
+
+	recordUser(ctx, identity.UserID)
+	ctx = logging.With(ctx, logging.UserIDKey, identity.UserID)
+	return context.WithValue(ctx, UserIDKey, identity.UserID), nil
+}
+
+// TrustedGatewayAuthenticator trusts the x-user-id header.
+type TrustedGatewayAuthenticator struct{}
+
+func (TrustedGatewayAuthenticator) Authenticate(ctx context.Context) (*Identity, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil, status.Errorf(codes.Unauthenticated, "metadata is not provided")
+	}
+
+	userIDValues := md.Get("x-user-id")
+	if len(userIDValues) == 0 {
+		return nil, status.Errorf(codes.Unauthenticated, "x-user-id is not provided")
+	}
+
+	return &Identity{UserID: userIDValues[0]}, nil
+}
+
+// serverStream replaces the context of a stream.
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
 }
@@ -0,0 +1,86 @@
+package interceptor
+
+import (
+	"fmt"
+	"log/slog"
+
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"google.golang.org/grpc"
+)
+
+// Names of the interceptors that can be put in a chain.
+const (
+	RequestID = "request_id"
+	Recovery  = "recovery"
+	Logging   = "logging"
+	Metrics   = "metrics"
+	Auth      = "auth"
+	// OTel traces the calls. It is installed as a stats handler, which wraps
+	// the whole chain, so its position does not matter.
+	OTel = "otel"
+)
+
+// DefaultOrder logs and measures every call, including the ones that fail
+// auth or panic, with its request ID.
+var DefaultOrder = []string{RequestID, OTel, Metrics, Logging, Recovery, Auth}
+
+type interceptors struct {
+	unary  grpc.UnaryServerInterceptor
+	stream grpc.StreamServerInterceptor
+}
+
+func newInterceptors(name string, auth Authenticator, logger *slog.Logger) (interceptors, bool) {
+	switch name {
+	case RequestID:
+		return interceptors{RequestIDUnaryServerInterceptor(), RequestIDStreamServerInterceptor()}, true
+	case Recovery:
+		return interceptors{RecoveryUnaryServerInterceptor(logger), RecoveryStreamServerInterceptor(logger)}, true
+	case Logging:
+		return interceptors{LoggingUnaryServerInterceptor(logger), LoggingStreamServerInterceptor(logger)}, true
+	case Metrics:
+		return interceptors{MetricsUnaryServerInterceptor(), MetricsStreamServerInterceptor()}, true
+	case Auth:
+		return interceptors{AuthUnaryServerInterceptor(auth), AuthStreamServerInterceptor(auth)}, true
+	}
+	return interceptors{}, false
+}
+
+// ServerOptions chains the named interceptors for unary and streaming calls;
+// the first one is the outermost. Auth is required so that no configuration
+// serves unauthenticated calls; it identifies callers with auth. The logging
+// and recovery interceptors write to logger.
+func ServerOptions(order []string, auth Authenticator, logger *slog.Logger) ([]grpc.ServerOption, error) {
+	var (
+		options []grpc.ServerOption
+		unary   []grpc.UnaryServerInterceptor
+		stream  []grpc.StreamServerInterceptor
+		hasAuth bool
+	)
+	seen := map[string]bool{}
+	for _, name := range order {
+		if seen[name] {
+			return nil, fmt.Errorf("interceptor %q is listed twice", name)
+		}
+		seen[name] = true
+
+		if name == OTel {
+			options = append(options, grpc.StatsHandler(otelgrpc.NewServerHandler()))
+			continue
+		}
+		i, ok := newInterceptors(name, auth, logger)
+		if !ok {
+			return nil, fmt.Errorf("unknown interceptor %q", name)
+		}
+		hasAuth = hasAuth || name == Auth
+		unary = append(unary, i.unary)
+		stream = append(stream, i.stream)
+	}
+	if !hasAuth {
+		return nil, fmt.Errorf("interceptor %q is required", Auth)
+	}
+
+	return append(options,
+		grpc.ChainUnaryInterceptor(unary...),
+		grpc.ChainStreamInterceptor(stream...),
+	), nil
+}
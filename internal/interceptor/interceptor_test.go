@@ -0,0 +1,120 @@
+package interceptor
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+var testLogger = slog.New(slog.DiscardHandler)
+
+// fakeStream is a server stream with a fixed context.
+type fakeStream struct {
+	grpc.ServerStream
+	ctx    context.Context
+	header metadata.MD
+}
+
+func (s *fakeStream) Context() context.Context { return s.ctx }
+
+func (s *fakeStream) SetHeader(md metadata.MD) error {
+	s.header = metadata.Join(s.header, md)
+	return nil
+}
+
+func incoming(pairs ...string) context.Context {
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs(pairs...))
+}
+
+func TestServerOptions(t *testing.T) {
+	if _, err := ServerOptions(DefaultOrder, TrustedGatewayAuthenticator{}, testLogger); err != nil {
+		t.Fatalf("ServerOptions(DefaultOrder): %v", err)
+	}
+	for _, order := range [][]string{
+		{RequestID, Logging},
+		{Auth, "ratelimit"},
+		{Auth, Logging, Auth},
+	} {
+		if _, err := ServerOptions(order, TrustedGatewayAuthenticator{}, testLogger); err == nil {
+			t.Fatalf("expected an error for %v", order)
+		}
+	}
+}
+
+func TestStreamInterceptors(t *testing.T) {
+	info := &grpc.StreamServerInfo{FullMethod: "/board_v1.BoardService/WatchBoard"}
+	chain := []grpc.StreamServerInterceptor{
+		RequestIDStreamServerInterceptor(),
+		LoggingStreamServerInterceptor(testLogger),
+		RecoveryStreamServerInterceptor(testLogger),
+		AuthStreamServerInterceptor(TrustedGatewayAuthenticator{}),
+	}
+	run := func(ctx context.Context, handler grpc.StreamHandler) (*fakeStream, error) {
+		stream := &fakeStream{ctx: ctx}
+		for i := len(chain) - 1; i >= 0; i-- {
+			next, interceptor := handler, chain[i]
+			handler = func(srv any, ss grpc.ServerStream) error {
+				return interceptor(srv, ss, info, next)
+			}
+		}
+		return stream, handler(nil, stream)
+	}
+
+	var userID, requestID string
+	stream, err := run(incoming("x-user-id", "owner", RequestIDHeader, "req-1"), func(srv any, ss grpc.ServerStream) error {
+		userID, _ = ss.Context().Value(UserIDKey).(string)
+		requestID = RequestIDFromContext(ss.Context())
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if userID != "owner" || requestID != "req-1" || stream.header.Get(RequestIDHeader)[0] != "req-1" {
+		t.Fatalf("expected user owner and request req-1, got %q and %q", userID, requestID)
+	}
+
+	_, err = run(incoming(), func(srv any, ss grpc.ServerStream) error {
+		t.Fatal("handler called without a user")
+		return nil
+	})
+	if status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("expected Unauthenticated, got %v", err)
+	}
+
+	stream, err = run(incoming("x-user-id", "owner"), func(srv any, ss grpc.ServerStream) error {
+		panic("boom")
+	})
+	if status.Code(err) != codes.Internal {
+		t.Fatalf("expected Internal after a panic, got %v", err)
+	}
+	if len(stream.header.Get(RequestIDHeader)) != 1 {
+		t.Fatal("expected a generated request ID")
+	}
+}
+
+func TestRecoveryUnaryServerInterceptor(t *testing.T) {
+	info := &grpc.UnaryServerInfo{FullMethod: "/board_v1.BoardService/GetBoards"}
+	_, err := RecoveryUnaryServerInterceptor(testLogger)(context.Background(), nil, info, func(ctx context.Context, req any) (any, error) {
+		panic("boom")
+	})
+	if status.Code(err) != codes.Internal {
+		t.Fatalf("expected Internal after a panic, got %v", err)
+	}
+}
+
+func TestAuthSkipsHealthChecks(t *testing.T) {
+	auth := AuthUnaryServerInterceptor(TrustedGatewayAuthenticator{})
+	handler := func(ctx context.Context, req any) (any, error) { return "ok", nil }
+
+	if _, err := auth(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: "/grpc.health.v1.Health/Check"}, handler); err != nil {
+		t.Fatalf("health check: unexpected error %v", err)
+	}
+	if _, err := auth(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: "/board_v1.BoardService/GetBoardInfo"}, handler); status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("expected Unauthenticated without a user, got %v", err)
+	}
+}
@@ -0,0 +1,244 @@
+package interceptor
+
+import (
+	"context"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"math/big"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"golang.org/x/sync/singleflight"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// jwksRefreshInterval limits how often a JWKS URL is fetched again for a
+// token signed with an unknown key.
+const jwksRefreshInterval = time.Minute
+
+// JWTConfig configures the keys tokens are verified with. HS256 tokens need
+// HMACSecret, RS256 tokens a key from the JWKS file or URL.
+type JWTConfig struct {
+	HMACSecret string
+	JWKSFile   string
+	JWKSURL    string
+	// Issuer and Audience are checked when set.
+	Issuer   string
+	Audience string
+}
+
+// JWTAuthenticator verifies bearer tokens. The subject of the token becomes
+// the user ID; expired tokens and tokens without an expiry are rejected.
+type JWTAuthenticator struct {
+	config  JWTConfig
+	methods []string
+	client  *http.Client
+	logger  *slog.Logger
+
+	mu        sync.Mutex
+	keys      map[string]*rsa.PublicKey
+	fetchedAt time.Time
+	// refresh lets concurrent requests with an unknown key share one fetch.
+	refresh singleflight.Group
+}
+
+func NewJWTAuthenticator(cfg JWTConfig, logger *slog.Logger) (*JWTAuthenticator, error) {
+	a := &JWTAuthenticator{
+		config: cfg,
+		client: &http.Client{Timeout: 10 * time.Second},
+		logger: logger,
+		keys:   map[string]*rsa.PublicKey{},
+	}
+
+	if cfg.HMACSecret != "" {
+		a.methods = append(a.methods, jwt.SigningMethodHS256.Alg())
+	}
+	if cfg.JWKSFile != "" || cfg.JWKSURL != "" {
+		a.methods = append(a.methods, jwt.SigningMethodRS256.Alg())
+	}
+	if len(a.methods) == 0 {
+		return nil, errors.New("jwt auth needs an HMAC secret, a JWKS file or a JWKS URL")
+	}
+
+	if cfg.JWKSFile != "" {
+		data, err := os.ReadFile(cfg.JWKSFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read JWKS file: %w", err)
+		}
+		keys, err := parseJWKS(data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse JWKS file: %w", err)
+		}
+		a.keys = keys
+	}
+	if cfg.JWKSURL != "" {
+		if err := a.fetchKeys(context.Background()); err != nil {
+			return nil, err
+		}
+	}
+	return a, nil
+}
+
+func (a *JWTAuthenticator) Authenticate(ctx context.Context) (*Identity, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get("authorization")
+	if len(values) == 0 {
+		return nil, status.Errorf(codes.Unauthenticated, "authorization is not provided")
+	}
+	raw, ok := strings.CutPrefix(values[0], "Bearer ")
+	if !ok {
+		return nil, status.Errorf(codes.Unauthenticated, "authorization is not a bearer token")
+	}
+
+	options := []jwt.ParserOption{
+		jwt.WithValidMethods(a.methods),
+		jwt.WithExpirationRequired(),
+	}
+	if a.config.Issuer != "" {
+		options = append(options, jwt.WithIssuer(a.config.Issuer))
+	}
+	if a.config.Audience != "" {
+		options = append(options, jwt.WithAudience(a.config.Audience))
+	}
+
+	claims := jwt.MapClaims{}
+	if _, err := jwt.ParseWithClaims(raw, claims, func(token *jwt.Token) (any, error) {
+		return a.key(ctx, token)
+	}, options...); err != nil {
+		if errors.Is(err, jwt.ErrTokenExpired) {
+			return nil, status.Errorf(codes.Unauthenticated, "token is expired")
+		}
+		return nil, status.Errorf(codes.Unauthenticated, "invalid token: %v", err)
+	}
+
+	subject, err := claims.GetSubject()
+	if err != nil || subject == "" {
+		return nil, status.Errorf(codes.Unauthenticated, "token has no subject")
+	}
+	return &Identity{UserID: subject}, nil
+}
+
+// key returns the key that verifies the token's signature.
+func (a *JWTAuthenticator) key(ctx context.Context, token *jwt.Token) (any, error) {
+	if token.Method == jwt.SigningMethodHS256 {
+		return []byte(a.config.HMACSecret), nil
+	}
+
+	kid, _ := token.Header["kid"].(string)
+	if key, ok := a.cachedKey(kid); ok {
+		return key, nil
+	}
+
+	// The issuer may have rotated its keys. Requests that arrive during a
+	// fetch wait for it, and the interval is checked again once they get
+	// their turn, so that the keys are fetched once.
+	if a.config.JWKSURL != "" {
+		_, _, _ = a.refresh.Do("jwks", func() (any, error) {
+			a.mu.Lock()
+			stale := time.Since(a.fetchedAt) > jwksRefreshInterval
+			a.mu.Unlock()
+			if !stale {
+				return nil, nil
+			}
+			if err := a.fetchKeys(ctx); err != nil {
+				a.logger.WarnContext(ctx, "failed to refresh JWKS", "error", err)
+			}
+			return nil, nil
+		})
+		if key, ok := a.cachedKey(kid); ok {
+			return key, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown key %q", kid)
+}
+
+func (a *JWTAuthenticator) cachedKey(kid string) (*rsa.PublicKey, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	key, ok := a.keys[kid]
+	return key, ok
+}
+
+func (a *JWTAuthenticator) fetchKeys(ctx context.Context) error {
+	a.mu.Lock()
+	a.fetchedAt = time.Now()
+	a.mu.Unlock()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, a.config.JWKSURL, nil)
+	if err != nil {
+		return fmt.Errorf("failed to fetch JWKS: %w", err)
+	}
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to fetch JWKS: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to fetch JWKS: %s", resp.Status)
+	}
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to fetch JWKS: %w", err)
+	}
+	keys, err := parseJWKS(data)
+	if err != nil {
+		return fmt.Errorf("failed to parse JWKS: %w", err)
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	for kid, key := range keys {
+		a.keys[kid] = key
+	}
+	return nil
+}
+
+// parseJWKS returns the RSA keys of a JSON Web Key Set by key ID.
+func parseJWKS(data []byte) (map[string]*rsa.PublicKey, error) {
+	var set struct {
+		Keys []struct {
+			Kty string `json:"kty"`
+			Kid string `json:"kid"`
+			Use string `json:"use"`
+			N   string `json:"n"`
+			E   string `json:"e"`
+		} `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, err
+	}
+
+	keys := map[string]*rsa.PublicKey{}
+	for _, k := range set.Keys {
+		if k.Kty != "RSA" || (k.Use != "" && k.Use != "sig") {
+			continue
+		}
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			return nil, fmt.Errorf("key %q: invalid modulus: %w", k.Kid, err)
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil {
+			return nil, fmt.Errorf("key %q: invalid exponent: %w", k.Kid, err)
+		}
+		keys[k.Kid] = &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}
+	}
+	if len(keys) == 0 {
+		return nil, errors.New("no RSA signing keys")
+	}
+	return keys, nil
+}
@@ -0,0 +1,170 @@
+package interceptor
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func bearer(t *testing.T, method jwt.SigningMethod, key any, kid string, claims jwt.MapClaims) context.Context {
+	t.Helper()
+
+	token := jwt.NewWithClaims(method, claims)
+	if kid != "" {
+		token.Header["kid"] = kid
+	}
+	signed, err := token.SignedString(key)
+	if err != nil {
+		t.Fatalf("failed to sign token: %v", err)
+	}
+	return incoming("authorization", "Bearer "+signed)
+}
+
+func jwks(t *testing.T, kid string, key *rsa.PublicKey) []byte {
+	t.Helper()
+
+	data, err := json.Marshal(map[string]any{"keys": []map[string]string{{
+		"kty": "RSA",
+		"kid": kid,
+		"use": "sig",
+		"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+		"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+	}}})
+	if err != nil {
+		t.Fatalf("failed to encode JWKS: %v", err)
+	}
+	return data
+}
+
+func TestJWTAuthenticatorHS256(t *testing.T) {
+	auth, err := NewJWTAuthenticator(JWTConfig{HMACSecret: "secret", Issuer: "auth"}, testLogger)
+	if err != nil {
+		t.Fatalf("NewJWTAuthenticator(): %v", err)
+	}
+	valid := jwt.MapClaims{"sub": "owner", "iss": "auth", "exp": time.Now().Add(time.Hour).Unix()}
+
+	identity, err := auth.Authenticate(bearer(t, jwt.SigningMethodHS256, []byte("secret"), "", valid))
+	if err != nil {
+		t.Fatalf("Authenticate(): %v", err)
+	}
+	if identity.UserID != "owner" {
+		t.Fatalf("unexpected identity %+v", identity)
+	}
+
+	tests := map[string]context.Context{
+		"no token":     incoming("x-user-id", "owner"),
+		"wrong secret": bearer(t, jwt.SigningMethodHS256, []byte("other"), "", valid),
+		"expired":      bearer(t, jwt.SigningMethodHS256, []byte("secret"), "", jwt.MapClaims{"sub": "owner", "iss": "auth", "exp": time.Now().Add(-time.Minute).Unix()}),
+		"no expiry":    bearer(t, jwt.SigningMethodHS256, []byte("secret"), "", jwt.MapClaims{"sub": "owner", "iss": "auth"}),
+		"wrong issuer": bearer(t, jwt.SigningMethodHS256, []byte("secret"), "", jwt.MapClaims{"sub": "owner", "iss": "other", "exp": time.Now().Add(time.Hour).Unix()}),
+		"no subject":   bearer(t, jwt.SigningMethodHS256, []byte("secret"), "", jwt.MapClaims{"iss": "auth", "exp": time.Now().Add(time.Hour).Unix()}),
+	}
+	for name, ctx := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := auth.Authenticate(ctx); status.Code(err) != codes.Unauthenticated {
+				t.Fatalf("expected Unauthenticated, got %v", err)
+			}
+		})
+	}
+}
+
+func TestJWTAuthenticatorRS256(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	claims := jwt.MapClaims{"sub": "owner", "exp": time.Now().Add(time.Hour).Unix()}
+
+	file := filepath.Join(t.TempDir(), "jwks.json")
+	if err := os.WriteFile(file, jwks(t, "file-key", &key.PublicKey), 0o600); err != nil {
+		t.Fatalf("failed to write JWKS: %v", err)
+	}
+	fromFile, err := NewJWTAuthenticator(JWTConfig{JWKSFile: file}, testLogger)
+	if err != nil {
+		t.Fatalf("NewJWTAuthenticator(): %v", err)
+	}
+	identity, err := fromFile.Authenticate(bearer(t, jwt.SigningMethodRS256, key, "file-key", claims))
+	if err != nil {
+		t.Fatalf("Authenticate(): %v", err)
+	}
+	if identity.UserID != "owner" {
+		t.Fatalf("unexpected identity %+v", identity)
+	}
+	if _, err := fromFile.Authenticate(bearer(t, jwt.SigningMethodRS256, key, "unknown", claims)); status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("expected Unauthenticated for an unknown key, got %v", err)
+	}
+	// An HS256 token must not be accepted by an RS256-only authenticator.
+	if _, err := fromFile.Authenticate(bearer(t, jwt.SigningMethodHS256, []byte("secret"), "file-key", claims)); status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("expected Unauthenticated for HS256, got %v", err)
+	}
+
+	kid := "old-key"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(jwks(t, kid, &key.PublicKey))
+	}))
+	defer server.Close()
+	fromURL, err := NewJWTAuthenticator(JWTConfig{JWKSURL: server.URL}, testLogger)
+	if err != nil {
+		t.Fatalf("NewJWTAuthenticator(): %v", err)
+	}
+	if _, err := fromURL.Authenticate(bearer(t, jwt.SigningMethodRS256, key, "old-key", claims)); err != nil {
+		t.Fatalf("Authenticate(): %v", err)
+	}
+
+	// A rotated key is picked up once the refresh interval has passed.
+	kid = "new-key"
+	fromURL.fetchedAt = time.Now().Add(-2 * jwksRefreshInterval)
+	if _, err := fromURL.Authenticate(bearer(t, jwt.SigningMethodRS256, key, "new-key", claims)); err != nil {
+		t.Fatalf("Authenticate() after rotation: %v", err)
+	}
+}
+
+func TestJWTAuthenticatorFetchesOnceForConcurrentRequests(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	var fetches atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fetches.Add(1)
+		time.Sleep(50 * time.Millisecond)
+		_, _ = w.Write(jwks(t, "old-key", &key.PublicKey))
+	}))
+	defer server.Close()
+	auth, err := NewJWTAuthenticator(JWTConfig{JWKSURL: server.URL}, testLogger)
+	if err != nil {
+		t.Fatalf("NewJWTAuthenticator(): %v", err)
+	}
+	auth.fetchedAt = time.Now().Add(-2 * jwksRefreshInterval)
+
+	ctx := bearer(t, jwt.SigningMethodRS256, key, "unknown", jwt.MapClaims{"sub": "owner", "exp": time.Now().Add(time.Hour).Unix()})
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, _ = auth.Authenticate(ctx)
+		}()
+	}
+	wg.Wait()
+
+	// One fetch on start and one for the unknown key.
+	if n := fetches.Load(); n != 2 {
+		t.Fatalf("expected 2 fetches, got %d", n)
+	}
+}
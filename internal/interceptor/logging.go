@@ -0,0 +1,93 @@
+package interceptor
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/SeiFlow-3P2/board_service/internal/logging"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const callKey contextKey = "call"
+
+// call collects what the interceptors inside the logging interceptor learn
+// about the caller, so that it can be logged when the call ends.
+type call struct {
+	userID string
+}
+
+// recordUser notes the authenticated user for the log line of the call.
+func recordUser(ctx context.Context, userID string) {
+	if c, ok := ctx.Value(callKey).(*call); ok {
+		c.userID = userID
+	}
+}
+
+// LoggingUnaryServerInterceptor logs every call with its status code and
+// duration. Records logged inside the call carry its method.
+func LoggingUnaryServerInterceptor(logger *slog.Logger) grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req any,
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (any, error) {
+		c := &call{}
+		ctx = context.WithValue(ctx, callKey, c)
+		ctx = logging.With(ctx, logging.MethodKey, info.FullMethod)
+
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		logCall(ctx, logger, c, start, err)
+		return resp, err
+	}
+}
+
+func LoggingStreamServerInterceptor(logger *slog.Logger) grpc.StreamServerInterceptor {
+	return func(
+		srv any,
+		ss grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		c := &call{}
+		ctx := context.WithValue(ss.Context(), callKey, c)
+		ctx = logging.With(ctx, logging.MethodKey, info.FullMethod)
+
+		start := time.Now()
+		err := handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+		logCall(ctx, logger, c, start, err)
+		return err
+	}
+}
+
+// serverErrors are the codes that point to a fault of the service rather
+// than of the caller; calls that end with them are logged as errors.
+var serverErrors = map[codes.Code]bool{
+	codes.Unknown:     true,
+	codes.Internal:    true,
+	codes.Unavailable: true,
+	codes.DataLoss:    true,
+}
+
+func logCall(ctx context.Context, logger *slog.Logger, c *call, start time.Time, err error) {
+	code := status.Code(err)
+	level := slog.LevelInfo
+	if serverErrors[code] {
+		level = slog.LevelError
+	}
+
+	attrs := []slog.Attr{
+		slog.String("code", code.String()),
+		slog.Duration("duration", time.Since(start)),
+	}
+	// The auth interceptor runs inside this one, so the user is taken from
+	// the call rather than from the context.
+	if c.userID != "" {
+		attrs = append(attrs, slog.String(logging.UserIDKey, c.userID))
+	}
+	logger.LogAttrs(ctx, level, "call finished", attrs...)
+}
@@ -0,0 +1,69 @@
+package logging
+
+import (
+	"context"
+	"io"
+	"log/slog"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Keys of the attributes added to the records.
+const (
+	TraceIDKey   = "trace_id"
+	SpanIDKey    = "span_id"
+	MethodKey    = "rpc_method"
+	RequestIDKey = "request_id"
+	UserIDKey    = "user_id"
+)
+
+// New returns a logger that writes JSON records of at least the given level
+// to w. Records logged with a context carry the trace and span IDs of its
+// span and the attributes added with With.
+func New(w io.Writer, level slog.Level) *slog.Logger {
+	return slog.New(&contextHandler{Handler: slog.NewJSONHandler(w, &slog.HandlerOptions{Level: level})})
+}
+
+type attrsKey struct{}
+
+// With returns a context whose log records carry the given attributes, e.g.
+// the RPC method or the user of a call. args are key-value pairs or
+// slog.Attrs, as for slog.Logger.With.
+func With(ctx context.Context, args ...any) context.Context {
+	var attrs []slog.Attr
+	if parent, ok := ctx.Value(attrsKey{}).([]slog.Attr); ok {
+		attrs = append(attrs, parent...)
+	}
+	record := slog.Record{}
+	record.Add(args...)
+	record.Attrs(func(attr slog.Attr) bool {
+		attrs = append(attrs, attr)
+		return true
+	})
+	return context.WithValue(ctx, attrsKey{}, attrs)
+}
+
+type contextHandler struct {
+	slog.Handler
+}
+
+func (h *contextHandler) Handle(ctx context.Context, record slog.Record) error {
+	if spanContext := trace.SpanContextFromContext(ctx); spanContext.IsValid() {
+		record.AddAttrs(
+			slog.String(TraceIDKey, spanContext.TraceID().String()),
+			slog.String(SpanIDKey, spanContext.SpanID().String()),
+		)
+	}
+	if attrs, ok := ctx.Value(attrsKey{}).([]slog.Attr); ok {
+		record.AddAttrs(attrs...)
+	}
+	return h.Handler.Handle(ctx, record)
+}
+
+func (h *contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &contextHandler{Handler: h.Handler.WithAttrs(attrs)}
+}
+
+func (h *contextHandler) WithGroup(name string) slog.Handler {
+	return &contextHandler{Handler: h.Handler.WithGroup(name)}
+}
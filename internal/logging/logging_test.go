@@ -0,0 +1,45 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestContextAttributes(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(&buf, slog.LevelInfo)
+
+	spanContext := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: trace.TraceID{1},
+		SpanID:  trace.SpanID{2},
+	})
+	ctx := trace.ContextWithSpanContext(context.Background(), spanContext)
+	ctx = With(ctx, MethodKey, "/board_v1.BoardService/GetBoards")
+	ctx = With(ctx, UserIDKey, "owner")
+
+	logger.DebugContext(ctx, "skipped")
+	logger.With("component", "test").InfoContext(ctx, "logged")
+
+	var record map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+		t.Fatalf("expected one JSON record, got %q: %v", buf.String(), err)
+	}
+	want := map[string]string{
+		"msg":       "logged",
+		"component": "test",
+		TraceIDKey:  spanContext.TraceID().String(),
+		SpanIDKey:   spanContext.SpanID().String(),
+		MethodKey:   "/board_v1.BoardService/GetBoards",
+		UserIDKey:   "owner",
+	}
+	for key, value := range want {
+		if record[key] != value {
+			t.Fatalf("expected %s=%q, got %v", key, value, record[key])
+		}
+	}
+}
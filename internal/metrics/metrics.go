@@ -0,0 +1,95 @@
+package metrics
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "board"
+
+// Registry holds the service's metrics together with the Go runtime and
+// process collectors.
+var Registry = prometheus.NewRegistry()
+
+var (
+	rpcDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "grpc_request_duration_seconds",
+		Help:      "Duration of gRPC calls by method and status code.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "code"})
+
+	mongoDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "mongo_operation_duration_seconds",
+		Help:      "Duration of MongoDB operations by repository method.",
+		Buckets:   []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+	}, []string{"operation"})
+
+	kafkaPublished = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "kafka_published_total",
+		Help:      "Messages published to Kafka by topic and result, ok or error.",
+	}, []string{"topic", "result"})
+
+	created = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "created_total",
+		Help:      "Boards, columns and tasks created through the API by kind and board methodology.",
+	}, []string{"kind", "methodology"})
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		rpcDuration,
+		mongoDuration,
+		kafkaPublished,
+		created,
+	)
+}
+
+// Handler serves the metrics in the Prometheus text format.
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{})
+}
+
+// ObserveRPC records a finished gRPC call. code is the name of its status
+// code, e.g. OK or NotFound.
+func ObserveRPC(method, code string, duration time.Duration) {
+	rpcDuration.WithLabelValues(method, code).Observe(duration.Seconds())
+}
+
+// ObserveMongo records a finished repository operation such as
+// BoardRepository.GetBoardInfo.
+func ObserveMongo(operation string, duration time.Duration) {
+	mongoDuration.WithLabelValues(operation).Observe(duration.Seconds())
+}
+
+// KafkaPublished counts a message published to topic, or failed to be if
+// err is not nil.
+func KafkaPublished(topic string, err error) {
+	result := "ok"
+	if err != nil {
+		result = "error"
+	}
+	kafkaPublished.WithLabelValues(topic, result).Inc()
+}
+
+// Kinds of created objects.
+const (
+	KindBoard  = "board"
+	KindColumn = "column"
+	KindTask   = "task"
+)
+
+// Created counts an object of the given kind created on a board with the
+// given methodology.
+func Created(kind, methodology string) {
+	created.WithLabelValues(kind, methodology).Inc()
+}
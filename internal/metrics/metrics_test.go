@@ -0,0 +1,77 @@
+package metrics
+
+import (
+	"errors"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
+)
+
+// sampleCount returns the number of observations of a histogram.
+func sampleCount(t *testing.T, observer prometheus.Observer) uint64 {
+	t.Helper()
+
+	var metric dto.Metric
+	if err := observer.(prometheus.Metric).Write(&metric); err != nil {
+		t.Fatalf("Write(): %v", err)
+	}
+	return metric.GetHistogram().GetSampleCount()
+}
+
+// TestMetrics compares the metrics before and after, because they live in
+// the package's Registry for the whole test binary.
+func TestMetrics(t *testing.T) {
+	rpc := rpcDuration.WithLabelValues("/board_v1.BoardService/GetBoardInfo", "NotFound")
+	mongo := mongoDuration.WithLabelValues("BoardRepository.GetBoardInfo")
+	published := kafkaPublished.WithLabelValues("calendar.event", "ok")
+	failed := kafkaPublished.WithLabelValues("calendar.event", "error")
+	tasks := created.WithLabelValues(KindTask, "kanban")
+
+	rpcBefore, mongoBefore := sampleCount(t, rpc), sampleCount(t, mongo)
+	publishedBefore, failedBefore := testutil.ToFloat64(published), testutil.ToFloat64(failed)
+	tasksBefore := testutil.ToFloat64(tasks)
+
+	ObserveRPC("/board_v1.BoardService/GetBoardInfo", "NotFound", 20*time.Millisecond)
+	ObserveMongo("BoardRepository.GetBoardInfo", 5*time.Millisecond)
+	KafkaPublished("calendar.event", nil)
+	KafkaPublished("calendar.event", errors.New("timeout"))
+	Created(KindTask, "kanban")
+	Created(KindTask, "kanban")
+
+	if got := sampleCount(t, rpc) - rpcBefore; got != 1 {
+		t.Fatalf("expected one observed call, got %v", got)
+	}
+	if got := sampleCount(t, mongo) - mongoBefore; got != 1 {
+		t.Fatalf("expected one observed operation, got %v", got)
+	}
+	if got := testutil.ToFloat64(published) - publishedBefore; got != 1 {
+		t.Fatalf("expected one publish, got %v", got)
+	}
+	if got := testutil.ToFloat64(failed) - failedBefore; got != 1 {
+		t.Fatalf("expected one failed publish, got %v", got)
+	}
+	if got := testutil.ToFloat64(tasks) - tasksBefore; got != 2 {
+		t.Fatalf("expected two created tasks, got %v", got)
+	}
+
+	rec := httptest.NewRecorder()
+	Handler().ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	body, _ := io.ReadAll(rec.Body)
+	for _, want := range []string{
+		`board_grpc_request_duration_seconds_count{code="NotFound",method="/board_v1.BoardService/GetBoardInfo"}`,
+		`board_mongo_operation_duration_seconds_count{operation="BoardRepository.GetBoardInfo"}`,
+		`board_kafka_published_total{result="ok",topic="calendar.event"}`,
+		`board_created_total{kind="task",methodology="kanban"}`,
+		"go_goroutines",
+	} {
+		if !strings.Contains(string(body), want) {
+			t.Errorf("metrics do not contain %q", want)
+		}
+	}
+}
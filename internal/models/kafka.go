@@ -1,11 +1,59 @@
 package models
 
-import "time"
+import (
+	"time"
 
+	"github.com/google/uuid"
+)
+
+const (
+	EventTypeCreate = "create"
+	EventTypeUpdate = "update"
+	EventTypeDelete = "delete"
+)
+
+// BoardEvent tells the calendar service about a task that is in the
+// calendar. EventID is unique per event, so that consumers can drop
+// duplicates; TaskID identifies the calendar entry across events.
 type BoardEvent struct {
+	EventID     uuid.UUID `json:"event_id"`
 	EventType   string    `json:"event_type"`
+	TaskID      uuid.UUID `json:"task_id"`
 	Title       string    `json:"title"`
 	Description string    `json:"description"`
 	Deadline    time.Time `json:"deadline"`
 	UserID      string    `json:"user_id"`
 }
+
+// OutboxEvent is a Kafka message stored in the Outbox collection in the same
+// transaction as the change it reports. The relay publishes it later and
+// sets Sent_at; until then Next_attempt_at says when it is due.
+type OutboxEvent struct {
+	ID              uuid.UUID  `bson:"_id"`
+	Topic           string     `bson:"topic"`
+	Key             string     `bson:"key"`
+	Payload         string     `bson:"payload"`
+	Attempts        int        `bson:"attempts"`
+	Last_error      string     `bson:"last_error,omitempty"`
+	Created_at      time.Time  `bson:"created_at"`
+	Next_attempt_at time.Time  `bson:"next_attempt_at"`
+	Sent_at         *time.Time `bson:"sent_at,omitempty"`
+}
+
+// CalendarEvent is a change the calendar service made to the calendar entry
+// of a task. EventType is update for a moved deadline and delete for an
+// entry that was removed from the calendar.
+type CalendarEvent struct {
+	EventID   uuid.UUID `json:"event_id"`
+	EventType string    `json:"event_type"`
+	TaskID    uuid.UUID `json:"task_id"`
+	Deadline  time.Time `json:"deadline"`
+	UserID    string    `json:"user_id"`
+}
+
+// UserDeletedEvent is published by the auth service when a user account is
+// deleted.
+type UserDeletedEvent struct {
+	EventID uuid.UUID `json:"event_id"`
+	UserID  string    `json:"user_id"`
+}
@@ -36,4 +36,41 @@ type Task struct {
 	Deadline    time.Time `bson:"deadline"`
 	In_Calendar bool      `bson:"in_calendar"`
 	Column_id   uuid.UUID `bson:"column_id"`
+	Rank        string    `bson:"rank"`
+	// Calendar_user_id is the user who put the task in the calendar, whose
+	// calendar receives its events.
+	Calendar_user_id string `bson:"calendar_user_id,omitempty"`
+}
+
+type Role string
+
+const (
+	RoleViewer Role = "viewer"
+	RoleEditor Role = "editor"
+	RoleOwner  Role = "owner"
+)
+
+var roleRanks = map[Role]int{
+	RoleViewer: 1,
+	RoleEditor: 2,
+	RoleOwner:  3,
+}
+
+// Valid reports whether r is one of the known roles.
+func (r Role) Valid() bool {
+	_, ok := roleRanks[r]
+	return ok
+}
+
+// Allows reports whether r grants at least the permissions of required.
+func (r Role) Allows(required Role) bool {
+	return roleRanks[r] >= roleRanks[required]
+}
+
+type BoardMember struct {
+	ID         uuid.UUID `bson:"_id,omitempty"`
+	Board_id   uuid.UUID `bson:"board_id"`
+	User_id    string    `bson:"user_id"`
+	Role       Role      `bson:"role"`
+	Created_at time.Time `bson:"created_at"`
 }
@@ -0,0 +1,137 @@
+package outbox
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/SeiFlow-3P2/board_service/internal/events"
+	"github.com/SeiFlow-3P2/board_service/internal/models"
+	"github.com/SeiFlow-3P2/board_service/internal/repository"
+	"github.com/SeiFlow-3P2/shared/telemetry"
+)
+
+type Config struct {
+	// Interval is the pause between polls when the outbox is drained.
+	Interval time.Duration
+	// BatchSize is the number of events claimed per poll.
+	BatchSize int
+	// Lease is how long a claimed event is hidden from other relays.
+	Lease time.Duration
+	// PublishTimeout bounds a single publish.
+	PublishTimeout time.Duration
+	// MinBackoff and MaxBackoff bound the delay before a failed event is
+	// retried. The delay doubles with every failed attempt.
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+}
+
+func DefaultConfig() Config {
+	return Config{
+		Interval:       time.Second,
+		BatchSize:      100,
+		Lease:          time.Minute,
+		PublishTimeout: 10 * time.Second,
+		MinBackoff:     time.Second,
+		MaxBackoff:     5 * time.Minute,
+	}
+}
+
+// Relay publishes the events of the Outbox collection. Events are only
+// marked as sent after the publisher accepted them, so every event is
+// delivered at least once, also across restarts.
+type Relay struct {
+	repo      repository.OutboxRepository
+	publisher events.EventPublisher
+	config    Config
+	logger    *slog.Logger
+	now       func() time.Time
+}
+
+func NewRelay(repo repository.OutboxRepository, publisher events.EventPublisher, config Config, logger *slog.Logger) *Relay {
+	return &Relay{
+		repo:      repo,
+		publisher: publisher,
+		config:    config,
+		logger:    logger,
+		now:       time.Now,
+	}
+}
+
+// Run relays events until ctx is cancelled.
+func (r *Relay) Run(ctx context.Context) {
+	for {
+		n, err := r.RelayPending(ctx)
+		if err != nil {
+			r.logger.ErrorContext(ctx, "failed to relay outbox events", "error", err)
+		}
+
+		// A full batch suggests that more events are waiting.
+		if err == nil && n == r.config.BatchSize {
+			if ctx.Err() != nil {
+				return
+			}
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(r.config.Interval):
+		}
+	}
+}
+
+// RelayPending publishes the events that are due and returns how many it
+// claimed. Failed events are rescheduled with backoff. The later events of
+// the batch with the same key as a failed one are handed back unpublished,
+// to keep the events of a key in order.
+func (r *Relay) RelayPending(ctx context.Context) (int, error) {
+	ctx, span := telemetry.StartSpan(ctx, "OutboxRelay.RelayPending")
+	defer span.End()
+
+	events, err := r.repo.ClaimPending(ctx, r.now(), r.config.Lease, r.config.BatchSize)
+	if err != nil {
+		telemetry.RecordError(span, err)
+		return 0, err
+	}
+
+	failed := map[string]bool{}
+	for _, event := range events {
+		if failed[event.Key] {
+			err = r.repo.Release(ctx, event.ID, r.now())
+		} else {
+			var sent bool
+			sent, err = r.relay(ctx, event)
+			failed[event.Key] = !sent
+		}
+		if err != nil {
+			telemetry.RecordError(span, err)
+			return len(events), err
+		}
+	}
+	return len(events), nil
+}
+
+// relay publishes the event and reports whether it was sent.
+func (r *Relay) relay(ctx context.Context, event *models.OutboxEvent) (bool, error) {
+	publishCtx, cancel := context.WithTimeout(ctx, r.config.PublishTimeout)
+	err := r.publisher.Publish(publishCtx, event.Topic, event.Key, []byte(event.Payload))
+	cancel()
+	if err != nil {
+		r.logger.WarnContext(ctx, "failed to publish outbox event", "event_id", event.ID, "attempt", event.Attempts+1, "error", err)
+		next := r.now().Add(r.backoff(event.Attempts))
+		return false, r.repo.MarkFailed(ctx, event.ID, next, err.Error())
+	}
+	return true, r.repo.MarkSent(ctx, event.ID, r.now())
+}
+
+// backoff returns the delay before the next attempt of an event that has
+// already failed attempts times.
+func (r *Relay) backoff(attempts int) time.Duration {
+	delay := r.config.MinBackoff
+	for i := 0; i < attempts && delay < r.config.MaxBackoff; i++ {
+		delay *= 2
+	}
+	return min(delay, r.config.MaxBackoff)
+}
@@ -0,0 +1,251 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/SeiFlow-3P2/board_service/internal/events"
+	"github.com/SeiFlow-3P2/board_service/internal/models"
+	"github.com/SeiFlow-3P2/board_service/internal/repository"
+	"github.com/google/uuid"
+)
+
+var testLogger = slog.New(slog.DiscardHandler)
+
+// fakeOutboxRepo keeps the outbox in memory.
+type fakeOutboxRepo struct {
+	mu     sync.Mutex
+	events map[uuid.UUID]*models.OutboxEvent
+}
+
+var _ repository.OutboxRepository = (*fakeOutboxRepo)(nil)
+
+func newFakeOutboxRepo(events ...*models.OutboxEvent) *fakeOutboxRepo {
+	r := &fakeOutboxRepo{events: map[uuid.UUID]*models.OutboxEvent{}}
+	for _, event := range events {
+		r.events[event.ID] = event
+	}
+	return r
+}
+
+func (r *fakeOutboxRepo) ClaimPending(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*models.OutboxEvent, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var unsent []*models.OutboxEvent
+	for _, event := range r.events {
+		if event.Sent_at == nil {
+			unsent = append(unsent, event)
+		}
+	}
+	sort.Slice(unsent, func(i, j int) bool {
+		return unsent[i].Created_at.Before(unsent[j].Created_at)
+	})
+
+	// An event that is not due holds back the later events of its key.
+	claimed := []*models.OutboxEvent{}
+	blocked := map[string]bool{}
+	for _, event := range unsent {
+		if len(claimed) == limit {
+			break
+		}
+		if blocked[event.Key] || event.Next_attempt_at.After(now) {
+			blocked[event.Key] = true
+			continue
+		}
+		event.Next_attempt_at = now.Add(lease)
+		e := *event
+		claimed = append(claimed, &e)
+	}
+	return claimed, nil
+}
+
+func (r *fakeOutboxRepo) MarkSent(ctx context.Context, id uuid.UUID, sentAt time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	event := r.events[id]
+	event.Sent_at = &sentAt
+	event.Attempts++
+	return nil
+}
+
+func (r *fakeOutboxRepo) MarkFailed(ctx context.Context, id uuid.UUID, nextAttemptAt time.Time, reason string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	event := r.events[id]
+	event.Next_attempt_at = nextAttemptAt
+	event.Last_error = reason
+	event.Attempts++
+	return nil
+}
+
+func (r *fakeOutboxRepo) Release(ctx context.Context, id uuid.UUID, nextAttemptAt time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events[id].Next_attempt_at = nextAttemptAt
+	return nil
+}
+
+func (r *fakeOutboxRepo) get(id uuid.UUID) models.OutboxEvent {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return *r.events[id]
+}
+
+// failingPublisher fails while fail is positive and hands the other
+// messages to a MemoryPublisher.
+type failingPublisher struct {
+	*events.MemoryPublisher
+	fail int
+}
+
+func (p *failingPublisher) Publish(ctx context.Context, topic, key string, value []byte) error {
+	if p.fail > 0 {
+		p.fail--
+		return errors.New("broker unavailable")
+	}
+	return p.MemoryPublisher.Publish(ctx, topic, key, value)
+}
+
+func published(publisher *events.MemoryPublisher) []string {
+	var values []string
+	for _, message := range publisher.Messages() {
+		values = append(values, string(message.Value))
+	}
+	return values
+}
+
+func newEvent(payload string, createdAt time.Time) *models.OutboxEvent {
+	return &models.OutboxEvent{
+		ID:              uuid.New(),
+		Topic:           "board.event",
+		Key:             "owner",
+		Payload:         payload,
+		Created_at:      createdAt,
+		Next_attempt_at: createdAt,
+	}
+}
+
+// newTestRelay returns a relay whose clock is controlled by the returned
+// function.
+func newTestRelay(repo repository.OutboxRepository, publisher events.EventPublisher, start time.Time) (*Relay, func(time.Duration)) {
+	relay := NewRelay(repo, publisher, DefaultConfig(), testLogger)
+	now := start
+	relay.now = func() time.Time { return now }
+	return relay, func(d time.Duration) { now = now.Add(d) }
+}
+
+func TestRelayPublishesInOrder(t *testing.T) {
+	start := time.Now()
+	first, second := newEvent("first", start), newEvent("second", start.Add(time.Millisecond))
+	repo := newFakeOutboxRepo(second, first)
+	publisher := events.NewMemoryPublisher()
+	relay, advance := newTestRelay(repo, publisher, start)
+	advance(time.Second)
+
+	n, err := relay.RelayPending(context.Background())
+	if err != nil {
+		t.Fatalf("RelayPending(): %v", err)
+	}
+	if values := published(publisher); n != 2 || len(values) != 2 || values[0] != "first" || values[1] != "second" {
+		t.Fatalf("expected first and second to be published, got %v", values)
+	}
+	if repo.get(first.ID).Sent_at == nil || repo.get(second.ID).Sent_at == nil {
+		t.Fatal("expected the events to be marked as sent")
+	}
+
+	if n, err := relay.RelayPending(context.Background()); err != nil || n != 0 {
+		t.Fatalf("expected nothing left to relay, got %d, %v", n, err)
+	}
+}
+
+func TestRelayRetriesWithBackoff(t *testing.T) {
+	start := time.Now()
+	event := newEvent("event", start)
+	repo := newFakeOutboxRepo(event)
+	publisher := &failingPublisher{MemoryPublisher: events.NewMemoryPublisher(), fail: 2}
+	relay, advance := newTestRelay(repo, publisher, start)
+
+	if _, err := relay.RelayPending(context.Background()); err != nil {
+		t.Fatalf("RelayPending(): %v", err)
+	}
+	stored := repo.get(event.ID)
+	if stored.Sent_at != nil || stored.Attempts != 1 || stored.Last_error == "" {
+		t.Fatalf("expected a failed attempt, got %+v", stored)
+	}
+	if want := start.Add(relay.config.MinBackoff); !stored.Next_attempt_at.Equal(want) {
+		t.Fatalf("expected next attempt at %v, got %v", want, stored.Next_attempt_at)
+	}
+
+	// The event is not due before its backoff has passed.
+	if n, _ := relay.RelayPending(context.Background()); n != 0 {
+		t.Fatalf("expected the event to wait for its backoff, got %d claimed", n)
+	}
+
+	advance(relay.config.MinBackoff)
+	relay.RelayPending(context.Background())
+	stored = repo.get(event.ID)
+	if want := start.Add(3 * relay.config.MinBackoff); stored.Attempts != 2 || !stored.Next_attempt_at.Equal(want) {
+		t.Fatalf("expected the backoff to double, got %+v", stored)
+	}
+
+	// A new relay, as after a restart, picks the event up from the outbox.
+	relay, _ = newTestRelay(repo, publisher, start.Add(3*relay.config.MinBackoff))
+	relay.RelayPending(context.Background())
+	if stored := repo.get(event.ID); stored.Sent_at == nil || stored.Attempts != 3 {
+		t.Fatalf("expected the event to be sent on the third attempt, got %+v", stored)
+	}
+	if values := published(publisher.MemoryPublisher); len(values) != 1 {
+		t.Fatalf("expected one publish, got %v", values)
+	}
+}
+
+func TestRelayKeepsTheOrderOfAKey(t *testing.T) {
+	start := time.Now()
+	create, remove := newEvent("create", start), newEvent("delete", start.Add(time.Millisecond))
+	other := newEvent("other", start.Add(2*time.Millisecond))
+	other.Key = "somebody else"
+	repo := newFakeOutboxRepo(create, remove, other)
+	publisher := &failingPublisher{MemoryPublisher: events.NewMemoryPublisher(), fail: 1}
+	relay, advance := newTestRelay(repo, publisher, start)
+	advance(time.Second)
+
+	// The delete must not overtake the failed create of the same key.
+	if _, err := relay.RelayPending(context.Background()); err != nil {
+		t.Fatalf("RelayPending(): %v", err)
+	}
+	if values := published(publisher.MemoryPublisher); len(values) != 1 || values[0] != "other" {
+		t.Fatalf("expected only the event of the other key to be published, got %v", values)
+	}
+	if stored := repo.get(remove.ID); stored.Sent_at != nil || stored.Attempts != 0 {
+		t.Fatalf("expected the delete to be handed back without an attempt, got %+v", stored)
+	}
+
+	// While the create waits for its backoff, the delete stays behind it.
+	if n, _ := relay.RelayPending(context.Background()); n != 0 {
+		t.Fatalf("expected the delete to wait for the create, got %d claimed", n)
+	}
+
+	advance(relay.config.MinBackoff)
+	if _, err := relay.RelayPending(context.Background()); err != nil {
+		t.Fatalf("RelayPending(): %v", err)
+	}
+	values := published(publisher.MemoryPublisher)
+	if len(values) != 3 || values[1] != "create" || values[2] != "delete" {
+		t.Fatalf("expected the create before the delete, got %v", values)
+	}
+}
+
+func TestBackoffIsCapped(t *testing.T) {
+	relay := NewRelay(nil, nil, DefaultConfig(), testLogger)
+	if got := relay.backoff(0); got != relay.config.MinBackoff {
+		t.Fatalf("expected %v, got %v", relay.config.MinBackoff, got)
+	}
+	if got := relay.backoff(100); got != relay.config.MaxBackoff {
+		t.Fatalf("expected %v, got %v", relay.config.MaxBackoff, got)
+	}
+}
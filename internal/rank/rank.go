@@ -0,0 +1,130 @@
+// Package rank generates lexicographically sortable position keys.
+//
+// A rank is a base-36 fraction written without the leading "0." and without
+// trailing zeros, so comparing two ranks as strings compares their numeric
+// values. There is always room for another rank between two distinct ranks,
+// which lets an item be repositioned by rewriting only its own rank.
+package rank
+
+import (
+	"errors"
+	"strings"
+)
+
+const digits = "0123456789abcdefghijklmnopqrstuvwxyz"
+
+const base = len(digits)
+
+var ErrInvalidRange = errors.New("rank: lower bound must be less than upper bound")
+
+// Between returns a rank strictly greater than prev and strictly less than
+// next. An empty prev means "before everything", an empty next means "after
+// everything".
+func Between(prev, next string) (string, error) {
+	if !valid(prev) || !valid(next) {
+		return "", ErrInvalidRange
+	}
+	if next != "" && prev >= next {
+		return "", ErrInvalidRange
+	}
+	return midpoint(prev, next), nil
+}
+
+// Spread returns n increasing ranks spaced evenly across the key space. It is
+// used to assign fresh ranks to a whole list at once.
+func Spread(n int) []string {
+	// Use enough digits for the spacing between neighbours to survive
+	// truncation.
+	width := 1
+	for capacity := base; capacity <= n+1; capacity *= base {
+		width++
+	}
+
+	ranks := make([]string, n)
+	for i := range ranks {
+		ranks[i] = encode(i+1, n+1, width)
+	}
+	return ranks
+}
+
+// After returns n increasing ranks greater than prev, spaced evenly below a
+// single rank after prev. Unlike chaining Between, their length grows with
+// the logarithm of n only.
+func After(prev string, n int) ([]string, error) {
+	start, err := Between(prev, "")
+	if err != nil {
+		return nil, err
+	}
+	ranks := Spread(n)
+	for i := range ranks {
+		ranks[i] = start + ranks[i]
+	}
+	return ranks, nil
+}
+
+// midpoint assumes prev < next (with "" as next meaning +infinity).
+func midpoint(prev, next string) string {
+	n := 0
+	for n < len(next) && digitAt(prev, n) == index(next[n]) {
+		n++
+	}
+	if n > 0 {
+		return next[:n] + midpoint(tail(prev, n), next[n:])
+	}
+
+	lo := digitAt(prev, 0)
+	hi := base
+	if next != "" {
+		hi = index(next[0])
+	}
+
+	if hi-lo > 1 {
+		return string(digits[(lo+hi)/2])
+	}
+	if len(next) > 1 {
+		return next[:1]
+	}
+	return string(digits[lo]) + midpoint(tail(prev, 1), "")
+}
+
+// encode writes the fraction num/den as a base-36 key of at most width
+// digits with trailing zeros removed.
+func encode(num, den, width int) string {
+	var b strings.Builder
+	for i := 0; i < width && num != 0; i++ {
+		num *= base
+		b.WriteByte(digits[num/den])
+		num %= den
+	}
+	return strings.TrimRight(b.String(), "0")
+}
+
+func valid(r string) bool {
+	if strings.HasSuffix(r, "0") {
+		return false
+	}
+	for i := 0; i < len(r); i++ {
+		if index(r[i]) < 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func index(c byte) int {
+	return strings.IndexByte(digits, c)
+}
+
+func digitAt(r string, i int) int {
+	if i < len(r) {
+		return index(r[i])
+	}
+	return 0
+}
+
+func tail(r string, n int) string {
+	if n < len(r) {
+		return r[n:]
+	}
+	return ""
+}
@@ -0,0 +1,118 @@
+package rank
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+func TestBetween(t *testing.T) {
+	tests := []struct {
+		prev, next string
+	}{
+		{"", ""},
+		{"", "1"},
+		{"", "01"},
+		{"i", ""},
+		{"z", ""},
+		{"zz", ""},
+		{"a", "b"},
+		{"a", "a1"},
+		{"a1", "a2"},
+		{"0z", "1"},
+		{"i", "j"},
+		{"abc", "abd"},
+	}
+
+	for _, tt := range tests {
+		got, err := Between(tt.prev, tt.next)
+		if err != nil {
+			t.Fatalf("Between(%q, %q): %v", tt.prev, tt.next, err)
+		}
+		if got <= tt.prev || (tt.next != "" && got >= tt.next) {
+			t.Errorf("Between(%q, %q) = %q, not strictly between", tt.prev, tt.next, got)
+		}
+		if !valid(got) {
+			t.Errorf("Between(%q, %q) = %q, not a valid rank", tt.prev, tt.next, got)
+		}
+	}
+}
+
+func TestBetweenInvalidRange(t *testing.T) {
+	for _, tt := range [][2]string{{"b", "a"}, {"a", "a"}, {"a0", "b"}, {"A", ""}} {
+		if _, err := Between(tt[0], tt[1]); err != ErrInvalidRange {
+			t.Errorf("Between(%q, %q): expected ErrInvalidRange, got %v", tt[0], tt[1], err)
+		}
+	}
+}
+
+func TestRandomInsertionsKeepOrder(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	ranks := []string{}
+
+	for i := 0; i < 2000; i++ {
+		pos := rng.Intn(len(ranks) + 1)
+		prev, next := "", ""
+		if pos > 0 {
+			prev = ranks[pos-1]
+		}
+		if pos < len(ranks) {
+			next = ranks[pos]
+		}
+
+		r, err := Between(prev, next)
+		if err != nil {
+			t.Fatalf("Between(%q, %q): %v", prev, next, err)
+		}
+		ranks = append(ranks[:pos], append([]string{r}, ranks[pos:]...)...)
+	}
+
+	if !sort.StringsAreSorted(ranks) {
+		t.Fatal("ranks are not sorted after random insertions")
+	}
+	for i := 1; i < len(ranks); i++ {
+		if ranks[i] == ranks[i-1] {
+			t.Fatalf("duplicate rank %q", ranks[i])
+		}
+	}
+}
+
+func TestSpread(t *testing.T) {
+	for _, n := range []int{0, 1, 3, 35, 36, 37, 500, 2000} {
+		ranks := Spread(n)
+		if len(ranks) != n {
+			t.Fatalf("Spread(%d) returned %d ranks", n, len(ranks))
+		}
+		for i, r := range ranks {
+			if !valid(r) || r == "" {
+				t.Fatalf("Spread(%d)[%d] = %q is not a valid rank", n, i, r)
+			}
+			if i > 0 && r <= ranks[i-1] {
+				t.Fatalf("Spread(%d) is not strictly increasing at %d: %q <= %q", n, i, r, ranks[i-1])
+			}
+		}
+	}
+}
+
+func TestAfter(t *testing.T) {
+	for _, prev := range []string{"", "i", "zz"} {
+		ranks, err := After(prev, 2000)
+		if err != nil {
+			t.Fatalf("After(%q): %v", prev, err)
+		}
+		for i, r := range ranks {
+			if !valid(r) || r <= prev {
+				t.Fatalf("After(%q)[%d] = %q is not a valid rank after %q", prev, i, r, prev)
+			}
+			if i > 0 && r <= ranks[i-1] {
+				t.Fatalf("After(%q) is not strictly increasing at %d: %q <= %q", prev, i, r, ranks[i-1])
+			}
+			if len(r) > len(prev)+4 {
+				t.Fatalf("After(%q)[%d] = %q is longer than expected", prev, i, r)
+			}
+		}
+	}
+	if _, err := After("i0", 1); err != ErrInvalidRange {
+		t.Fatalf("expected ErrInvalidRange, got %v", err)
+	}
+}
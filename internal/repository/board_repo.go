@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"log/slog"
 	"time"
 
 	"github.com/SeiFlow-3P2/board_service/internal/models"
@@ -14,12 +15,12 @@ import (
 
 type BoardRepository interface {
 	CreateBoard(ctx context.Context, board *models.Board) (*models.Board, error)
+	GetBoard(ctx context.Context, id uuid.UUID) (*models.Board, error)
 	GetBoardInfo(ctx context.Context, id uuid.UUID) (*models.Board, error)
 	GetBoards(ctx context.Context, userID string) ([]*models.Board, error)
+	ListBoards(ctx context.Context, query *BoardQuery) ([]*models.Board, error)
 	UpdateBoard(ctx context.Context, id uuid.UUID, updates *BoardUpdates) (*models.Board, error)
-	DeleteBoard(ctx context.Context, id uuid.UUID) error
-	IncrementColumnsAmount(ctx context.Context, id uuid.UUID) (int, error)
-	DecrementColumnsAmount(ctx context.Context, id uuid.UUID) error
+	DeleteBoard(ctx context.Context, id uuid.UUID, deleteEvents DeleteEvents) error
 }
 
 type BoardUpdates struct {
@@ -30,20 +31,70 @@ type BoardUpdates struct {
 	Updated_at  *time.Time `bson:"updated_at,omitempty"`
 }
 
+type BoardSortField string
+
+const (
+	BoardSortUpdatedAt BoardSortField = "updated_at"
+	BoardSortCreatedAt BoardSortField = "created_at"
+	BoardSortTitle     BoardSortField = "title"
+)
+
+// BoardQuery selects a page of the boards owned by UserID or listed in
+// SharedIDs. Boards are sorted by SortBy with the ID as a tie-breaker.
+type BoardQuery struct {
+	UserID      string
+	SharedIDs   []uuid.UUID
+	Category    *string
+	Methodology *string
+	Favorite    *bool
+	SortBy      BoardSortField
+	Descending  bool
+	After       *BoardCursor
+	Limit       int
+}
+
+// BoardCursor is the position of the last board of the previous page. Value
+// holds the board's SortBy field, a time.Time or a string.
+type BoardCursor struct {
+	Value any
+	ID    uuid.UUID
+}
+
 type boardRepository struct {
-	db *mongo.Database
+	db     *mongo.Database
+	logger *slog.Logger
 }
 
-func NewBoardRepository(db *mongo.Database) BoardRepository {
-	return &boardRepository{db: db}
+func NewBoardRepository(db *mongo.Database, logger *slog.Logger) BoardRepository {
+	return &boardRepository{db: db, logger: logger}
 }
 
+// CreateBoard inserts the board and its initial columns in one transaction.
+// The columns are stored in the Columns collection rather than embedded in
+// the board document.
 func (r *boardRepository) CreateBoard(ctx context.Context, board *models.Board) (*models.Board, error) {
-	ctx, span := telemetry.StartSpan(ctx, "BoardRepository.CreateBoard")
+	ctx, span := startSpan(ctx, r.logger, "BoardRepository.CreateBoard")
 	defer span.End()
 
 	collection := r.db.Collection("Boards")
-	_, err := collection.InsertOne(ctx, board)
+	document := *board
+	document.Columns = nil
+
+	err := withTransaction(ctx, r.db, func(sc mongo.SessionContext) error {
+		if _, err := collection.InsertOne(sc, &document); err != nil {
+			return err
+		}
+		if len(board.Columns) == 0 {
+			return nil
+		}
+
+		columns := make([]any, 0, len(board.Columns))
+		for _, column := range board.Columns {
+			columns = append(columns, column)
+		}
+		_, err := r.db.Collection("Columns").InsertMany(sc, columns)
+		return err
+	})
 	if err != nil {
 		telemetry.RecordError(span, err)
 		return nil, err
@@ -51,96 +102,172 @@ func (r *boardRepository) CreateBoard(ctx context.Context, board *models.Board)
 	return board, nil
 }
 
-func (r *boardRepository) GetBoardInfo(ctx context.Context, id uuid.UUID) (*models.Board, error) {
-	ctx, span := telemetry.StartSpan(ctx, "BoardRepository.GetBoardInfo")
+func (r *boardRepository) GetBoard(ctx context.Context, id uuid.UUID) (*models.Board, error) {
+	ctx, span := startSpan(ctx, r.logger, "BoardRepository.GetBoard")
 	defer span.End()
 
 	collection := r.db.Collection("Boards")
 	var board models.Board
-	err := collection.FindOne(ctx, bson.M{"_id": id}).Decode(&board)
+	options := options.FindOne().SetProjection(bson.M{"columns": 0})
+	err := collection.FindOne(ctx, bson.M{"_id": id}, options).Decode(&board)
 	if err != nil {
 		telemetry.RecordError(span, err)
 		return nil, err
 	}
+	return &board, nil
+}
+
+// GetBoardInfo loads the board with its columns and their tasks in a single
+// aggregation. Columns are sorted by order number and tasks by rank.
+func (r *boardRepository) GetBoardInfo(ctx context.Context, id uuid.UUID) (*models.Board, error) {
+	ctx, span := startSpan(ctx, r.logger, "BoardRepository.GetBoardInfo")
+	defer span.End()
+
+	collection := r.db.Collection("Boards")
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"_id": id}}},
+		// The let and $expr form of $lookup also runs on servers before
+		// MongoDB 5.0, which reject localField combined with a pipeline.
+		{{Key: "$lookup", Value: bson.M{
+			"from": "Columns",
+			"let":  bson.M{"board_id": "$_id"},
+			"as":   "columns",
+			"pipeline": mongo.Pipeline{
+				{{Key: "$match", Value: bson.M{"$expr": bson.M{"$eq": bson.A{"$desk_id", "$$board_id"}}}}},
+				{{Key: "$sort", Value: bson.M{"order_number": 1}}},
+				{{Key: "$lookup", Value: bson.M{
+					"from": "Tasks",
+					"let":  bson.M{"column_id": "$_id"},
+					"as":   "tasks",
+					"pipeline": mongo.Pipeline{
+						{{Key: "$match", Value: bson.M{"$expr": bson.M{"$eq": bson.A{"$column_id", "$$column_id"}}}}},
+						{{Key: "$sort", Value: bson.D{{Key: "rank", Value: 1}, {Key: "_id", Value: 1}}}},
+					},
+				}}},
+			},
+		}}},
+	}
 
-	columnsCursor, err := r.db.Collection("Columns").Find(ctx, bson.M{"desk_id": id})
+	cursor, err := collection.Aggregate(ctx, pipeline)
 	if err != nil {
 		telemetry.RecordError(span, err)
 		return nil, err
 	}
-	defer columnsCursor.Close(ctx)
+	defer cursor.Close(ctx)
 
-	for columnsCursor.Next(ctx) {
-		var column models.Column
-		if err := columnsCursor.Decode(&column); err != nil {
-			telemetry.RecordError(span, err)
-			return nil, err
+	if !cursor.Next(ctx) {
+		err := cursor.Err()
+		if err == nil {
+			err = mongo.ErrNoDocuments
 		}
-		board.Columns = append(board.Columns, column)
+		telemetry.RecordError(span, err)
+		return nil, err
 	}
-	if err := columnsCursor.Err(); err != nil {
+	var board models.Board
+	if err := cursor.Decode(&board); err != nil {
 		telemetry.RecordError(span, err)
 		return nil, err
 	}
+	return &board, nil
+}
 
-	for i := range board.Columns {
-		tasksCursor, err := r.db.Collection("Tasks").Find(ctx, bson.M{"column_id": board.Columns[i].ID})
-		if err != nil {
-			telemetry.RecordError(span, err)
-			return nil, err
-		}
-		defer tasksCursor.Close(ctx)
-		for tasksCursor.Next(ctx) {
-			var task models.Task
-			if err := tasksCursor.Decode(&task); err != nil {
-				telemetry.RecordError(span, err)
-				return nil, err
-			}
-			board.Columns[i].Tasks = append(board.Columns[i].Tasks, task)
+func (r *boardRepository) GetBoards(ctx context.Context, userID string) ([]*models.Board, error) {
+	ctx, span := startSpan(ctx, r.logger, "BoardRepository.GetBoards")
+	defer span.End()
+
+	boards, err := r.findBoards(ctx, bson.M{"user_id": userID}, options.Find())
+	if err != nil {
+		telemetry.RecordError(span, err)
+		return nil, err
+	}
+	return boards, nil
+}
+
+func (r *boardRepository) ListBoards(ctx context.Context, query *BoardQuery) ([]*models.Board, error) {
+	ctx, span := startSpan(ctx, r.logger, "BoardRepository.ListBoards")
+	defer span.End()
+
+	filter, sort := listBoardsFilter(query)
+	boards, err := r.findBoards(ctx, filter, options.Find().SetSort(sort).SetLimit(int64(query.Limit)))
+	if err != nil {
+		telemetry.RecordError(span, err)
+		return nil, err
+	}
+	return boards, nil
+}
+
+// listBoardsFilter returns the filter and sort of a ListBoards query. The
+// owned and the shared boards are separate branches of a top-level $or, each
+// with all the other conditions, so that each branch uses an index of its
+// own: the owned boards the user_id index of the sort field, the shared ones
+// the _id index.
+func listBoardsFilter(query *BoardQuery) (bson.M, bson.D) {
+	conditions := bson.M{}
+	if query.Category != nil {
+		conditions["category"] = *query.Category
+	}
+	if query.Methodology != nil {
+		conditions["metodology"] = *query.Methodology
+	}
+	if query.Favorite != nil {
+		conditions["favorite"] = *query.Favorite
+	}
+
+	field := string(query.SortBy)
+	direction, compare := 1, "$gt"
+	if query.Descending {
+		direction, compare = -1, "$lt"
+	}
+	if query.After != nil {
+		conditions["$or"] = bson.A{
+			bson.M{field: bson.M{compare: query.After.Value}},
+			bson.M{field: query.After.Value, "_id": bson.M{compare: query.After.ID}},
 		}
-		if err := tasksCursor.Err(); err != nil {
-			telemetry.RecordError(span, err)
-			return nil, err
+	}
+
+	branch := func(owner bson.M) bson.M {
+		for key, value := range conditions {
+			owner[key] = value
 		}
+		return owner
+	}
+	owners := bson.A{branch(bson.M{"user_id": query.UserID})}
+	if len(query.SharedIDs) > 0 {
+		owners = append(owners, branch(bson.M{"_id": bson.M{"$in": query.SharedIDs}}))
 	}
 
-	return &board, nil
+	sort := bson.D{{Key: field, Value: direction}, {Key: "_id", Value: direction}}
+	return bson.M{"$or": owners}, sort
 }
 
-func (r *boardRepository) GetBoards(ctx context.Context, userID string) ([]*models.Board, error) {
-	ctx, span := telemetry.StartSpan(ctx, "BoardRepository.GetBoards")
-	defer span.End()
-
+func (r *boardRepository) findBoards(ctx context.Context, filter bson.M, options *options.FindOptions) ([]*models.Board, error) {
 	collection := r.db.Collection("Boards")
 	var boards []*models.Board
-	options := options.Find().SetProjection(bson.M{
+	options.SetProjection(bson.M{
 		"_id": 1, "title": 1, "description": 1, "category": 1,
 		"progress": 1, "favorite": 1, "metodology": 1,
-		"updated_at": 1, "user_id": 1,
+		"created_at": 1, "updated_at": 1, "user_id": 1,
 	})
-	cursor, err := collection.Find(ctx, bson.M{"user_id": userID}, options)
+	cursor, err := collection.Find(ctx, filter, options)
 	if err != nil {
-		telemetry.RecordError(span, err)
 		return nil, err
 	}
 	defer cursor.Close(ctx)
 	for cursor.Next(ctx) {
 		var board models.Board
 		if err := cursor.Decode(&board); err != nil {
-			telemetry.RecordError(span, err)
 			return nil, err
 		}
 		boards = append(boards, &board)
 	}
 	if err := cursor.Err(); err != nil {
-		telemetry.RecordError(span, err)
 		return nil, err
 	}
 	return boards, nil
 }
 
 func (r *boardRepository) UpdateBoard(ctx context.Context, id uuid.UUID, updates *BoardUpdates) (*models.Board, error) {
-	ctx, span := telemetry.StartSpan(ctx, "BoardRepository.UpdateBoard")
+	ctx, span := startSpan(ctx, r.logger, "BoardRepository.UpdateBoard")
 	defer span.End()
 
 	collection := r.db.Collection("Boards")
@@ -174,97 +301,58 @@ func (r *boardRepository) UpdateBoard(ctx context.Context, id uuid.UUID, updates
 	return r.GetBoardInfo(ctx, id)
 }
 
-func (r *boardRepository) DeleteBoard(ctx context.Context, id uuid.UUID) error {
-	ctx, span := telemetry.StartSpan(ctx, "BoardRepository.DeleteBoard")
+// DeleteBoard removes the board with its columns, tasks and members in one
+// transaction. The events deleteEvents returns for the board's tasks in the
+// calendar, as found in the transaction, are stored in the Outbox collection
+// in the same transaction. deleteEvents may be nil.
+func (r *boardRepository) DeleteBoard(ctx context.Context, id uuid.UUID, deleteEvents DeleteEvents) error {
+	ctx, span := startSpan(ctx, r.logger, "BoardRepository.DeleteBoard")
 	defer span.End()
 
-	session, err := r.db.Client().StartSession()
-	if err != nil {
-		telemetry.RecordError(span, err)
-		return err
-	}
-	defer session.EndSession(ctx)
-
-	err = mongo.WithSession(ctx, session, func(sc mongo.SessionContext) error {
-		if err := session.StartTransaction(); err != nil {
-			telemetry.RecordError(span, err)
+	columnsCollection := r.db.Collection("Columns")
+	tasksCollection := r.db.Collection("Tasks")
+	err := withTransaction(ctx, r.db, func(sc mongo.SessionContext) error {
+		cursor, err := columnsCollection.Find(sc, bson.M{"desk_id": id}, options.Find().SetProjection(bson.M{"_id": 1}))
+		if err != nil {
 			return err
 		}
-		columnsCollection := r.db.Collection("Columns")
-		cursor, err := columnsCollection.Find(sc, bson.M{"desk_id": id})
-		if err != nil {
-			telemetry.RecordError(span, err)
+		var columns []models.Column
+		if err := cursor.All(sc, &columns); err != nil {
 			return err
 		}
-		defer cursor.Close(sc)
+		columnIDs := make([]uuid.UUID, 0, len(columns))
+		for _, column := range columns {
+			columnIDs = append(columnIDs, column.ID)
+		}
 
-		tasksCollection := r.db.Collection("Tasks")
-		for cursor.Next(sc) {
-			var column models.Column
-			if err := cursor.Decode(&column); err != nil {
-				telemetry.RecordError(span, err)
+		if deleteEvents != nil && len(columnIDs) > 0 {
+			cursor, err := tasksCollection.Find(sc, bson.M{"column_id": bson.M{"$in": columnIDs}, "in_calendar": true})
+			if err != nil {
 				return err
 			}
-			_, err := tasksCollection.DeleteMany(sc, bson.M{"column_id": column.ID})
-			if err != nil {
-				telemetry.RecordError(span, err)
+			var tasks []*models.Task
+			if err := cursor.All(sc, &tasks); err != nil {
+				return err
+			}
+			if err := insertEvents(sc, r.db, deleteEvents(tasks)); err != nil {
 				return err
 			}
 		}
-		_, err = columnsCollection.DeleteMany(sc, bson.M{"desk_id": id})
-		if err != nil {
-			telemetry.RecordError(span, err)
-			return err
+
+		if len(columnIDs) > 0 {
+			if _, err := tasksCollection.DeleteMany(sc, bson.M{"column_id": bson.M{"$in": columnIDs}}); err != nil {
+				return err
+			}
 		}
-		boardsCollection := r.db.Collection("Boards")
-		_, err = boardsCollection.DeleteOne(sc, bson.M{"_id": id})
-		if err != nil {
-			telemetry.RecordError(span, err)
+		if _, err := columnsCollection.DeleteMany(sc, bson.M{"desk_id": id}); err != nil {
 			return err
 		}
-		if err := session.CommitTransaction(sc); err != nil {
-			telemetry.RecordError(span, err)
+		if _, err := r.db.Collection("Members").DeleteMany(sc, bson.M{"board_id": id}); err != nil {
 			return err
 		}
-		return nil
-	})
-	if err != nil {
-		if abortErr := session.AbortTransaction(ctx); abortErr != nil {
-			telemetry.RecordError(span, abortErr)
-			return abortErr
-		}
+		_, err = r.db.Collection("Boards").DeleteOne(sc, bson.M{"_id": id})
 		return err
-	}
-	return nil
-}
-
-func (r *boardRepository) IncrementColumnsAmount(ctx context.Context, id uuid.UUID) (int, error) {
-	ctx, span := telemetry.StartSpan(ctx, "BoardRepository.IncrementColumnsAmount")
-	defer span.End()
-
-	collection := r.db.Collection("Boards")
-	update := bson.M{"$inc": bson.M{"columns_amount": 1}}
-	_, err := collection.UpdateOne(ctx, bson.M{"_id": id}, update)
-	if err != nil {
-		telemetry.RecordError(span, err)
-		return 0, err
-	}
-	var board models.Board
-	err = collection.FindOne(ctx, bson.M{"_id": id}).Decode(&board)
-	if err != nil {
-		telemetry.RecordError(span, err)
-		return 0, err
-	}
-	return board.Columns_amount, nil
-}
-
-func (r *boardRepository) DecrementColumnsAmount(ctx context.Context, id uuid.UUID) error {
-	ctx, span := telemetry.StartSpan(ctx, "BoardRepository.DecrementColumnsAmount")
-	defer span.End()
-
-	collection := r.db.Collection("Boards")
-	update := bson.M{"$inc": bson.M{"columns_amount": -1}}
-	_, err := collection.UpdateOne(ctx, bson.M{"_id": id}, update)
+	})
 	if err != nil {
 		telemetry.RecordError(span, err)
 		return err
@@ -0,0 +1,308 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/SeiFlow-3P2/board_service/internal/models"
+	"github.com/SeiFlow-3P2/board_service/internal/rank"
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func TestCreateBoardStoresColumns(t *testing.T) {
+	db := testDB(t)
+	ctx := context.Background()
+
+	boardID := uuid.New()
+	board := &models.Board{ID: boardID, Title: "board", User_id: "owner", Columns_amount: 2, Columns: []models.Column{
+		{ID: uuid.New(), Name: "To Do", Order_number: 1, Desk_id: boardID},
+		{ID: uuid.New(), Name: "Done", Order_number: 2, Desk_id: boardID},
+	}}
+	if _, err := NewBoardRepository(db, testLogger).CreateBoard(ctx, board); err != nil {
+		t.Fatalf("CreateBoard(): %v", err)
+	}
+
+	columns, err := NewColumnRepository(db, testLogger).GetColumns(ctx, boardID)
+	if err != nil {
+		t.Fatalf("GetColumns(): %v", err)
+	}
+	if len(columns) != 2 {
+		t.Fatalf("expected 2 columns, got %d", len(columns))
+	}
+	for i, column := range columns {
+		if column.ID != board.Columns[i].ID {
+			t.Fatalf("expected column %s at index %d, got %s", board.Columns[i].ID, i, column.ID)
+		}
+	}
+}
+
+// seedBoard creates a board with the given number of columns and tasks per
+// column and returns its ID.
+func seedBoard(tb testing.TB, db *mongo.Database, columns, tasksPerColumn int) uuid.UUID {
+	tb.Helper()
+	ctx := context.Background()
+
+	if err := EnsureIndexes(ctx, db, testLogger); err != nil {
+		tb.Fatalf("EnsureIndexes(): %v", err)
+	}
+
+	boardID := uuid.New()
+	board := &models.Board{ID: boardID, Title: "board", User_id: "owner", Columns_amount: columns}
+	var tasks []any
+	for i := 0; i < columns; i++ {
+		column := models.Column{ID: uuid.New(), Name: fmt.Sprintf("column %d", i), Order_number: columns - i, Desk_id: boardID}
+		board.Columns = append(board.Columns, column)
+		ranks := rank.Spread(tasksPerColumn)
+		for j := 0; j < tasksPerColumn; j++ {
+			tasks = append(tasks, models.Task{
+				ID:        uuid.New(),
+				Title:     fmt.Sprintf("task %d", j),
+				Column_id: column.ID,
+				Rank:      ranks[tasksPerColumn-1-j],
+			})
+		}
+	}
+
+	if _, err := NewBoardRepository(db, testLogger).CreateBoard(ctx, board); err != nil {
+		tb.Fatalf("CreateBoard(): %v", err)
+	}
+	if len(tasks) > 0 {
+		if _, err := db.Collection("Tasks").InsertMany(ctx, tasks); err != nil {
+			tb.Fatalf("InsertMany(): %v", err)
+		}
+	}
+	return boardID
+}
+
+func TestGetBoardInfoLoadsColumnsAndTasksInOrder(t *testing.T) {
+	db := testDB(t)
+	boardID := seedBoard(t, db, 3, 4)
+
+	board, err := NewBoardRepository(db, testLogger).GetBoardInfo(context.Background(), boardID)
+	if err != nil {
+		t.Fatalf("GetBoardInfo(): %v", err)
+	}
+	if len(board.Columns) != 3 {
+		t.Fatalf("expected 3 columns, got %d", len(board.Columns))
+	}
+	for i, column := range board.Columns {
+		if column.Order_number != i+1 {
+			t.Fatalf("expected order number %d at index %d, got %d", i+1, i, column.Order_number)
+		}
+		if len(column.Tasks) != 4 {
+			t.Fatalf("expected 4 tasks in %q, got %d", column.Name, len(column.Tasks))
+		}
+		for j := 1; j < len(column.Tasks); j++ {
+			if column.Tasks[j-1].Rank >= column.Tasks[j].Rank {
+				t.Fatalf("tasks of %q are not sorted by rank", column.Name)
+			}
+		}
+	}
+
+	if _, err := NewBoardRepository(db, testLogger).GetBoardInfo(context.Background(), uuid.New()); err != mongo.ErrNoDocuments {
+		t.Fatalf("expected mongo.ErrNoDocuments, got %v", err)
+	}
+}
+
+// BenchmarkGetBoardInfo measures loading a large board. Run it against a local
+// replica set with:
+//
+//	MONGO_TEST_URL=mongodb://localhost:27017/?replicaSet=rs0 go test -run=^$ -bench=GetBoardInfo ./internal/repository
+func BenchmarkGetBoardInfo(b *testing.B) {
+	db := testDB(b)
+	boardID := seedBoard(b, db, 20, 50)
+	repo := NewBoardRepository(db, testLogger)
+	ctx := context.Background()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := repo.GetBoardInfo(ctx, boardID); err != nil {
+			b.Fatalf("GetBoardInfo(): %v", err)
+		}
+	}
+}
+
+// getBoardInfoPerQuery loads a board the way GetBoardInfo did before the
+// aggregation: one query for the board, one for its columns and one for the
+// tasks of every column.
+func getBoardInfoPerQuery(ctx context.Context, db *mongo.Database, id uuid.UUID) (*models.Board, error) {
+	var board models.Board
+	if err := db.Collection("Boards").FindOne(ctx, bson.M{"_id": id}).Decode(&board); err != nil {
+		return nil, err
+	}
+
+	cursor, err := db.Collection("Columns").Find(ctx, bson.M{"desk_id": id}, options.Find().SetSort(bson.M{"order_number": 1}))
+	if err != nil {
+		return nil, err
+	}
+	if err := cursor.All(ctx, &board.Columns); err != nil {
+		return nil, err
+	}
+
+	tasksOptions := options.Find().SetSort(bson.D{{Key: "rank", Value: 1}, {Key: "_id", Value: 1}})
+	for i := range board.Columns {
+		cursor, err := db.Collection("Tasks").Find(ctx, bson.M{"column_id": board.Columns[i].ID}, tasksOptions)
+		if err != nil {
+			return nil, err
+		}
+		if err := cursor.All(ctx, &board.Columns[i].Tasks); err != nil {
+			return nil, err
+		}
+	}
+	return &board, nil
+}
+
+// BenchmarkGetBoardInfoPerQuery is the baseline for BenchmarkGetBoardInfo on
+// the same board, loaded with a query per column.
+func BenchmarkGetBoardInfoPerQuery(b *testing.B) {
+	db := testDB(b)
+	boardID := seedBoard(b, db, 20, 50)
+	ctx := context.Background()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := getBoardInfoPerQuery(ctx, db, boardID); err != nil {
+			b.Fatalf("getBoardInfoPerQuery(): %v", err)
+		}
+	}
+}
+
+func TestListBoardsPagination(t *testing.T) {
+	db := testDB(t)
+	ctx := context.Background()
+	repo := NewBoardRepository(db, testLogger)
+	if err := EnsureIndexes(ctx, db, testLogger); err != nil {
+		t.Fatalf("EnsureIndexes(): %v", err)
+	}
+
+	now := time.Now().Truncate(time.Millisecond)
+	sharedID := uuid.New()
+	boards := []*models.Board{
+		{ID: uuid.New(), Title: "a", User_id: "owner", Updated_at: now},
+		{ID: uuid.New(), Title: "b", User_id: "owner", Updated_at: now},
+		{ID: uuid.New(), Title: "c", User_id: "owner", Updated_at: now.Add(-time.Hour)},
+		{ID: sharedID, Title: "d", User_id: "other", Updated_at: now.Add(-2 * time.Hour)},
+		{ID: uuid.New(), Title: "e", User_id: "other", Updated_at: now},
+	}
+	for _, board := range boards {
+		if _, err := repo.CreateBoard(ctx, board); err != nil {
+			t.Fatalf("CreateBoard(): %v", err)
+		}
+	}
+
+	query := &BoardQuery{UserID: "owner", SharedIDs: []uuid.UUID{sharedID}, SortBy: BoardSortUpdatedAt, Descending: true, Limit: 2}
+	seen := map[string]bool{}
+	var titles []string
+	for {
+		page, err := repo.ListBoards(ctx, query)
+		if err != nil {
+			t.Fatalf("ListBoards(): %v", err)
+		}
+		if len(page) == 0 {
+			break
+		}
+		for _, board := range page {
+			if seen[board.Title] {
+				t.Fatalf("board %q returned twice", board.Title)
+			}
+			seen[board.Title] = true
+			titles = append(titles, board.Title)
+		}
+		last := page[len(page)-1]
+		query.After = &BoardCursor{Value: last.Updated_at, ID: last.ID}
+	}
+
+	if len(titles) != 4 || titles[2] != "c" || titles[3] != "d" {
+		t.Fatalf("expected the two newest boards followed by c and d, got %v", titles)
+	}
+}
+
+func TestListBoardsUsesIndexes(t *testing.T) {
+	db := testDB(t)
+	ctx := context.Background()
+	if err := EnsureIndexes(ctx, db, testLogger); err != nil {
+		t.Fatalf("EnsureIndexes(): %v", err)
+	}
+	if _, err := NewBoardRepository(db, testLogger).CreateBoard(ctx, &models.Board{ID: uuid.New(), Title: "a", User_id: "owner"}); err != nil {
+		t.Fatalf("CreateBoard(): %v", err)
+	}
+
+	category, favorite := "work", true
+	for _, sortBy := range []BoardSortField{BoardSortUpdatedAt, BoardSortCreatedAt, BoardSortTitle} {
+		query := &BoardQuery{
+			UserID: "owner", SharedIDs: []uuid.UUID{uuid.New()},
+			Category: &category, Favorite: &favorite,
+			SortBy: sortBy, Limit: 10,
+			After: &BoardCursor{Value: "a", ID: uuid.New()},
+		}
+		filter, sort := listBoardsFilter(query)
+		var plan bson.M
+		if err := db.RunCommand(ctx, bson.D{
+			{Key: "explain", Value: bson.D{{Key: "find", Value: "Boards"}, {Key: "filter", Value: filter}, {Key: "sort", Value: sort}, {Key: "limit", Value: 10}}},
+			{Key: "verbosity", Value: "queryPlanner"},
+		}).Decode(&plan); err != nil {
+			t.Fatalf("explain: %v", err)
+		}
+		winning := fmt.Sprint(plan["queryPlanner"].(bson.M)["winningPlan"])
+		if strings.Contains(winning, "COLLSCAN") {
+			t.Fatalf("expected listing by %s to use indexes, got %s", sortBy, winning)
+		}
+	}
+}
+
+func TestMigrateEmbeddedColumns(t *testing.T) {
+	db := testDB(t)
+	ctx := context.Background()
+	if err := EnsureIndexes(ctx, db, testLogger); err != nil {
+		t.Fatalf("EnsureIndexes(): %v", err)
+	}
+
+	// A board as it was stored before its columns moved to their own
+	// collection, with a column created since that took the next number.
+	boardID := uuid.New()
+	embedded := []models.Column{
+		{ID: uuid.New(), Name: "To Do", Order_number: 1, Desk_id: boardID},
+		{ID: uuid.New(), Name: "Done", Order_number: 2, Desk_id: boardID},
+	}
+	if _, err := db.Collection("Boards").InsertOne(ctx, models.Board{
+		ID: boardID, Title: "board", User_id: "owner", Columns_amount: 3, Columns: embedded,
+	}); err != nil {
+		t.Fatalf("InsertOne(): %v", err)
+	}
+	created := models.Column{ID: uuid.New(), Name: "Review", Order_number: 3, Desk_id: boardID}
+	if _, err := db.Collection("Columns").InsertOne(ctx, created); err != nil {
+		t.Fatalf("InsertOne(): %v", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		if err := MigrateEmbeddedColumns(ctx, db, testLogger); err != nil {
+			t.Fatalf("MigrateEmbeddedColumns(): %v", err)
+		}
+	}
+
+	board, err := NewBoardRepository(db, testLogger).GetBoardInfo(ctx, boardID)
+	if err != nil {
+		t.Fatalf("GetBoardInfo(): %v", err)
+	}
+	want := []uuid.UUID{embedded[0].ID, embedded[1].ID, created.ID}
+	if len(board.Columns) != len(want) {
+		t.Fatalf("expected %d columns, got %d", len(want), len(board.Columns))
+	}
+	for i, column := range board.Columns {
+		if column.ID != want[i] || column.Order_number != i+1 {
+			t.Fatalf("expected column %s with number %d, got %s with %d", want[i], i+1, column.ID, column.Order_number)
+		}
+	}
+	if board.Columns_amount != 3 {
+		t.Fatalf("expected columns_amount 3, got %d", board.Columns_amount)
+	}
+	if count, _ := db.Collection("Boards").CountDocuments(ctx, bson.M{"columns": bson.M{"$exists": true}}); count != 0 {
+		t.Fatalf("expected no embedded columns left, got %d boards", count)
+	}
+}
@@ -2,8 +2,11 @@ package repository
 
 import (
 	"context"
+	"errors"
+	"log/slog"
 
 	"github.com/SeiFlow-3P2/board_service/internal/models"
+	"github.com/SeiFlow-3P2/board_service/internal/rank"
 	"github.com/SeiFlow-3P2/shared/telemetry"
 	"github.com/google/uuid"
 	"go.mongodb.org/mongo-driver/bson"
@@ -16,28 +19,58 @@ type ColumnRepository interface {
 	GetColumnInfo(ctx context.Context, id uuid.UUID) (*models.Column, error)
 	GetColumns(ctx context.Context, boardID uuid.UUID) ([]*models.Column, error)
 	UpdateColumn(ctx context.Context, id uuid.UUID, updates *ColumnUpdates) (*models.Column, error)
-	DeleteColumn(ctx context.Context, id uuid.UUID) error
-	DecrementOrderNumbers(ctx context.Context, boardID uuid.UUID, orderNumber int) error
+	DeleteColumn(ctx context.Context, id uuid.UUID, moveTasksTo *uuid.UUID, deleteEvents DeleteEvents) error
+	MoveColumn(ctx context.Context, id uuid.UUID, orderNumber int) (*models.Column, error)
 }
 
+// ErrInvalidTarget is returned by DeleteColumn when the column to receive the
+// tasks is missing, is the deleted column itself or is on another board.
+var ErrInvalidTarget = errors.New("target column must be another column of the same board")
+
+// DeleteEvents returns the outbox events for tasks in the calendar that are
+// deleted together with their column.
+type DeleteEvents func(tasks []*models.Task) []*models.OutboxEvent
+
 type ColumnUpdates struct {
 	Name *string `bson:"name,omitempty"`
 }
 
 type columnRepository struct {
-	db *mongo.Database
+	db     *mongo.Database
+	logger *slog.Logger
 }
 
-func NewColumnRepository(db *mongo.Database) ColumnRepository {
-	return &columnRepository{db: db}
+func NewColumnRepository(db *mongo.Database, logger *slog.Logger) ColumnRepository {
+	return &columnRepository{db: db, logger: logger}
 }
 
+// CreateColumn appends the column to its board. The board's columns_amount is
+// incremented in the same transaction and its new value becomes the column's
+// order number, so concurrent inserts never share a position.
+// A column whose name is taken on the board, ignoring case, is rejected with
+// a duplicate key error.
 func (r *columnRepository) CreateColumn(ctx context.Context, column *models.Column) (*models.Column, error) {
-	ctx, span := telemetry.StartSpan(ctx, "ColumnRepository.CreateColumn")
+	ctx, span := startSpan(ctx, r.logger, "ColumnRepository.CreateColumn")
 	defer span.End()
 
 	collection := r.db.Collection("Columns")
-	_, err := collection.InsertOne(ctx, column)
+	err := withTransaction(ctx, r.db, func(sc mongo.SessionContext) error {
+		var board models.Board
+		err := r.db.Collection("Boards").FindOneAndUpdate(sc,
+			bson.M{"_id": column.Desk_id},
+			bson.M{"$inc": bson.M{"columns_amount": 1}},
+			options.FindOneAndUpdate().
+				SetReturnDocument(options.After).
+				SetProjection(bson.M{"columns_amount": 1}),
+		).Decode(&board)
+		if err != nil {
+			return err
+		}
+
+		column.Order_number = board.Columns_amount
+		_, err = collection.InsertOne(sc, column)
+		return err
+	})
 	if err != nil {
 		telemetry.RecordError(span, err)
 		return nil, err
@@ -46,7 +79,7 @@ func (r *columnRepository) CreateColumn(ctx context.Context, column *models.Colu
 }
 
 func (r *columnRepository) GetColumnInfo(ctx context.Context, id uuid.UUID) (*models.Column, error) {
-	ctx, span := telemetry.StartSpan(ctx, "ColumnRepository.GetColumnInfo")
+	ctx, span := startSpan(ctx, r.logger, "ColumnRepository.GetColumnInfo")
 	defer span.End()
 
 	collection := r.db.Collection("Columns")
@@ -60,12 +93,14 @@ func (r *columnRepository) GetColumnInfo(ctx context.Context, id uuid.UUID) (*mo
 }
 
 func (r *columnRepository) GetColumns(ctx context.Context, boardID uuid.UUID) ([]*models.Column, error) {
-	ctx, span := telemetry.StartSpan(ctx, "ColumnRepository.GetColumns")
+	ctx, span := startSpan(ctx, r.logger, "ColumnRepository.GetColumns")
 	defer span.End()
 
 	collection := r.db.Collection("Columns")
 	var columns []*models.Column
-	options := options.Find().SetProjection(bson.M{"_id": 1, "name": 1, "order_number": 1})
+	options := options.Find().
+		SetProjection(bson.M{"_id": 1, "name": 1, "order_number": 1, "desk_id": 1}).
+		SetSort(bson.M{"order_number": 1})
 	cursor, err := collection.Find(ctx, bson.M{"desk_id": boardID}, options)
 	if err != nil {
 		telemetry.RecordError(span, err)
@@ -88,7 +123,7 @@ func (r *columnRepository) GetColumns(ctx context.Context, boardID uuid.UUID) ([
 }
 
 func (r *columnRepository) UpdateColumn(ctx context.Context, id uuid.UUID, updates *ColumnUpdates) (*models.Column, error) {
-	ctx, span := telemetry.StartSpan(ctx, "ColumnRepository.UpdateColumn")
+	ctx, span := startSpan(ctx, r.logger, "ColumnRepository.UpdateColumn")
 	defer span.End()
 
 	collection := r.db.Collection("Columns")
@@ -110,12 +145,76 @@ func (r *columnRepository) UpdateColumn(ctx context.Context, id uuid.UUID, updat
 	return r.GetColumnInfo(ctx, id)
 }
 
-func (r *columnRepository) DeleteColumn(ctx context.Context, id uuid.UUID) error {
-	ctx, span := telemetry.StartSpan(ctx, "ColumnRepository.DeleteColumn")
+// DeleteColumn removes a column together with its tasks, or moves the tasks
+// to the end of moveTasksTo when it is set. The target is checked, the order
+// numbers of the following columns and the board's columns_amount are
+// updated, and the events deleteEvents returns for the deleted tasks in the
+// calendar are stored in the Outbox collection, in the same transaction.
+// deleteEvents may be nil.
+func (r *columnRepository) DeleteColumn(ctx context.Context, id uuid.UUID, moveTasksTo *uuid.UUID, deleteEvents DeleteEvents) error {
+	ctx, span := startSpan(ctx, r.logger, "ColumnRepository.DeleteColumn")
 	defer span.End()
 
-	collection := r.db.Collection("Columns")
-	_, err := collection.DeleteOne(ctx, bson.M{"_id": id})
+	columnsCollection := r.db.Collection("Columns")
+	tasksCollection := r.db.Collection("Tasks")
+	err := withTransaction(ctx, r.db, func(sc mongo.SessionContext) error {
+		var column models.Column
+		if err := columnsCollection.FindOne(sc, bson.M{"_id": id}).Decode(&column); err != nil {
+			return err
+		}
+
+		if moveTasksTo != nil {
+			if *moveTasksTo == id {
+				return ErrInvalidTarget
+			}
+			var target models.Column
+			err := columnsCollection.FindOne(sc, bson.M{"_id": *moveTasksTo}).Decode(&target)
+			if err == mongo.ErrNoDocuments || (err == nil && target.Desk_id != column.Desk_id) {
+				return ErrInvalidTarget
+			}
+			if err != nil {
+				return err
+			}
+			if err := r.appendTasks(sc, id, *moveTasksTo); err != nil {
+				return err
+			}
+		} else {
+			if deleteEvents != nil {
+				cursor, err := tasksCollection.Find(sc, bson.M{"column_id": id, "in_calendar": true})
+				if err != nil {
+					return err
+				}
+				var tasks []*models.Task
+				if err := cursor.All(sc, &tasks); err != nil {
+					return err
+				}
+				if err := insertEvents(sc, r.db, deleteEvents(tasks)); err != nil {
+					return err
+				}
+			}
+			if _, err := tasksCollection.DeleteMany(sc, bson.M{"column_id": id}); err != nil {
+				return err
+			}
+		}
+
+		if _, err := columnsCollection.DeleteOne(sc, bson.M{"_id": id}); err != nil {
+			return err
+		}
+
+		_, err := columnsCollection.UpdateMany(sc, bson.M{
+			"desk_id":      column.Desk_id,
+			"order_number": bson.M{"$gt": column.Order_number},
+		}, bson.M{"$inc": bson.M{"order_number": -1}})
+		if err != nil {
+			return err
+		}
+
+		_, err = r.db.Collection("Boards").UpdateOne(sc,
+			bson.M{"_id": column.Desk_id},
+			bson.M{"$inc": bson.M{"columns_amount": -1}},
+		)
+		return err
+	})
 	if err != nil {
 		telemetry.RecordError(span, err)
 		return err
@@ -123,19 +222,93 @@ func (r *columnRepository) DeleteColumn(ctx context.Context, id uuid.UUID) error
 	return nil
 }
 
-func (r *columnRepository) DecrementOrderNumbers(ctx context.Context, boardID uuid.UUID, orderNumber int) error {
-	ctx, span := telemetry.StartSpan(ctx, "ColumnRepository.DecrementOrderNumbers")
+// appendTasks moves all tasks of one column behind the tasks of another,
+// keeping their relative order. The moved tasks get evenly spaced ranks after
+// the last rank of the target, so that handing over a large column does not
+// lengthen the ranks.
+func (r *columnRepository) appendTasks(sc mongo.SessionContext, fromID, toID uuid.UUID) error {
+	collection := r.db.Collection("Tasks")
+
+	var last models.Task
+	lastOptions := options.FindOne().SetSort(bson.M{"rank": -1}).SetProjection(bson.M{"rank": 1})
+	err := collection.FindOne(sc, bson.M{"column_id": toID}, lastOptions).Decode(&last)
+	if err != nil && err != mongo.ErrNoDocuments {
+		return err
+	}
+
+	findOptions := options.Find().
+		SetSort(bson.D{{Key: "rank", Value: 1}, {Key: "_id", Value: 1}}).
+		SetProjection(bson.M{"_id": 1})
+	cursor, err := collection.Find(sc, bson.M{"column_id": fromID}, findOptions)
+	if err != nil {
+		return err
+	}
+	var tasks []models.Task
+	if err := cursor.All(sc, &tasks); err != nil {
+		return err
+	}
+	if len(tasks) == 0 {
+		return nil
+	}
+
+	ranks, err := rank.After(last.Rank, len(tasks))
+	if err != nil {
+		return err
+	}
+	writes := make([]mongo.WriteModel, 0, len(tasks))
+	for i, task := range tasks {
+		writes = append(writes, mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"_id": task.ID}).
+			SetUpdate(bson.M{"$set": bson.M{"column_id": toID, "rank": ranks[i]}}))
+	}
+
+	_, err = collection.BulkWrite(sc, writes)
+	return err
+}
+
+// MoveColumn puts the column at orderNumber and shifts the columns in between
+// by one, keeping the order numbers of the board dense. An out of range
+// orderNumber is clamped to the first or last position.
+func (r *columnRepository) MoveColumn(ctx context.Context, id uuid.UUID, orderNumber int) (*models.Column, error) {
+	ctx, span := startSpan(ctx, r.logger, "ColumnRepository.MoveColumn")
 	defer span.End()
 
 	collection := r.db.Collection("Columns")
-	update := bson.M{"$inc": bson.M{"order_number": -1}}
-	_, err := collection.UpdateMany(ctx, bson.M{
-		"desk_id":      boardID,
-		"order_number": bson.M{"$gt": orderNumber},
-	}, update)
+	err := withTransaction(ctx, r.db, func(sc mongo.SessionContext) error {
+		var column models.Column
+		if err := collection.FindOne(sc, bson.M{"_id": id}).Decode(&column); err != nil {
+			return err
+		}
+
+		count, err := collection.CountDocuments(sc, bson.M{"desk_id": column.Desk_id})
+		if err != nil {
+			return err
+		}
+		target := max(1, min(orderNumber, int(count)))
+
+		from := column.Order_number
+		if target == from {
+			return nil
+		}
+
+		filter := bson.M{"desk_id": column.Desk_id, "_id": bson.M{"$ne": id}}
+		shift := 1
+		if target < from {
+			filter["order_number"] = bson.M{"$gte": target, "$lt": from}
+		} else {
+			filter["order_number"] = bson.M{"$gt": from, "$lte": target}
+			shift = -1
+		}
+
+		if _, err := collection.UpdateMany(sc, filter, bson.M{"$inc": bson.M{"order_number": shift}}); err != nil {
+			return err
+		}
+		_, err = collection.UpdateOne(sc, bson.M{"_id": id}, bson.M{"$set": bson.M{"order_number": target}})
+		return err
+	})
 	if err != nil {
 		telemetry.RecordError(span, err)
-		return err
+		return nil, err
 	}
-	return err
+	return r.GetColumnInfo(ctx, id)
 }
@@ -0,0 +1,214 @@
+package repository
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/SeiFlow-3P2/board_service/internal/models"
+	"github.com/SeiFlow-3P2/board_service/internal/rank"
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func TestCreateColumnConcurrently(t *testing.T) {
+	db := testDB(t)
+	ctx := context.Background()
+	boards, columns := NewBoardRepository(db, testLogger), NewColumnRepository(db, testLogger)
+
+	board, err := boards.CreateBoard(ctx, &models.Board{ID: uuid.New(), Title: "board", User_id: "owner"})
+	if err != nil {
+		t.Fatalf("CreateBoard(): %v", err)
+	}
+
+	const n = 50
+	var wg sync.WaitGroup
+	errs := make(chan error, n)
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := columns.CreateColumn(ctx, &models.Column{ID: uuid.New(), Name: uuid.NewString(), Desk_id: board.ID})
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("CreateColumn(): %v", err)
+		}
+	}
+
+	got, err := boards.GetBoard(ctx, board.ID)
+	if err != nil {
+		t.Fatalf("GetBoard(): %v", err)
+	}
+	if got.Columns_amount != n {
+		t.Fatalf("expected columns_amount %d, got %d", n, got.Columns_amount)
+	}
+
+	created, err := columns.GetColumns(ctx, board.ID)
+	if err != nil {
+		t.Fatalf("GetColumns(): %v", err)
+	}
+	if len(created) != n {
+		t.Fatalf("expected %d columns, got %d", n, len(created))
+	}
+	for i, column := range created {
+		if column.Order_number != i+1 {
+			t.Fatalf("expected order number %d at index %d, got %d", i+1, i, column.Order_number)
+		}
+	}
+}
+
+func TestCreateColumnOnMissingBoard(t *testing.T) {
+	db := testDB(t)
+	ctx := context.Background()
+
+	boardID := uuid.New()
+	_, err := NewColumnRepository(db, testLogger).CreateColumn(ctx, &models.Column{ID: uuid.New(), Name: "To Do", Desk_id: boardID})
+	if err == nil {
+		t.Fatal("expected an error for a missing board")
+	}
+	if count, _ := db.Collection("Columns").CountDocuments(ctx, bson.M{"desk_id": boardID}); count != 0 {
+		t.Fatalf("expected no orphan columns, got %d", count)
+	}
+}
+
+func TestDeleteColumnMovesTasks(t *testing.T) {
+	db := testDB(t)
+	ctx := context.Background()
+	boards, columns, tasks := NewBoardRepository(db, testLogger), NewColumnRepository(db, testLogger), NewTaskRepository(db, testLogger)
+
+	from := models.Column{ID: uuid.New(), Name: "From", Order_number: 1}
+	to := models.Column{ID: uuid.New(), Name: "To", Order_number: 2}
+	gone := models.Column{ID: uuid.New(), Name: "Gone", Order_number: 3}
+	board := &models.Board{ID: uuid.New(), Title: "board", User_id: "owner", Columns_amount: 3}
+	for _, column := range []*models.Column{&from, &to, &gone} {
+		column.Desk_id = board.ID
+		board.Columns = append(board.Columns, *column)
+	}
+	if _, err := boards.CreateBoard(ctx, board); err != nil {
+		t.Fatalf("CreateBoard(): %v", err)
+	}
+
+	const n = 500
+	for _, r := range rank.Spread(n) {
+		task := &models.Task{ID: uuid.New(), Title: "task", Column_id: from.ID, Rank: r}
+		if _, err := tasks.CreateTask(ctx, task); err != nil {
+			t.Fatalf("CreateTask(): %v", err)
+		}
+	}
+
+	inCalendar := &models.Task{ID: uuid.New(), Title: "in calendar", Column_id: gone.ID, In_Calendar: true}
+	if _, err := tasks.CreateTask(ctx, inCalendar); err != nil {
+		t.Fatalf("CreateTask(): %v", err)
+	}
+	deleteEvents := func(deleted []*models.Task) []*models.OutboxEvent {
+		if len(deleted) != 1 || deleted[0].ID != inCalendar.ID {
+			t.Errorf("expected the task in the calendar, got %v", deleted)
+		}
+		return []*models.OutboxEvent{{ID: uuid.New(), Topic: "board.event", Key: "owner", Payload: "{}"}}
+	}
+	if err := columns.DeleteColumn(ctx, gone.ID, nil, deleteEvents); err != nil {
+		t.Fatalf("DeleteColumn(): %v", err)
+	}
+	if count, _ := db.Collection("Outbox").CountDocuments(ctx, bson.M{}); count != 1 {
+		t.Fatalf("expected the delete event in the outbox, got %d events", count)
+	}
+	if err := columns.DeleteColumn(ctx, from.ID, &gone.ID, nil); err != ErrInvalidTarget {
+		t.Fatalf("expected ErrInvalidTarget for a deleted target, got %v", err)
+	}
+
+	if err := columns.DeleteColumn(ctx, from.ID, &to.ID, nil); err != nil {
+		t.Fatalf("DeleteColumn(): %v", err)
+	}
+	moved, err := tasks.GetColumnTasks(ctx, to.ID)
+	if err != nil {
+		t.Fatalf("GetColumnTasks(): %v", err)
+	}
+	if len(moved) != n {
+		t.Fatalf("expected %d moved tasks, got %d", n, len(moved))
+	}
+	for _, task := range moved {
+		if len(task.Rank) > 4 {
+			t.Fatalf("expected short ranks after the hand-over, got %q", task.Rank)
+		}
+	}
+}
+
+func TestColumnNamesAreUnique(t *testing.T) {
+	db := testDB(t)
+	ctx := context.Background()
+	if err := EnsureIndexes(ctx, db, testLogger); err != nil {
+		t.Fatalf("EnsureIndexes(): %v", err)
+	}
+	boards, columns := NewBoardRepository(db, testLogger), NewColumnRepository(db, testLogger)
+
+	board, err := boards.CreateBoard(ctx, &models.Board{ID: uuid.New(), Title: "board", User_id: "owner"})
+	if err != nil {
+		t.Fatalf("CreateBoard(): %v", err)
+	}
+	other, err := boards.CreateBoard(ctx, &models.Board{ID: uuid.New(), Title: "other", User_id: "owner"})
+	if err != nil {
+		t.Fatalf("CreateBoard(): %v", err)
+	}
+
+	review, err := columns.CreateColumn(ctx, &models.Column{ID: uuid.New(), Name: "Review", Desk_id: board.ID})
+	if err != nil {
+		t.Fatalf("CreateColumn(): %v", err)
+	}
+	if _, err := columns.CreateColumn(ctx, &models.Column{ID: uuid.New(), Name: "REVIEW", Desk_id: board.ID}); !mongo.IsDuplicateKeyError(err) {
+		t.Fatalf("expected a duplicate key error, got %v", err)
+	}
+	if _, err := columns.CreateColumn(ctx, &models.Column{ID: uuid.New(), Name: "Review", Desk_id: other.ID}); err != nil {
+		t.Fatalf("expected the name to be free on another board, got %v", err)
+	}
+
+	done, err := columns.CreateColumn(ctx, &models.Column{ID: uuid.New(), Name: "Done", Desk_id: board.ID})
+	if err != nil {
+		t.Fatalf("CreateColumn(): %v", err)
+	}
+	name := "review"
+	if _, err := columns.UpdateColumn(ctx, done.ID, &ColumnUpdates{Name: &name}); !mongo.IsDuplicateKeyError(err) {
+		t.Fatalf("expected a duplicate key error on rename, got %v", err)
+	}
+	if _, err := columns.UpdateColumn(ctx, review.ID, &ColumnUpdates{Name: &name}); err != nil {
+		t.Fatalf("UpdateColumn(): %v", err)
+	}
+
+	got, err := boards.GetBoard(ctx, board.ID)
+	if err != nil {
+		t.Fatalf("GetBoard(): %v", err)
+	}
+	if got.Columns_amount != 2 {
+		t.Fatalf("expected the rejected column to leave columns_amount at 2, got %d", got.Columns_amount)
+	}
+}
+
+func TestEnsureIndexesOverDuplicateColumnNames(t *testing.T) {
+	db := testDB(t)
+	ctx := context.Background()
+
+	boardID := uuid.New()
+	if _, err := db.Collection("Columns").InsertMany(ctx, []any{
+		models.Column{ID: uuid.New(), Name: "Review", Order_number: 1, Desk_id: boardID},
+		models.Column{ID: uuid.New(), Name: "review", Order_number: 2, Desk_id: boardID},
+		models.Column{ID: uuid.New(), Name: "Review", Order_number: 1, Desk_id: uuid.New()},
+	}); err != nil {
+		t.Fatalf("InsertMany(): %v", err)
+	}
+
+	if err := EnsureIndexes(ctx, db, testLogger); err != nil {
+		t.Fatalf("expected EnsureIndexes to start over duplicates, got %v", err)
+	}
+	boardIDs, err := boardsWithDuplicateColumnNames(ctx, db)
+	if err != nil {
+		t.Fatalf("boardsWithDuplicateColumnNames(): %v", err)
+	}
+	if len(boardIDs) != 1 || boardIDs[0] != boardID {
+		t.Fatalf("expected board %s to conflict, got %v", boardID, boardIDs)
+	}
+}
@@ -0,0 +1,143 @@
+package repository
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/SeiFlow-3P2/board_service/internal/models"
+	"github.com/SeiFlow-3P2/shared/telemetry"
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type MemberRepository interface {
+	AddMember(ctx context.Context, member *models.BoardMember) (*models.BoardMember, error)
+	GetMember(ctx context.Context, boardID uuid.UUID, userID string) (*models.BoardMember, error)
+	GetMembers(ctx context.Context, boardID uuid.UUID) ([]*models.BoardMember, error)
+	GetMemberBoardIDs(ctx context.Context, userID string) ([]uuid.UUID, error)
+	UpdateMemberRole(ctx context.Context, boardID uuid.UUID, userID string, role models.Role) (*models.BoardMember, error)
+	RemoveMember(ctx context.Context, boardID uuid.UUID, userID string) error
+}
+
+type memberRepository struct {
+	db     *mongo.Database
+	logger *slog.Logger
+}
+
+func NewMemberRepository(db *mongo.Database, logger *slog.Logger) MemberRepository {
+	return &memberRepository{db: db, logger: logger}
+}
+
+func (r *memberRepository) AddMember(ctx context.Context, member *models.BoardMember) (*models.BoardMember, error) {
+	ctx, span := startSpan(ctx, r.logger, "MemberRepository.AddMember")
+	defer span.End()
+
+	collection := r.db.Collection("Members")
+	_, err := collection.InsertOne(ctx, member)
+	if err != nil {
+		telemetry.RecordError(span, err)
+		return nil, err
+	}
+	return member, nil
+}
+
+func (r *memberRepository) GetMember(ctx context.Context, boardID uuid.UUID, userID string) (*models.BoardMember, error) {
+	ctx, span := startSpan(ctx, r.logger, "MemberRepository.GetMember")
+	defer span.End()
+
+	collection := r.db.Collection("Members")
+	var member models.BoardMember
+	err := collection.FindOne(ctx, bson.M{"board_id": boardID, "user_id": userID}).Decode(&member)
+	if err != nil {
+		telemetry.RecordError(span, err)
+		return nil, err
+	}
+	return &member, nil
+}
+
+func (r *memberRepository) GetMembers(ctx context.Context, boardID uuid.UUID) ([]*models.BoardMember, error) {
+	ctx, span := startSpan(ctx, r.logger, "MemberRepository.GetMembers")
+	defer span.End()
+
+	collection := r.db.Collection("Members")
+	var members []*models.BoardMember
+	options := options.Find().SetSort(bson.M{"created_at": 1})
+	cursor, err := collection.Find(ctx, bson.M{"board_id": boardID}, options)
+	if err != nil {
+		telemetry.RecordError(span, err)
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+	for cursor.Next(ctx) {
+		var member models.BoardMember
+		if err := cursor.Decode(&member); err != nil {
+			telemetry.RecordError(span, err)
+			return nil, err
+		}
+		members = append(members, &member)
+	}
+	if err := cursor.Err(); err != nil {
+		telemetry.RecordError(span, err)
+		return nil, err
+	}
+	return members, nil
+}
+
+func (r *memberRepository) GetMemberBoardIDs(ctx context.Context, userID string) ([]uuid.UUID, error) {
+	ctx, span := startSpan(ctx, r.logger, "MemberRepository.GetMemberBoardIDs")
+	defer span.End()
+
+	collection := r.db.Collection("Members")
+	var boardIDs []uuid.UUID
+	options := options.Find().SetProjection(bson.M{"board_id": 1})
+	cursor, err := collection.Find(ctx, bson.M{"user_id": userID}, options)
+	if err != nil {
+		telemetry.RecordError(span, err)
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+	for cursor.Next(ctx) {
+		var member models.BoardMember
+		if err := cursor.Decode(&member); err != nil {
+			telemetry.RecordError(span, err)
+			return nil, err
+		}
+		boardIDs = append(boardIDs, member.Board_id)
+	}
+	if err := cursor.Err(); err != nil {
+		telemetry.RecordError(span, err)
+		return nil, err
+	}
+	return boardIDs, nil
+}
+
+func (r *memberRepository) UpdateMemberRole(ctx context.Context, boardID uuid.UUID, userID string, role models.Role) (*models.BoardMember, error) {
+	ctx, span := startSpan(ctx, r.logger, "MemberRepository.UpdateMemberRole")
+	defer span.End()
+
+	collection := r.db.Collection("Members")
+	_, err := collection.UpdateOne(ctx,
+		bson.M{"board_id": boardID, "user_id": userID},
+		bson.M{"$set": bson.M{"role": role}},
+	)
+	if err != nil {
+		telemetry.RecordError(span, err)
+		return nil, err
+	}
+	return r.GetMember(ctx, boardID, userID)
+}
+
+func (r *memberRepository) RemoveMember(ctx context.Context, boardID uuid.UUID, userID string) error {
+	ctx, span := startSpan(ctx, r.logger, "MemberRepository.RemoveMember")
+	defer span.End()
+
+	collection := r.db.Collection("Members")
+	_, err := collection.DeleteOne(ctx, bson.M{"board_id": boardID, "user_id": userID})
+	if err != nil {
+		telemetry.RecordError(span, err)
+		return err
+	}
+	return nil
+}
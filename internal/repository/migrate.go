@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/SeiFlow-3P2/board_service/internal/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// MigrateEmbeddedColumns moves the columns that boards created before the
+// Columns collection held them still embed into that collection. The
+// board's columns are then numbered densely in their current order and its
+// columns_amount is set to their count. Boards without embedded columns are
+// left alone, so it is safe to call on every start.
+func MigrateEmbeddedColumns(ctx context.Context, db *mongo.Database, logger *slog.Logger) error {
+	filter := bson.M{"columns.0": bson.M{"$exists": true}}
+	cursor, err := db.Collection("Boards").Find(ctx, filter, options.Find().SetProjection(bson.M{"columns": 1}))
+	if err != nil {
+		return fmt.Errorf("failed to find boards with embedded columns: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	migrated := 0
+	for cursor.Next(ctx) {
+		var board models.Board
+		if err := cursor.Decode(&board); err != nil {
+			return fmt.Errorf("failed to decode board: %w", err)
+		}
+		err := withTransaction(ctx, db, func(sc mongo.SessionContext) error {
+			return migrateBoardColumns(sc, db, &board)
+		})
+		// A column created since may already use the name of an embedded
+		// one. The board keeps its embedded columns until that column is
+		// renamed.
+		if mongo.IsDuplicateKeyError(err) {
+			logger.WarnContext(ctx, "embedded columns share names with columns of the board, rename those and restart to move them",
+				"board_id", board.ID)
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to migrate the columns of board %s: %w", board.ID, err)
+		}
+		migrated++
+	}
+	if err := cursor.Err(); err != nil {
+		return fmt.Errorf("failed to find boards with embedded columns: %w", err)
+	}
+
+	if migrated > 0 {
+		logger.InfoContext(ctx, "moved embedded columns to the Columns collection", "boards", migrated)
+	}
+	return nil
+}
+
+func migrateBoardColumns(sc mongo.SessionContext, db *mongo.Database, board *models.Board) error {
+	columns := db.Collection("Columns")
+	for _, column := range board.Columns {
+		column.Desk_id = board.ID
+		column.Tasks = nil
+		if _, err := columns.ReplaceOne(sc, bson.M{"_id": column.ID}, column, options.Replace().SetUpsert(true)); err != nil {
+			return err
+		}
+	}
+
+	sort := bson.D{{Key: "order_number", Value: 1}, {Key: "_id", Value: 1}}
+	cursor, err := columns.Find(sc, bson.M{"desk_id": board.ID}, options.Find().SetSort(sort))
+	if err != nil {
+		return err
+	}
+	var stored []models.Column
+	if err := cursor.All(sc, &stored); err != nil {
+		return err
+	}
+	for i, column := range stored {
+		if column.Order_number == i+1 {
+			continue
+		}
+		if _, err := columns.UpdateOne(sc, bson.M{"_id": column.ID}, bson.M{"$set": bson.M{"order_number": i + 1}}); err != nil {
+			return err
+		}
+	}
+
+	_, err = db.Collection("Boards").UpdateOne(sc, bson.M{"_id": board.ID}, bson.M{
+		"$set":   bson.M{"columns_amount": len(stored)},
+		"$unset": bson.M{"columns": ""},
+	})
+	return err
+}
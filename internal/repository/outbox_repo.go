@@ -0,0 +1,237 @@
+package repository
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/SeiFlow-3P2/board_service/internal/models"
+	"github.com/SeiFlow-3P2/shared/telemetry"
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// OutboxRepository gives the relay access to the events that the other
+// repositories store alongside their changes.
+type OutboxRepository interface {
+	ClaimPending(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*models.OutboxEvent, error)
+	MarkSent(ctx context.Context, id uuid.UUID, sentAt time.Time) error
+	MarkFailed(ctx context.Context, id uuid.UUID, nextAttemptAt time.Time, reason string) error
+	Release(ctx context.Context, id uuid.UUID, nextAttemptAt time.Time) error
+}
+
+type outboxRepository struct {
+	db     *mongo.Database
+	logger *slog.Logger
+}
+
+func NewOutboxRepository(db *mongo.Database, logger *slog.Logger) OutboxRepository {
+	return &outboxRepository{db: db, logger: logger}
+}
+
+// ClaimPending returns up to limit unsent events that are due at now, oldest
+// first. Their next attempt is pushed to now+lease, so that other relays skip
+// them while they are being published. An event whose relay dies before
+// marking it is picked up again once the lease has run out.
+//
+// Events with the same key are published in the order they were created: of
+// every key, only the run of due events from its oldest unsent event on is
+// claimed. A key whose oldest unsent event is not due, because it failed or
+// is leased to another relay, waits.
+//
+// The events are selected with one aggregation and claimed with one update,
+// which marks them with a claim ID that they are read back by.
+func (r *outboxRepository) ClaimPending(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*models.OutboxEvent, error) {
+	ctx, span := startSpan(ctx, r.logger, "OutboxRepository.ClaimPending")
+	defer span.End()
+
+	collection := r.db.Collection("Outbox")
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"sent_at": nil}}},
+		{{Key: "$sort", Value: bson.D{{Key: "key", Value: 1}, {Key: "created_at", Value: 1}, {Key: "_id", Value: 1}}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":        "$key",
+			"created_at": bson.M{"$first": "$created_at"},
+			"events":     bson.M{"$push": bson.M{"_id": "$_id", "next_attempt_at": "$next_attempt_at"}},
+		}}},
+		{{Key: "$match", Value: bson.M{"events.0.next_attempt_at": bson.M{"$lte": now}}}},
+		{{Key: "$sort", Value: bson.D{{Key: "created_at", Value: 1}, {Key: "_id", Value: 1}}}},
+		{{Key: "$limit", Value: limit}},
+		{{Key: "$project", Value: bson.M{"events": bson.M{"$slice": bson.A{"$events", limit}}}}},
+	}
+	cursor, err := collection.Aggregate(ctx, pipeline, options.Aggregate().SetAllowDiskUse(true))
+	if err != nil {
+		telemetry.RecordError(span, err)
+		return nil, err
+	}
+	var keys []struct {
+		Events []struct {
+			ID              uuid.UUID `bson:"_id"`
+			Next_attempt_at time.Time `bson:"next_attempt_at"`
+		} `bson:"events"`
+	}
+	if err := cursor.All(ctx, &keys); err != nil {
+		telemetry.RecordError(span, err)
+		return nil, err
+	}
+
+	// runs holds the events to claim of every key, in order.
+	var runs [][]uuid.UUID
+	var ids []uuid.UUID
+	for _, key := range keys {
+		var run []uuid.UUID
+		for _, event := range key.Events {
+			if len(ids) == limit || event.Next_attempt_at.After(now) {
+				break
+			}
+			run = append(run, event.ID)
+			ids = append(ids, event.ID)
+		}
+		runs = append(runs, run)
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	// Another relay may have claimed some of the events in the meantime.
+	claim := uuid.New()
+	if _, err := collection.UpdateMany(ctx,
+		bson.M{"_id": bson.M{"$in": ids}, "sent_at": nil, "next_attempt_at": bson.M{"$lte": now}},
+		bson.M{"$set": bson.M{"next_attempt_at": now.Add(lease), "claim": claim}},
+	); err != nil {
+		telemetry.RecordError(span, err)
+		return nil, err
+	}
+	cursor, err = collection.Find(ctx, bson.M{"claim": claim}, options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}, {Key: "_id", Value: 1}}))
+	if err != nil {
+		telemetry.RecordError(span, err)
+		return nil, err
+	}
+	var claimed []*models.OutboxEvent
+	if err := cursor.All(ctx, &claimed); err != nil {
+		telemetry.RecordError(span, err)
+		return nil, err
+	}
+	if len(claimed) == len(ids) {
+		return claimed, nil
+	}
+
+	// An event that went to another relay holds back the later events of its
+	// key, which are handed back.
+	byID := map[uuid.UUID]bool{}
+	for _, event := range claimed {
+		byID[event.ID] = true
+	}
+	var released []uuid.UUID
+	for _, run := range runs {
+		for i, id := range run {
+			if byID[id] {
+				continue
+			}
+			for _, later := range run[i+1:] {
+				if byID[later] {
+					released = append(released, later)
+					delete(byID, later)
+				}
+			}
+			break
+		}
+	}
+	if len(released) > 0 {
+		if _, err := collection.UpdateMany(ctx,
+			bson.M{"_id": bson.M{"$in": released}, "claim": claim},
+			bson.M{"$set": bson.M{"next_attempt_at": now}},
+		); err != nil {
+			telemetry.RecordError(span, err)
+			return nil, err
+		}
+	}
+	kept := claimed[:0]
+	for _, event := range claimed {
+		if byID[event.ID] {
+			kept = append(kept, event)
+		}
+	}
+	return kept, nil
+}
+
+func (r *outboxRepository) MarkSent(ctx context.Context, id uuid.UUID, sentAt time.Time) error {
+	ctx, span := startSpan(ctx, r.logger, "OutboxRepository.MarkSent")
+	defer span.End()
+
+	collection := r.db.Collection("Outbox")
+	_, err := collection.UpdateOne(ctx,
+		bson.M{"_id": id},
+		bson.M{"$set": bson.M{"sent_at": sentAt}, "$inc": bson.M{"attempts": 1}},
+	)
+	if err != nil {
+		telemetry.RecordError(span, err)
+		return err
+	}
+	return nil
+}
+
+func (r *outboxRepository) MarkFailed(ctx context.Context, id uuid.UUID, nextAttemptAt time.Time, reason string) error {
+	ctx, span := startSpan(ctx, r.logger, "OutboxRepository.MarkFailed")
+	defer span.End()
+
+	collection := r.db.Collection("Outbox")
+	_, err := collection.UpdateOne(ctx,
+		bson.M{"_id": id},
+		bson.M{
+			"$set": bson.M{"next_attempt_at": nextAttemptAt, "last_error": reason},
+			"$inc": bson.M{"attempts": 1},
+		},
+	)
+	if err != nil {
+		telemetry.RecordError(span, err)
+		return err
+	}
+	return nil
+}
+
+// Release hands a claimed event back without publishing it, due again at
+// nextAttemptAt. Unlike MarkFailed it does not count as an attempt.
+func (r *outboxRepository) Release(ctx context.Context, id uuid.UUID, nextAttemptAt time.Time) error {
+	ctx, span := startSpan(ctx, r.logger, "OutboxRepository.Release")
+	defer span.End()
+
+	collection := r.db.Collection("Outbox")
+	_, err := collection.UpdateOne(ctx,
+		bson.M{"_id": id, "sent_at": nil},
+		bson.M{"$set": bson.M{"next_attempt_at": nextAttemptAt}},
+	)
+	if err != nil {
+		telemetry.RecordError(span, err)
+		return err
+	}
+	return nil
+}
+
+// withEvents runs fn and stores events in the Outbox collection in one
+// transaction. Without events fn runs on its own.
+func withEvents(ctx context.Context, db *mongo.Database, events []*models.OutboxEvent, fn func(ctx context.Context) error) error {
+	if len(events) == 0 {
+		return fn(ctx)
+	}
+	return withTransaction(ctx, db, func(sc mongo.SessionContext) error {
+		if err := fn(sc); err != nil {
+			return err
+		}
+		return insertEvents(sc, db, events)
+	})
+}
+
+func insertEvents(sc mongo.SessionContext, db *mongo.Database, events []*models.OutboxEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+	documents := make([]any, 0, len(events))
+	for _, event := range events {
+		documents = append(documents, event)
+	}
+	_, err := db.Collection("Outbox").InsertMany(sc, documents)
+	return err
+}
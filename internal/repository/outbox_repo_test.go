@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/SeiFlow-3P2/board_service/internal/models"
+	"github.com/google/uuid"
+)
+
+func TestOutboxEventsAreStoredWithTheChange(t *testing.T) {
+	db := testDB(t)
+	ctx := context.Background()
+	if err := EnsureIndexes(ctx, db, testLogger); err != nil {
+		t.Fatalf("EnsureIndexes(): %v", err)
+	}
+
+	now := time.Now().Truncate(time.Millisecond)
+	event := &models.OutboxEvent{ID: uuid.New(), Topic: "board.event", Payload: "{}", Created_at: now, Next_attempt_at: now}
+	task := &models.Task{ID: uuid.New(), Title: "task", Column_id: uuid.New()}
+	if _, err := NewTaskRepository(db, testLogger).CreateTask(ctx, task, event); err != nil {
+		t.Fatalf("CreateTask(): %v", err)
+	}
+
+	outbox := NewOutboxRepository(db, testLogger)
+	claimed, err := outbox.ClaimPending(ctx, now, time.Minute, 10)
+	if err != nil {
+		t.Fatalf("ClaimPending(): %v", err)
+	}
+	if len(claimed) != 1 || claimed[0].ID != event.ID {
+		t.Fatalf("expected the event to be claimed, got %v", claimed)
+	}
+
+	// A claimed event is leased to the relay that claimed it.
+	if claimed, err := outbox.ClaimPending(ctx, now, time.Minute, 10); err != nil || len(claimed) != 0 {
+		t.Fatalf("expected no events during the lease, got %v, %v", claimed, err)
+	}
+
+	if err := outbox.MarkSent(ctx, event.ID, now); err != nil {
+		t.Fatalf("MarkSent(): %v", err)
+	}
+	if claimed, err := outbox.ClaimPending(ctx, now.Add(time.Hour), time.Minute, 10); err != nil || len(claimed) != 0 {
+		t.Fatalf("expected sent events to stay sent, got %v, %v", claimed, err)
+	}
+}
+
+func TestClaimPendingKeepsTheOrderOfAKey(t *testing.T) {
+	db := testDB(t)
+	ctx := context.Background()
+	if err := EnsureIndexes(ctx, db, testLogger); err != nil {
+		t.Fatalf("EnsureIndexes(): %v", err)
+	}
+
+	now := time.Now().Truncate(time.Millisecond)
+	newEvent := func(key string, createdAt time.Time) *models.OutboxEvent {
+		return &models.OutboxEvent{ID: uuid.New(), Topic: "board.event", Key: key, Payload: "{}", Created_at: createdAt, Next_attempt_at: now}
+	}
+	create, remove := newEvent("owner", now), newEvent("owner", now.Add(time.Millisecond))
+	other := newEvent("somebody else", now.Add(2*time.Millisecond))
+	if _, err := db.Collection("Outbox").InsertMany(ctx, []any{create, remove, other}); err != nil {
+		t.Fatalf("InsertMany(): %v", err)
+	}
+
+	outbox := NewOutboxRepository(db, testLogger)
+	claimed, err := outbox.ClaimPending(ctx, now, time.Minute, 10)
+	if err != nil {
+		t.Fatalf("ClaimPending(): %v", err)
+	}
+	if len(claimed) != 3 || claimed[0].ID != create.ID || claimed[1].ID != remove.ID {
+		t.Fatalf("expected the events of a key in order, got %v", claimed)
+	}
+
+	// The create failed and the delete was handed back: only the create may
+	// be retried.
+	if err := outbox.MarkFailed(ctx, create.ID, now.Add(time.Second), "broker unavailable"); err != nil {
+		t.Fatalf("MarkFailed(): %v", err)
+	}
+	if err := outbox.Release(ctx, remove.ID, now); err != nil {
+		t.Fatalf("Release(): %v", err)
+	}
+	if claimed, err := outbox.ClaimPending(ctx, now, time.Minute, 10); err != nil || len(claimed) != 0 {
+		t.Fatalf("expected the delete to wait for the create, got %v, %v", claimed, err)
+	}
+
+	later := now.Add(time.Second)
+	claimed, err = outbox.ClaimPending(ctx, later, time.Minute, 10)
+	if err != nil {
+		t.Fatalf("ClaimPending(): %v", err)
+	}
+	if len(claimed) != 2 || claimed[0].ID != create.ID || claimed[1].ID != remove.ID {
+		t.Fatalf("expected the create before the delete, got %v", claimed)
+	}
+}
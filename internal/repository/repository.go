@@ -1,19 +1,180 @@
 package repository
 
 import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/SeiFlow-3P2/board_service/internal/metrics"
+	"github.com/SeiFlow-3P2/shared/telemetry"
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type Repository struct {
 	Board  BoardRepository
 	Task   TaskRepository
 	Column ColumnRepository
+	Member MemberRepository
+	Outbox OutboxRepository
 }
 
-func NewRepository(db *mongo.Database) *Repository {
+func NewRepository(db *mongo.Database, logger *slog.Logger) *Repository {
 	return &Repository{
-		Board:  NewBoardRepository(db),
-		Task:   NewTaskRepository(db),
-		Column: NewColumnRepository(db),
+		Board:  NewBoardRepository(db, logger),
+		Task:   NewTaskRepository(db, logger),
+		Column: NewColumnRepository(db, logger),
+		Member: NewMemberRepository(db, logger),
+		Outbox: NewOutboxRepository(db, logger),
+	}
+}
+
+// withTransaction runs fn inside a Mongo transaction. The driver retries fn on
+// transient errors such as write conflicts between concurrent transactions,
+// so fn must be safe to run more than once.
+func withTransaction(ctx context.Context, db *mongo.Database, fn func(sc mongo.SessionContext) error) error {
+	session, err := db.Client().StartSession()
+	if err != nil {
+		return err
+	}
+	defer session.EndSession(ctx)
+
+	_, err = session.WithTransaction(ctx, func(sc mongo.SessionContext) (any, error) {
+		return nil, fn(sc)
+	})
+	return err
+}
+
+// columnNameCollation compares column names regardless of case.
+var columnNameCollation = &options.Collation{Locale: "en", Strength: 2}
+
+// columnNameIndex keeps column names unique per board regardless of case.
+var columnNameIndex = mongo.IndexModel{
+	Keys:    bson.D{{Key: "desk_id", Value: 1}, {Key: "name", Value: 1}},
+	Options: options.Index().SetUnique(true).SetCollation(columnNameCollation),
+}
+
+// EnsureIndexes creates the indexes the repositories rely on. Creating an
+// index that already exists is a no-op, so it is safe to call on every start.
+//
+// Boards created before column names had to be unique may hold duplicates,
+// which the unique index on column names cannot be built over. Then the
+// conflicting boards are logged and the service starts without the index;
+// it is built on the first start after the duplicates have been renamed.
+func EnsureIndexes(ctx context.Context, db *mongo.Database, logger *slog.Logger) error {
+	indexes := map[string][]mongo.IndexModel{
+		// The owned boards of a listing are found and sorted by one of these;
+		// the category, methodology and favorite filters are checked on the
+		// boards found. The shared boards are found by _id.
+		"Boards": {
+			{Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "updated_at", Value: -1}, {Key: "_id", Value: -1}}},
+			{Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "created_at", Value: -1}, {Key: "_id", Value: -1}}},
+			{Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "title", Value: 1}, {Key: "_id", Value: 1}}},
+		},
+		"Columns": {
+			{Keys: bson.D{{Key: "desk_id", Value: 1}, {Key: "order_number", Value: 1}}},
+		},
+		"Tasks": {
+			{Keys: bson.D{{Key: "column_id", Value: 1}, {Key: "rank", Value: 1}, {Key: "_id", Value: 1}}},
+			{
+				Keys:    bson.D{{Key: "title", Value: "text"}, {Key: "description", Value: "text"}},
+				Options: options.Index().SetWeights(bson.D{{Key: "title", Value: 3}, {Key: "description", Value: 1}}),
+			},
+		},
+		"Members": {
+			{Keys: bson.D{{Key: "board_id", Value: 1}, {Key: "user_id", Value: 1}}, Options: options.Index().SetUnique(true)},
+			{Keys: bson.D{{Key: "user_id", Value: 1}}},
+		},
+		"Outbox": {
+			{Keys: bson.D{{Key: "sent_at", Value: 1}, {Key: "next_attempt_at", Value: 1}}},
+			// Orders the unsent events by key for claiming them.
+			{Keys: bson.D{{Key: "sent_at", Value: 1}, {Key: "key", Value: 1}, {Key: "created_at", Value: 1}, {Key: "_id", Value: 1}}},
+			// Reads claimed events back.
+			{Keys: bson.D{{Key: "claim", Value: 1}}, Options: options.Index().SetSparse(true)},
+			// Published events are kept for a week for troubleshooting.
+			{Keys: bson.D{{Key: "sent_at", Value: 1}}, Options: options.Index().SetExpireAfterSeconds(7 * 24 * 60 * 60)},
+		},
+		"ProcessedEvents": {
+			// Redeliveries happen within minutes, a month is plenty.
+			{Keys: bson.D{{Key: "processed_at", Value: 1}}, Options: options.Index().SetExpireAfterSeconds(30 * 24 * 60 * 60)},
+		},
+	}
+
+	for collection, models := range indexes {
+		if _, err := db.Collection(collection).Indexes().CreateMany(ctx, models); err != nil {
+			return fmt.Errorf("failed to create %s indexes: %w", collection, err)
+		}
+	}
+
+	_, err := db.Collection("Columns").Indexes().CreateOne(ctx, columnNameIndex)
+	if !mongo.IsDuplicateKeyError(err) {
+		if err != nil {
+			return fmt.Errorf("failed to create Columns indexes: %w", err)
+		}
+		return nil
 	}
+	boardIDs, err := boardsWithDuplicateColumnNames(ctx, db)
+	if err != nil {
+		return fmt.Errorf("failed to find duplicate column names: %w", err)
+	}
+	logger.WarnContext(ctx, "column names are not unique, rename the duplicates and restart to enforce unique names",
+		"board_ids", boardIDs)
+	return nil
+}
+
+// boardsWithDuplicateColumnNames returns the boards that have several
+// columns of the same name, compared the way the unique index does.
+func boardsWithDuplicateColumnNames(ctx context.Context, db *mongo.Database) ([]uuid.UUID, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$group", Value: bson.M{
+			"_id":   bson.M{"desk_id": "$desk_id", "name": "$name"},
+			"count": bson.M{"$sum": 1},
+		}}},
+		{{Key: "$match", Value: bson.M{"count": bson.M{"$gt": 1}}}},
+		{{Key: "$group", Value: bson.M{"_id": "$_id.desk_id"}}},
+	}
+	cursor, err := db.Collection("Columns").Aggregate(ctx, pipeline, options.Aggregate().SetCollation(columnNameCollation))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var boards []struct {
+		ID uuid.UUID `bson:"_id"`
+	}
+	if err := cursor.All(ctx, &boards); err != nil {
+		return nil, err
+	}
+	ids := make([]uuid.UUID, 0, len(boards))
+	for _, board := range boards {
+		ids = append(ids, board.ID)
+	}
+	return ids, nil
+}
+
+// timedSpan records the duration of a repository operation when it ends.
+type timedSpan struct {
+	trace.Span
+	ctx       context.Context
+	logger    *slog.Logger
+	operation string
+	start     time.Time
+}
+
+func (s *timedSpan) End(options ...trace.SpanEndOption) {
+	duration := time.Since(s.start)
+	metrics.ObserveMongo(s.operation, duration)
+	s.logger.DebugContext(s.ctx, "mongo operation finished", "operation", s.operation, "duration", duration)
+	s.Span.End(options...)
+}
+
+// startSpan starts the trace span of a repository operation, whose duration
+// is also exported as a metric and logged at debug level when the span ends.
+func startSpan(ctx context.Context, logger *slog.Logger, operation string) (context.Context, trace.Span) {
+	ctx, span := telemetry.StartSpan(ctx, operation)
+	return ctx, &timedSpan{Span: span, ctx: ctx, logger: logger, operation: operation, start: time.Now()}
 }
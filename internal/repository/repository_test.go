@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var testLogger = slog.New(slog.DiscardHandler)
+
+// testDB connects to the replica set at MONGO_TEST_URL and returns a fresh
+// database that is dropped when the test ends. Transactions need a replica
+// set, so the tests are skipped when the variable is not set.
+func testDB(tb testing.TB) *mongo.Database {
+	tb.Helper()
+
+	uri := os.Getenv("MONGO_TEST_URL")
+	if uri == "" {
+		tb.Skip("MONGO_TEST_URL is not set")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(uri))
+	if err != nil {
+		tb.Fatalf("failed to connect to MongoDB: %v", err)
+	}
+	if err := client.Ping(ctx, nil); err != nil {
+		tb.Fatalf("failed to ping MongoDB: %v", err)
+	}
+
+	db := client.Database("board_service_test_" + uuid.NewString()[:8])
+	tb.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		_ = db.Drop(ctx)
+		_ = client.Disconnect(ctx)
+	})
+	return db
+}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"log/slog"
 	"time"
 
 	"github.com/SeiFlow-3P2/board_service/internal/models"
@@ -9,36 +10,81 @@ import (
 	"github.com/google/uuid"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 type TaskRepository interface {
-	CreateTask(ctx context.Context, task *models.Task) (*models.Task, error)
+	CreateTask(ctx context.Context, task *models.Task, events ...*models.OutboxEvent) (*models.Task, error)
 	GetTask(ctx context.Context, id uuid.UUID) (*models.Task, error)
-	MoveTask(ctx context.Context, id uuid.UUID, newColumnID uuid.UUID) error
-	UpdateTask(ctx context.Context, id uuid.UUID, updates *TaskUpdates) (*models.Task, error)
-	DeleteTask(ctx context.Context, id uuid.UUID) error
+	GetColumnTasks(ctx context.Context, columnID uuid.UUID) ([]*models.Task, error)
+	GetLastRank(ctx context.Context, columnID uuid.UUID) (string, error)
+	MoveTask(ctx context.Context, id uuid.UUID, newColumnID uuid.UUID, rank string, events ...*models.OutboxEvent) error
+	UpdateRanks(ctx context.Context, columnID uuid.UUID, ranks map[uuid.UUID]string) error
+	UpdateTask(ctx context.Context, id uuid.UUID, updates *TaskUpdates, events ...*models.OutboxEvent) (*models.Task, error)
+	DeleteTask(ctx context.Context, id uuid.UUID, events ...*models.OutboxEvent) error
+	ApplyEventOnce(ctx context.Context, eventID uuid.UUID, id uuid.UUID, updates *TaskUpdates) error
+	SearchTasks(ctx context.Context, query *TaskQuery) ([]*TaskMatch, error)
 }
 
+// The methods that change tasks store the given events in the Outbox
+// collection in the same transaction as the change.
+
 type TaskUpdates struct {
 	Title       *string    `bson:"title,omitempty"`
 	Description *string    `bson:"description,omitempty"`
 	Deadline    *time.Time `bson:"deadline,omitempty"`
+	In_Calendar *bool      `bson:"in_calendar,omitempty"`
+	// Calendar_user_id is set when the task is put in the calendar.
+	Calendar_user_id *string `bson:"calendar_user_id,omitempty"`
+}
+
+// TaskQuery selects a page of the tasks on BoardIDs, or in ColumnID if set,
+// whose title or description matches Text, a MongoDB $text search. Tasks
+// are sorted by relevance with the ID as a tie-breaker.
+type TaskQuery struct {
+	Text         string
+	BoardIDs     []uuid.UUID
+	ColumnID     *uuid.UUID
+	DeadlineFrom *time.Time
+	DeadlineTo   *time.Time
+	InCalendar   *bool
+	After        *TaskCursor
+	Limit        int
+}
+
+// TaskCursor is the position of the last task of the previous page.
+type TaskCursor struct {
+	Score float64
+	ID    uuid.UUID
+}
+
+// TaskMatch is a task found by SearchTasks with its relevance and the column
+// and board it is on. Column and Board carry no nested columns or tasks.
+type TaskMatch struct {
+	models.Task `bson:",inline"`
+	Score       float64       `bson:"score"`
+	Column      models.Column `bson:"column"`
+	Board       models.Board  `bson:"board"`
 }
 
 type taskRepository struct {
-	db *mongo.Database
+	db     *mongo.Database
+	logger *slog.Logger
 }
 
-func NewTaskRepository(db *mongo.Database) TaskRepository {
-	return &taskRepository{db: db}
+func NewTaskRepository(db *mongo.Database, logger *slog.Logger) TaskRepository {
+	return &taskRepository{db: db, logger: logger}
 }
 
-func (r *taskRepository) CreateTask(ctx context.Context, task *models.Task) (*models.Task, error) {
-	ctx, span := telemetry.StartSpan(ctx, "TaskRepository.CreateTask")
+func (r *taskRepository) CreateTask(ctx context.Context, task *models.Task, events ...*models.OutboxEvent) (*models.Task, error) {
+	ctx, span := startSpan(ctx, r.logger, "TaskRepository.CreateTask")
 	defer span.End()
 
 	collection := r.db.Collection("Tasks")
-	_, err := collection.InsertOne(ctx, task)
+	err := withEvents(ctx, r.db, events, func(ctx context.Context) error {
+		_, err := collection.InsertOne(ctx, task)
+		return err
+	})
 	if err != nil {
 		telemetry.RecordError(span, err)
 		return nil, err
@@ -47,7 +93,7 @@ func (r *taskRepository) CreateTask(ctx context.Context, task *models.Task) (*mo
 }
 
 func (r *taskRepository) GetTask(ctx context.Context, id uuid.UUID) (*models.Task, error) {
-	ctx, span := telemetry.StartSpan(ctx, "TaskRepository.GetTask")
+	ctx, span := startSpan(ctx, r.logger, "TaskRepository.GetTask")
 	defer span.End()
 
 	collection := r.db.Collection("Tasks")
@@ -60,12 +106,95 @@ func (r *taskRepository) GetTask(ctx context.Context, id uuid.UUID) (*models.Tas
 	return &task, nil
 }
 
-func (r *taskRepository) MoveTask(ctx context.Context, id uuid.UUID, newColumnID uuid.UUID) error {
-	ctx, span := telemetry.StartSpan(ctx, "TaskRepository.MoveTask")
+// GetColumnTasks returns the tasks of a column in rank order.
+func (r *taskRepository) GetColumnTasks(ctx context.Context, columnID uuid.UUID) ([]*models.Task, error) {
+	ctx, span := startSpan(ctx, r.logger, "TaskRepository.GetColumnTasks")
+	defer span.End()
+
+	collection := r.db.Collection("Tasks")
+	var tasks []*models.Task
+	options := options.Find().SetSort(bson.D{{Key: "rank", Value: 1}, {Key: "_id", Value: 1}})
+	cursor, err := collection.Find(ctx, bson.M{"column_id": columnID}, options)
+	if err != nil {
+		telemetry.RecordError(span, err)
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+	for cursor.Next(ctx) {
+		var task models.Task
+		if err := cursor.Decode(&task); err != nil {
+			telemetry.RecordError(span, err)
+			return nil, err
+		}
+		tasks = append(tasks, &task)
+	}
+	if err := cursor.Err(); err != nil {
+		telemetry.RecordError(span, err)
+		return nil, err
+	}
+	return tasks, nil
+}
+
+// GetLastRank returns the highest rank in a column, or an empty string if the
+// column has no tasks.
+func (r *taskRepository) GetLastRank(ctx context.Context, columnID uuid.UUID) (string, error) {
+	ctx, span := startSpan(ctx, r.logger, "TaskRepository.GetLastRank")
+	defer span.End()
+
+	collection := r.db.Collection("Tasks")
+	var task models.Task
+	options := options.FindOne().
+		SetSort(bson.M{"rank": -1}).
+		SetProjection(bson.M{"rank": 1})
+	err := collection.FindOne(ctx, bson.M{"column_id": columnID}, options).Decode(&task)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return "", nil
+		}
+		telemetry.RecordError(span, err)
+		return "", err
+	}
+	return task.Rank, nil
+}
+
+func (r *taskRepository) MoveTask(ctx context.Context, id uuid.UUID, newColumnID uuid.UUID, rank string, events ...*models.OutboxEvent) error {
+	ctx, span := startSpan(ctx, r.logger, "TaskRepository.MoveTask")
+	defer span.End()
+
+	collection := r.db.Collection("Tasks")
+	err := withEvents(ctx, r.db, events, func(ctx context.Context) error {
+		_, err := collection.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": bson.M{
+			"column_id": newColumnID,
+			"rank":      rank,
+		}})
+		return err
+	})
+	if err != nil {
+		telemetry.RecordError(span, err)
+		return err
+	}
+	return nil
+}
+
+// UpdateRanks rewrites the ranks of several tasks of a column in one bulk
+// write. Tasks that have left the column in the meantime keep their rank.
+func (r *taskRepository) UpdateRanks(ctx context.Context, columnID uuid.UUID, ranks map[uuid.UUID]string) error {
+	ctx, span := startSpan(ctx, r.logger, "TaskRepository.UpdateRanks")
 	defer span.End()
 
+	if len(ranks) == 0 {
+		return nil
+	}
+
+	writes := make([]mongo.WriteModel, 0, len(ranks))
+	for id, rank := range ranks {
+		writes = append(writes, mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"_id": id, "column_id": columnID}).
+			SetUpdate(bson.M{"$set": bson.M{"rank": rank}}))
+	}
+
 	collection := r.db.Collection("Tasks")
-	_, err := collection.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": bson.M{"column_id": newColumnID}})
+	_, err := collection.BulkWrite(ctx, writes, options.BulkWrite().SetOrdered(false))
 	if err != nil {
 		telemetry.RecordError(span, err)
 		return err
@@ -73,8 +202,8 @@ func (r *taskRepository) MoveTask(ctx context.Context, id uuid.UUID, newColumnID
 	return nil
 }
 
-func (r *taskRepository) UpdateTask(ctx context.Context, id uuid.UUID, updates *TaskUpdates) (*models.Task, error) {
-	ctx, span := telemetry.StartSpan(ctx, "TaskRepository.UpdateTask")
+func (r *taskRepository) UpdateTask(ctx context.Context, id uuid.UUID, updates *TaskUpdates, events ...*models.OutboxEvent) (*models.Task, error) {
+	ctx, span := startSpan(ctx, r.logger, "TaskRepository.UpdateTask")
 	defer span.End()
 
 	collection := r.db.Collection("Tasks")
@@ -89,12 +218,21 @@ func (r *taskRepository) UpdateTask(ctx context.Context, id uuid.UUID, updates *
 	if updates.Deadline != nil {
 		updateFields["deadline"] = *updates.Deadline
 	}
+	if updates.In_Calendar != nil {
+		updateFields["in_calendar"] = *updates.In_Calendar
+	}
+	if updates.Calendar_user_id != nil {
+		updateFields["calendar_user_id"] = *updates.Calendar_user_id
+	}
 
 	if len(updateFields) == 0 {
 		return r.GetTask(ctx, id)
 	}
 
-	_, err := collection.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": updateFields})
+	err := withEvents(ctx, r.db, events, func(ctx context.Context) error {
+		_, err := collection.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": updateFields})
+		return err
+	})
 	if err != nil {
 		telemetry.RecordError(span, err)
 		return nil, err
@@ -102,15 +240,148 @@ func (r *taskRepository) UpdateTask(ctx context.Context, id uuid.UUID, updates *
 	return r.GetTask(ctx, id)
 }
 
-func (r *taskRepository) DeleteTask(ctx context.Context, id uuid.UUID) error {
-	ctx, span := telemetry.StartSpan(ctx, "TaskRepository.DeleteTask")
+func (r *taskRepository) DeleteTask(ctx context.Context, id uuid.UUID, events ...*models.OutboxEvent) error {
+	ctx, span := startSpan(ctx, r.logger, "TaskRepository.DeleteTask")
 	defer span.End()
 
 	collection := r.db.Collection("Tasks")
-	_, err := collection.DeleteOne(ctx, bson.M{"_id": id})
+	err := withEvents(ctx, r.db, events, func(ctx context.Context) error {
+		_, err := collection.DeleteOne(ctx, bson.M{"_id": id})
+		return err
+	})
+	if err != nil {
+		telemetry.RecordError(span, err)
+		return err
+	}
+	return nil
+}
+
+// ApplyEventOnce applies updates that come from an event of another service
+// to the task. The event ID is recorded in the ProcessedEvents collection in
+// the same transaction, and an event that was applied before is skipped, so
+// redelivered events change nothing. A missing task is reported as
+// mongo.ErrNoDocuments.
+func (r *taskRepository) ApplyEventOnce(ctx context.Context, eventID uuid.UUID, id uuid.UUID, updates *TaskUpdates) error {
+	ctx, span := startSpan(ctx, r.logger, "TaskRepository.ApplyEventOnce")
+	defer span.End()
+
+	updateFields := bson.M{}
+	if updates.Deadline != nil {
+		updateFields["deadline"] = *updates.Deadline
+	}
+	if updates.In_Calendar != nil {
+		updateFields["in_calendar"] = *updates.In_Calendar
+	}
+
+	processed, err := r.db.Collection("ProcessedEvents").CountDocuments(ctx, bson.M{"_id": eventID})
+	if err != nil {
+		telemetry.RecordError(span, err)
+		return err
+	}
+	if processed > 0 {
+		return nil
+	}
+
+	err = withTransaction(ctx, r.db, func(sc mongo.SessionContext) error {
+		result, err := r.db.Collection("Tasks").UpdateOne(sc, bson.M{"_id": id}, bson.M{"$set": updateFields})
+		if err != nil {
+			return err
+		}
+		if result.MatchedCount == 0 {
+			return mongo.ErrNoDocuments
+		}
+		_, err = r.db.Collection("ProcessedEvents").InsertOne(sc, bson.M{"_id": eventID, "processed_at": time.Now()})
+		return err
+	})
+	if mongo.IsDuplicateKeyError(err) {
+		// Another consumer applied the event first.
+		return nil
+	}
 	if err != nil {
 		telemetry.RecordError(span, err)
 		return err
 	}
 	return nil
 }
+
+func (r *taskRepository) SearchTasks(ctx context.Context, query *TaskQuery) ([]*TaskMatch, error) {
+	ctx, span := startSpan(ctx, r.logger, "TaskRepository.SearchTasks")
+	defer span.End()
+
+	// Tasks do not store their board, so the search is limited to the
+	// columns of the boards.
+	columnIDs := bson.A{}
+	if query.ColumnID != nil {
+		columnIDs = append(columnIDs, *query.ColumnID)
+	} else if len(query.BoardIDs) > 0 {
+		ids, err := r.db.Collection("Columns").Distinct(ctx, "_id", bson.M{"desk_id": bson.M{"$in": query.BoardIDs}})
+		if err != nil {
+			telemetry.RecordError(span, err)
+			return nil, err
+		}
+		columnIDs = ids
+	}
+	if len(columnIDs) == 0 {
+		return nil, nil
+	}
+
+	filter := bson.M{
+		"$text":     bson.M{"$search": query.Text},
+		"column_id": bson.M{"$in": columnIDs},
+	}
+	deadline := bson.M{}
+	if query.DeadlineFrom != nil {
+		deadline["$gte"] = *query.DeadlineFrom
+	}
+	if query.DeadlineTo != nil {
+		deadline["$lte"] = *query.DeadlineTo
+	}
+	if len(deadline) > 0 {
+		filter["deadline"] = deadline
+	}
+	if query.InCalendar != nil {
+		filter["in_calendar"] = *query.InCalendar
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: filter}},
+		{{Key: "$addFields", Value: bson.M{"score": bson.M{"$meta": "textScore"}}}},
+	}
+	if query.After != nil {
+		pipeline = append(pipeline, bson.D{{Key: "$match", Value: bson.M{"$or": bson.A{
+			bson.M{"score": bson.M{"$lt": query.After.Score}},
+			bson.M{"score": query.After.Score, "_id": bson.M{"$gt": query.After.ID}},
+		}}}})
+	}
+	pipeline = append(pipeline,
+		bson.D{{Key: "$sort", Value: bson.D{{Key: "score", Value: -1}, {Key: "_id", Value: 1}}}},
+		bson.D{{Key: "$limit", Value: query.Limit}},
+		bson.D{{Key: "$lookup", Value: bson.M{
+			"from":         "Columns",
+			"localField":   "column_id",
+			"foreignField": "_id",
+			"as":           "column",
+		}}},
+		bson.D{{Key: "$unwind", Value: "$column"}},
+		bson.D{{Key: "$lookup", Value: bson.M{
+			"from":         "Boards",
+			"localField":   "column.desk_id",
+			"foreignField": "_id",
+			"as":           "board",
+		}}},
+		bson.D{{Key: "$unwind", Value: "$board"}},
+		bson.D{{Key: "$project", Value: bson.M{"board.columns": 0}}},
+	)
+
+	cursor, err := r.db.Collection("Tasks").Aggregate(ctx, pipeline)
+	if err != nil {
+		telemetry.RecordError(span, err)
+		return nil, err
+	}
+	var matches []*TaskMatch
+	if err := cursor.All(ctx, &matches); err != nil {
+		telemetry.RecordError(span, err)
+		return nil, err
+	}
+	return matches, nil
+}
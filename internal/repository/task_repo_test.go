@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/SeiFlow-3P2/board_service/internal/models"
+	"github.com/google/uuid"
+)
+
+func TestSearchTasks(t *testing.T) {
+	db := testDB(t)
+	ctx := context.Background()
+	if err := EnsureIndexes(ctx, db, testLogger); err != nil {
+		t.Fatalf("EnsureIndexes(): %v", err)
+	}
+	boards := NewBoardRepository(db, testLogger)
+	tasks := NewTaskRepository(db, testLogger)
+
+	now := time.Now().Truncate(time.Millisecond)
+	board := &models.Board{ID: uuid.New(), Title: "Release", User_id: "owner"}
+	other := &models.Board{ID: uuid.New(), Title: "Other", User_id: "other"}
+	todo := models.Column{ID: uuid.New(), Name: "To do", Desk_id: board.ID}
+	done := models.Column{ID: uuid.New(), Name: "Done", Desk_id: board.ID}
+	board.Columns = []models.Column{todo, done}
+	other.Columns = []models.Column{{ID: uuid.New(), Name: "To do", Desk_id: other.ID}}
+	for _, b := range []*models.Board{board, other} {
+		if _, err := boards.CreateBoard(ctx, b); err != nil {
+			t.Fatalf("CreateBoard(): %v", err)
+		}
+	}
+
+	for _, task := range []*models.Task{
+		{ID: uuid.New(), Title: "Release notes", Description: "Draft", Column_id: todo.ID, Deadline: now.Add(time.Hour)},
+		{ID: uuid.New(), Title: "Changelog", Description: "For the release", Column_id: todo.ID, Deadline: now.Add(48 * time.Hour)},
+		{ID: uuid.New(), Title: "Tag release", Description: "Push the tag", Column_id: done.ID, Deadline: now.Add(time.Hour), In_Calendar: true},
+		{ID: uuid.New(), Title: "Unrelated", Description: "Nothing", Column_id: todo.ID, Deadline: now},
+		{ID: uuid.New(), Title: "Release elsewhere", Description: "Draft", Column_id: other.Columns[0].ID, Deadline: now},
+	} {
+		if _, err := tasks.CreateTask(ctx, task); err != nil {
+			t.Fatalf("CreateTask(): %v", err)
+		}
+	}
+
+	query := &TaskQuery{Text: "release", BoardIDs: []uuid.UUID{board.ID}, Limit: 2}
+	var titles []string
+	for {
+		page, err := tasks.SearchTasks(ctx, query)
+		if err != nil {
+			t.Fatalf("SearchTasks(): %v", err)
+		}
+		if len(page) == 0 {
+			break
+		}
+		for _, match := range page {
+			if match.Board.Title != board.Title || match.Column.ID != match.Column_id {
+				t.Fatalf("expected the context of %q, got board %q and column %v", match.Title, match.Board.Title, match.Column.ID)
+			}
+			titles = append(titles, match.Title)
+		}
+		last := page[len(page)-1]
+		query.After = &TaskCursor{Score: last.Score, ID: last.ID}
+	}
+	if len(titles) != 3 || titles[2] != "Changelog" {
+		t.Fatalf("expected the title matches before Changelog, got %v", titles)
+	}
+
+	inCalendar := true
+	to := now.Add(2 * time.Hour)
+	page, err := tasks.SearchTasks(ctx, &TaskQuery{
+		Text:       "release",
+		BoardIDs:   []uuid.UUID{board.ID},
+		DeadlineTo: &to,
+		InCalendar: &inCalendar,
+		Limit:      10,
+	})
+	if err != nil {
+		t.Fatalf("SearchTasks(): %v", err)
+	}
+	if len(page) != 1 || page[0].Title != "Tag release" || page[0].Column.Name != "Done" {
+		t.Fatalf("expected only Tag release, got %v", page)
+	}
+}
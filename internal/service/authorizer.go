@@ -0,0 +1,199 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/SeiFlow-3P2/board_service/internal/interceptor"
+	"github.com/SeiFlow-3P2/board_service/internal/models"
+	"github.com/SeiFlow-3P2/board_service/internal/repository"
+	"github.com/SeiFlow-3P2/shared/telemetry"
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+var ErrPermissionDenied = errors.New("permission denied")
+
+// Authorizer resolves tasks and columns to the board they belong to and
+// checks that the user from the context holds at least the required role on
+// that board. The board's creator is its owner, everybody else needs a
+// membership record.
+//
+// A missing resource is reported with the matching Err*NotFound error, an
+// existing resource the caller has no sufficient role for with
+// ErrPermissionDenied.
+type Authorizer struct {
+	boardRepo  repository.BoardRepository
+	columnRepo repository.ColumnRepository
+	taskRepo   repository.TaskRepository
+	memberRepo repository.MemberRepository
+}
+
+func NewAuthorizer(
+	boardRepo repository.BoardRepository,
+	columnRepo repository.ColumnRepository,
+	taskRepo repository.TaskRepository,
+	memberRepo repository.MemberRepository,
+) *Authorizer {
+	return &Authorizer{
+		boardRepo:  boardRepo,
+		columnRepo: columnRepo,
+		taskRepo:   taskRepo,
+		memberRepo: memberRepo,
+	}
+}
+
+func userIDFromContext(ctx context.Context) (string, error) {
+	userID, ok := ctx.Value(interceptor.UserIDKey).(string)
+	if !ok || userID == "" {
+		return "", ErrUserNotInContext
+	}
+	return userID, nil
+}
+
+// Board returns the board with the given ID if the caller holds the required
+// role on it.
+func (a *Authorizer) Board(ctx context.Context, boardID uuid.UUID, required models.Role) (*models.Board, error) {
+	ctx, span := telemetry.StartSpan(ctx, "Authorizer.Board")
+	defer span.End()
+
+	userID, err := userIDFromContext(ctx)
+	if err != nil {
+		telemetry.RecordError(span, err)
+		return nil, err
+	}
+
+	board, err := a.boardRepo.GetBoard(ctx, boardID)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			telemetry.RecordError(span, ErrBoardNotFound)
+			return nil, ErrBoardNotFound
+		}
+		telemetry.RecordError(span, err)
+		return nil, err
+	}
+
+	role, err := a.role(ctx, board, userID)
+	if err != nil {
+		telemetry.RecordError(span, err)
+		return nil, err
+	}
+
+	if !role.Allows(required) {
+		telemetry.RecordError(span, ErrPermissionDenied)
+		return nil, ErrPermissionDenied
+	}
+
+	return board, nil
+}
+
+// BoardIDs returns the IDs of the boards the caller owns or is a member of,
+// that is every board they can view.
+func (a *Authorizer) BoardIDs(ctx context.Context) ([]uuid.UUID, error) {
+	ctx, span := telemetry.StartSpan(ctx, "Authorizer.BoardIDs")
+	defer span.End()
+
+	userID, err := userIDFromContext(ctx)
+	if err != nil {
+		telemetry.RecordError(span, err)
+		return nil, err
+	}
+
+	owned, err := a.boardRepo.GetBoards(ctx, userID)
+	if err != nil {
+		telemetry.RecordError(span, err)
+		return nil, err
+	}
+	ids, err := a.memberRepo.GetMemberBoardIDs(ctx, userID)
+	if err != nil {
+		telemetry.RecordError(span, err)
+		return nil, err
+	}
+	for _, board := range owned {
+		ids = append(ids, board.ID)
+	}
+	return ids, nil
+}
+
+// role returns the role userID holds on board, or ErrPermissionDenied if the
+// user is neither the owner nor a member.
+func (a *Authorizer) role(ctx context.Context, board *models.Board, userID string) (models.Role, error) {
+	if board.User_id == userID {
+		return models.RoleOwner, nil
+	}
+
+	member, err := a.memberRepo.GetMember(ctx, board.ID, userID)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return "", ErrPermissionDenied
+		}
+		return "", err
+	}
+	return member.Role, nil
+}
+
+// Column returns the column with the given ID if the caller holds the
+// required role on the board it belongs to.
+func (a *Authorizer) Column(ctx context.Context, columnID uuid.UUID, required models.Role) (*models.Column, error) {
+	column, _, err := a.columnWithBoard(ctx, columnID, required)
+	return column, err
+}
+
+// columnWithBoard is Column that also returns the board of the column.
+func (a *Authorizer) columnWithBoard(ctx context.Context, columnID uuid.UUID, required models.Role) (*models.Column, *models.Board, error) {
+	ctx, span := telemetry.StartSpan(ctx, "Authorizer.Column")
+	defer span.End()
+
+	column, err := a.columnRepo.GetColumnInfo(ctx, columnID)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			telemetry.RecordError(span, ErrColumnNotFound)
+			return nil, nil, ErrColumnNotFound
+		}
+		telemetry.RecordError(span, err)
+		return nil, nil, err
+	}
+
+	board, err := a.Board(ctx, column.Desk_id, required)
+	if err != nil {
+		telemetry.RecordError(span, err)
+		return nil, nil, err
+	}
+
+	return column, board, nil
+}
+
+// Task returns the task with the given ID together with its column if the
+// caller holds the required role on the board the task belongs to.
+func (a *Authorizer) Task(ctx context.Context, taskID uuid.UUID, required models.Role) (*models.Task, *models.Column, error) {
+	task, column, _, err := a.taskWithBoard(ctx, taskID, required)
+	return task, column, err
+}
+
+// taskWithBoard is Task that also returns the board of the task.
+func (a *Authorizer) taskWithBoard(ctx context.Context, taskID uuid.UUID, required models.Role) (*models.Task, *models.Column, *models.Board, error) {
+	ctx, span := telemetry.StartSpan(ctx, "Authorizer.Task")
+	defer span.End()
+
+	task, err := a.taskRepo.GetTask(ctx, taskID)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			telemetry.RecordError(span, ErrTaskNotFound)
+			return nil, nil, nil, ErrTaskNotFound
+		}
+		telemetry.RecordError(span, err)
+		return nil, nil, nil, err
+	}
+
+	column, board, err := a.columnWithBoard(ctx, task.Column_id, required)
+	if err != nil {
+		// A task whose column is gone is unreachable through the board.
+		if err == ErrColumnNotFound {
+			err = ErrTaskNotFound
+		}
+		telemetry.RecordError(span, err)
+		return nil, nil, nil, err
+	}
+
+	return task, column, board, nil
+}
@@ -0,0 +1,188 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/SeiFlow-3P2/board_service/internal/interceptor"
+	"github.com/SeiFlow-3P2/board_service/internal/models"
+	"github.com/SeiFlow-3P2/board_service/internal/watch"
+	"github.com/google/uuid"
+)
+
+var testLogger = slog.New(slog.DiscardHandler)
+
+const (
+	ownerID    = "owner"
+	strangerID = "stranger"
+)
+
+type fixture struct {
+	store    *memStore
+	boards   *BoardService
+	members  *MemberService
+	columns  *ColumnService
+	tasks    *TaskService
+	changes  *watch.Bus
+	boardID  uuid.UUID
+	columnID uuid.UUID
+	otherCol uuid.UUID
+	taskID   uuid.UUID
+}
+
+func newFixture(t *testing.T) *fixture {
+	t.Helper()
+
+	store := newMemStore()
+	boardRepo, columnRepo, taskRepo := fakeBoardRepo{store}, fakeColumnRepo{store}, fakeTaskRepo{store}
+	memberRepo := fakeMemberRepo{store}
+	authorizer := NewAuthorizer(boardRepo, columnRepo, taskRepo, memberRepo)
+	changes := watch.NewBus()
+
+	f := &fixture{
+		store:    store,
+		boards:   NewBoardService(boardRepo, memberRepo, authorizer, changes, testLogger),
+		members:  NewMemberService(memberRepo, authorizer, changes, testLogger),
+		columns:  NewColumnService(columnRepo, authorizer, changes, testLogger),
+		tasks:    NewTaskService(taskRepo, columnRepo, authorizer, changes, testLogger),
+		changes:  changes,
+		boardID:  uuid.New(),
+		columnID: uuid.New(),
+		otherCol: uuid.New(),
+		taskID:   uuid.New(),
+	}
+
+	store.boards[f.boardID] = &models.Board{ID: f.boardID, Title: "board", User_id: ownerID, Columns_amount: 2}
+	store.columns[f.columnID] = &models.Column{ID: f.columnID, Name: "To Do", Order_number: 1, Desk_id: f.boardID}
+	store.columns[f.otherCol] = &models.Column{ID: f.otherCol, Name: "Done", Order_number: 2, Desk_id: f.boardID}
+	store.tasks[f.taskID] = &models.Task{ID: f.taskID, Title: "task", Column_id: f.columnID}
+
+	return f
+}
+
+func asUser(userID string) context.Context {
+	return context.WithValue(context.Background(), interceptor.UserIDKey, userID)
+}
+
+func (f *fixture) operations() map[string]func(ctx context.Context) error {
+	name := "renamed"
+	deadline := time.Now().Add(time.Hour)
+
+	return map[string]func(ctx context.Context) error{
+		"GetBoardInfo": func(ctx context.Context) error {
+			_, err := f.boards.GetBoardInfo(ctx, f.boardID)
+			return err
+		},
+		"WatchBoard": func(ctx context.Context) error {
+			sub, err := f.boards.WatchBoard(ctx, f.boardID)
+			if sub != nil {
+				sub.Close()
+			}
+			return err
+		},
+		"UpdateBoard": func(ctx context.Context) error {
+			_, err := f.boards.UpdateBoard(ctx, UpdateBoardInput{ID: f.boardID, Title: &name})
+			return err
+		},
+		"DeleteBoard": func(ctx context.Context) error {
+			return f.boards.DeleteBoard(ctx, f.boardID)
+		},
+		"CreateColumn": func(ctx context.Context) error {
+			_, err := f.columns.CreateColumn(ctx, CreateColumnInput{Name: "New", DeskID: f.boardID})
+			return err
+		},
+		"UpdateColumn": func(ctx context.Context) error {
+			_, err := f.columns.UpdateColumn(ctx, UpdateColumnInput{ID: f.columnID, Name: &name})
+			return err
+		},
+		"DeleteColumn": func(ctx context.Context) error {
+			return f.columns.DeleteColumn(ctx, DeleteColumnInput{ID: f.columnID})
+		},
+		"CreateTask": func(ctx context.Context) error {
+			_, err := f.tasks.CreateTask(ctx, CreateTaskInput{Title: "t", ColumnID: f.columnID, Deadline: &deadline})
+			return err
+		},
+		"MoveTask": func(ctx context.Context) error {
+			_, err := f.tasks.MoveTask(ctx, MoveTaskInput{TaskID: f.taskID, NewColumnID: f.otherCol})
+			return err
+		},
+		"UpdateTask": func(ctx context.Context) error {
+			_, err := f.tasks.UpdateTask(ctx, UpdateTaskInput{TaskID: f.taskID, Title: &name})
+			return err
+		},
+		"DeleteTask": func(ctx context.Context) error {
+			return f.tasks.DeleteTask(ctx, DeleteTaskInput{TaskID: f.taskID})
+		},
+	}
+}
+
+func TestOwnerCanAccessOwnBoard(t *testing.T) {
+	for name := range newFixture(t).operations() {
+		t.Run(name, func(t *testing.T) {
+			f := newFixture(t)
+			if err := f.operations()[name](asUser(ownerID)); err != nil {
+				t.Fatalf("%s() as owner: unexpected error %v", name, err)
+			}
+		})
+	}
+}
+
+func TestCrossUserAccessIsDenied(t *testing.T) {
+	for name := range newFixture(t).operations() {
+		t.Run(name, func(t *testing.T) {
+			f := newFixture(t)
+			err := f.operations()[name](asUser(strangerID))
+			if !errors.Is(err, ErrPermissionDenied) {
+				t.Fatalf("%s() as stranger: expected ErrPermissionDenied, got %v", name, err)
+			}
+
+			if _, ok := f.store.boards[f.boardID]; !ok {
+				t.Fatalf("%s() as stranger deleted the board", name)
+			}
+			if _, ok := f.store.columns[f.columnID]; !ok {
+				t.Fatalf("%s() as stranger deleted the column", name)
+			}
+			if task, ok := f.store.tasks[f.taskID]; !ok || task.Title != "task" || task.Column_id != f.columnID {
+				t.Fatalf("%s() as stranger modified the task", name)
+			}
+		})
+	}
+}
+
+func TestAccessToMissingResources(t *testing.T) {
+	f := newFixture(t)
+	ctx := asUser(ownerID)
+	authorizer := f.boards.authorizer
+
+	if _, err := authorizer.Board(ctx, uuid.New(), models.RoleViewer); err != ErrBoardNotFound {
+		t.Errorf("Board(): expected ErrBoardNotFound, got %v", err)
+	}
+	if _, err := authorizer.Column(ctx, uuid.New(), models.RoleViewer); err != ErrColumnNotFound {
+		t.Errorf("Column(): expected ErrColumnNotFound, got %v", err)
+	}
+	if _, _, err := authorizer.Task(ctx, uuid.New(), models.RoleViewer); err != ErrTaskNotFound {
+		t.Errorf("Task(): expected ErrTaskNotFound, got %v", err)
+	}
+	if _, err := authorizer.Board(context.Background(), f.boardID, models.RoleViewer); err != ErrUserNotInContext {
+		t.Errorf("Board() without user: expected ErrUserNotInContext, got %v", err)
+	}
+}
+
+func TestMoveTaskToForeignBoardIsDenied(t *testing.T) {
+	f := newFixture(t)
+
+	foreignBoard, foreignColumn := uuid.New(), uuid.New()
+	f.store.boards[foreignBoard] = &models.Board{ID: foreignBoard, Title: "foreign", User_id: strangerID}
+	f.store.columns[foreignColumn] = &models.Column{ID: foreignColumn, Name: "To Do", Desk_id: foreignBoard}
+
+	_, err := f.tasks.MoveTask(asUser(ownerID), MoveTaskInput{TaskID: f.taskID, NewColumnID: foreignColumn})
+	if err != ErrPermissionDenied {
+		t.Fatalf("expected ErrPermissionDenied, got %v", err)
+	}
+	if f.store.tasks[f.taskID].Column_id != f.columnID {
+		t.Fatal("task was moved to a foreign board")
+	}
+}
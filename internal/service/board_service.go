@@ -2,13 +2,18 @@ package service
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
 	"errors"
+	"log/slog"
 	"strings"
 	"time"
 
 	"github.com/SeiFlow-3P2/board_service/internal/interceptor"
+	"github.com/SeiFlow-3P2/board_service/internal/metrics"
 	"github.com/SeiFlow-3P2/board_service/internal/models"
 	"github.com/SeiFlow-3P2/board_service/internal/repository"
+	"github.com/SeiFlow-3P2/board_service/internal/watch"
 	"github.com/SeiFlow-3P2/shared/telemetry"
 	"github.com/google/uuid"
 	"go.mongodb.org/mongo-driver/mongo"
@@ -18,15 +23,37 @@ var (
 	ErrBoardExists      = errors.New("board already exists")
 	ErrUserNotInContext = errors.New("user ID not found in context")
 	ErrBoardNotFound    = errors.New("board not found")
+	ErrInvalidPageSize  = errors.New("page size cannot be negative")
+	ErrInvalidPageToken = errors.New("invalid page token")
+	ErrInvalidSortField = errors.New("boards can be sorted by updated_at, created_at or title")
+)
+
+const (
+	defaultPageSize = 20
+	maxPageSize     = 100
 )
 
 type BoardService struct {
-	boardRepo repository.BoardRepository
+	boardRepo  repository.BoardRepository
+	memberRepo repository.MemberRepository
+	authorizer *Authorizer
+	changes    *watch.Bus
+	logger     *slog.Logger
 }
 
-func NewBoardService(boardRepo repository.BoardRepository) *BoardService {
+func NewBoardService(
+	boardRepo repository.BoardRepository,
+	memberRepo repository.MemberRepository,
+	authorizer *Authorizer,
+	changes *watch.Bus,
+	logger *slog.Logger,
+) *BoardService {
 	return &BoardService{
-		boardRepo: boardRepo,
+		boardRepo:  boardRepo,
+		memberRepo: memberRepo,
+		authorizer: authorizer,
+		changes:    changes,
+		logger:     logger,
 	}
 }
 
@@ -61,21 +88,19 @@ func (s *BoardService) CreateBoard(ctx context.Context, input CreateBoardInput)
 		return nil, err
 	}
 	for _, board := range boards {
-		if board.Title == input.Title {
+		if strings.EqualFold(board.Title, input.Title) {
 			telemetry.RecordError(span, ErrBoardExists)
 			return nil, ErrBoardExists
 		}
 	}
 
 	var columns []models.Column
-	columnsAmount := 0
 
 	now := time.Now()
 	boardID := uuid.New()
 
 	switch input.Metodology {
 	case "kanban":
-		columnsAmount = 3
 		columns = []models.Column{
 			{
 				ID:           uuid.New(),
@@ -100,7 +125,6 @@ func (s *BoardService) CreateBoard(ctx context.Context, input CreateBoardInput)
 			},
 		}
 	case "simple":
-		columnsAmount = 1
 		columns = []models.Column{
 			{
 				ID:           uuid.New(),
@@ -120,7 +144,7 @@ func (s *BoardService) CreateBoard(ctx context.Context, input CreateBoardInput)
 		Progress:       0,
 		Favorite:       false,
 		Metodology:     input.Metodology,
-		Columns_amount: columnsAmount,
+		Columns_amount: len(columns),
 		Created_at:     now,
 		Updated_at:     now,
 		User_id:        userID,
@@ -129,8 +153,11 @@ func (s *BoardService) CreateBoard(ctx context.Context, input CreateBoardInput)
 
 	createdBoard, err := s.boardRepo.CreateBoard(ctx, board)
 	if err != nil {
+		telemetry.RecordError(span, err)
 		return nil, err
 	}
+	metrics.Created(metrics.KindBoard, board.Metodology)
+	s.logger.InfoContext(ctx, "board created", "board_id", board.ID, "methodology", board.Metodology)
 	return createdBoard, nil
 }
 
@@ -138,6 +165,11 @@ func (s *BoardService) GetBoardInfo(ctx context.Context, id uuid.UUID) (*models.
 	ctx, span := telemetry.StartSpan(ctx, "BoardService.GetBoardInfo")
 	defer span.End()
 
+	if _, err := s.authorizer.Board(ctx, id, models.RoleViewer); err != nil {
+		telemetry.RecordError(span, err)
+		return nil, err
+	}
+
 	board, err := s.boardRepo.GetBoardInfo(ctx, id)
 	if err != nil {
 		if err == mongo.ErrNoDocuments {
@@ -149,7 +181,34 @@ func (s *BoardService) GetBoardInfo(ctx context.Context, id uuid.UUID) (*models.
 	return board, nil
 }
 
-func (s *BoardService) GetBoards(ctx context.Context) ([]*models.Board, error) {
+type GetBoardsInput struct {
+	PageSize    int
+	PageToken   string
+	Category    *string
+	Methodology *string
+	Favorite    *bool
+	// SortBy is updated_at, created_at or title and defaults to updated_at.
+	SortBy string
+	// Descending defaults to true for timestamps and false for titles.
+	Descending *bool
+}
+
+type BoardsPage struct {
+	Boards        []*models.Board
+	NextPageToken string
+}
+
+// pageToken is the opaque page_token handed out to clients. It records the
+// sorting it was issued for, so that it cannot be reused with another one.
+type pageToken struct {
+	SortBy     repository.BoardSortField `json:"s"`
+	Descending bool                      `json:"d"`
+	Value      string                    `json:"v"`
+	ID         uuid.UUID                 `json:"i"`
+}
+
+// GetBoards returns a page of the boards the user owns or is a member of.
+func (s *BoardService) GetBoards(ctx context.Context, input GetBoardsInput) (*BoardsPage, error) {
 	ctx, span := telemetry.StartSpan(ctx, "BoardService.GetBoards")
 	defer span.End()
 
@@ -158,23 +217,123 @@ func (s *BoardService) GetBoards(ctx context.Context) ([]*models.Board, error) {
 		return nil, ErrUserNotInContext
 	}
 
-	boards, err := s.boardRepo.GetBoards(ctx, userID)
+	query, err := boardQuery(input)
+	if err != nil {
+		telemetry.RecordError(span, err)
+		return nil, err
+	}
+	query.UserID = userID
+
+	query.SharedIDs, err = s.memberRepo.GetMemberBoardIDs(ctx, userID)
 	if err != nil {
+		telemetry.RecordError(span, err)
 		return nil, err
 	}
-	return boards, nil
+
+	// Fetch one board more than requested to learn whether there is a next page.
+	limit := query.Limit
+	query.Limit++
+	boards, err := s.boardRepo.ListBoards(ctx, query)
+	if err != nil {
+		telemetry.RecordError(span, err)
+		return nil, err
+	}
+
+	page := &BoardsPage{Boards: boards}
+	if len(boards) > limit {
+		page.Boards = boards[:limit]
+		page.NextPageToken = encodePageToken(query, page.Boards[limit-1])
+	}
+	return page, nil
+}
+
+func boardQuery(input GetBoardsInput) (*repository.BoardQuery, error) {
+	query := &repository.BoardQuery{
+		Category:    input.Category,
+		Methodology: input.Methodology,
+		Favorite:    input.Favorite,
+		SortBy:      repository.BoardSortField(input.SortBy),
+		Limit:       input.PageSize,
+	}
+
+	switch {
+	case query.Limit < 0:
+		return nil, ErrInvalidPageSize
+	case query.Limit == 0:
+		query.Limit = defaultPageSize
+	case query.Limit > maxPageSize:
+		query.Limit = maxPageSize
+	}
+
+	switch query.SortBy {
+	case "":
+		query.SortBy = repository.BoardSortUpdatedAt
+	case repository.BoardSortUpdatedAt, repository.BoardSortCreatedAt, repository.BoardSortTitle:
+	default:
+		return nil, ErrInvalidSortField
+	}
+
+	query.Descending = query.SortBy != repository.BoardSortTitle
+	if input.Descending != nil {
+		query.Descending = *input.Descending
+	}
+
+	if input.PageToken != "" {
+		cursor, err := decodePageToken(input.PageToken, query)
+		if err != nil {
+			return nil, err
+		}
+		query.After = cursor
+	}
+	return query, nil
+}
+
+func encodePageToken(query *repository.BoardQuery, last *models.Board) string {
+	token := pageToken{SortBy: query.SortBy, Descending: query.Descending, ID: last.ID}
+	switch query.SortBy {
+	case repository.BoardSortUpdatedAt:
+		token.Value = last.Updated_at.Format(time.RFC3339Nano)
+	case repository.BoardSortCreatedAt:
+		token.Value = last.Created_at.Format(time.RFC3339Nano)
+	case repository.BoardSortTitle:
+		token.Value = last.Title
+	}
+
+	data, _ := json.Marshal(token)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodePageToken(encoded string, query *repository.BoardQuery) (*repository.BoardCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, ErrInvalidPageToken
+	}
+	var token pageToken
+	if err := json.Unmarshal(data, &token); err != nil {
+		return nil, ErrInvalidPageToken
+	}
+	if token.SortBy != query.SortBy || token.Descending != query.Descending {
+		return nil, ErrInvalidPageToken
+	}
+
+	cursor := &repository.BoardCursor{Value: token.Value, ID: token.ID}
+	if query.SortBy != repository.BoardSortTitle {
+		value, err := time.Parse(time.RFC3339Nano, token.Value)
+		if err != nil {
+			return nil, ErrInvalidPageToken
+		}
+		cursor.Value = value
+	}
+	return cursor, nil
 }
 
 func (s *BoardService) UpdateBoard(ctx context.Context, input UpdateBoardInput) (*models.Board, error) {
 	ctx, span := telemetry.StartSpan(ctx, "BoardService.UpdateBoard")
 	defer span.End()
 
-	board, err := s.boardRepo.GetBoardInfo(ctx, input.ID)
+	board, err := s.authorizer.Board(ctx, input.ID, models.RoleEditor)
 	if err != nil {
-		if err == mongo.ErrNoDocuments {
-			telemetry.RecordError(span, ErrBoardNotFound)
-			return nil, ErrBoardNotFound
-		}
+		telemetry.RecordError(span, err)
 		return nil, err
 	}
 
@@ -207,20 +366,52 @@ func (s *BoardService) DeleteBoard(ctx context.Context, id uuid.UUID) error {
 	ctx, span := telemetry.StartSpan(ctx, "BoardService.DeleteBoard")
 	defer span.End()
 
-	_, err := s.boardRepo.GetBoardInfo(ctx, id)
+	userID, err := userIDFromContext(ctx)
 	if err != nil {
-		if err == mongo.ErrNoDocuments {
-			telemetry.RecordError(span, ErrBoardNotFound)
-			return ErrBoardNotFound
-		}
+		telemetry.RecordError(span, err)
+		return err
+	}
+
+	board, err := s.authorizer.Board(ctx, id, models.RoleOwner)
+	if err != nil {
+		telemetry.RecordError(span, err)
 		return err
 	}
 
-	err = s.boardRepo.DeleteBoard(ctx, id)
+	// The tasks of the board leave the calendar together with it. The events
+	// are built from the tasks the repository deletes, in the same
+	// transaction.
+	err = s.boardRepo.DeleteBoard(ctx, id, deleteEvents(board))
 	if err != nil {
 		telemetry.RecordError(span, err)
 		return err
 	}
 
+	s.logger.InfoContext(ctx, "board deleted", "board_id", id)
+	s.changes.Publish(watch.Change{Type: watch.BoardDeleted, BoardID: id, UserID: userID})
 	return nil
 }
+
+// WatchBoard subscribes to the changes of a board the user can view. The
+// subscription is revoked when the user loses access to the board. The
+// caller must close the subscription.
+func (s *BoardService) WatchBoard(ctx context.Context, id uuid.UUID) (*watch.Subscription, error) {
+	ctx, span := telemetry.StartSpan(ctx, "BoardService.WatchBoard")
+	defer span.End()
+
+	userID, err := userIDFromContext(ctx)
+	if err != nil {
+		telemetry.RecordError(span, err)
+		return nil, err
+	}
+
+	// Subscribing before the check makes sure that a removal after the
+	// check revokes the subscription.
+	sub := s.changes.Subscribe(id, userID)
+	if _, err := s.authorizer.Board(ctx, id, models.RoleViewer); err != nil {
+		sub.Close()
+		telemetry.RecordError(span, err)
+		return nil, err
+	}
+	return sub, nil
+}
@@ -0,0 +1,262 @@
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/SeiFlow-3P2/board_service/internal/models"
+	"github.com/SeiFlow-3P2/board_service/internal/watch"
+	"github.com/google/uuid"
+)
+
+func TestCreateBoardPersistsDefaultColumns(t *testing.T) {
+	tests := []struct {
+		methodology string
+		want        []string
+	}{
+		{methodology: "kanban", want: []string{"To Do", "In Progress", "Done"}},
+		{methodology: "simple", want: []string{""}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.methodology, func(t *testing.T) {
+			ctx := asUser(ownerID)
+			f := newFixture(t)
+
+			board, err := f.boards.CreateBoard(ctx, CreateBoardInput{Title: "new", Description: "d", Metodology: tt.methodology, Category: "c"})
+			if err != nil {
+				t.Fatalf("CreateBoard(): %v", err)
+			}
+			if board.Columns_amount != len(tt.want) {
+				t.Fatalf("expected columns_amount %d, got %d", len(tt.want), board.Columns_amount)
+			}
+
+			f.boardID = board.ID
+			assertTitles(t, columnNames(t, f), tt.want...)
+
+			deadline := time.Now().Add(time.Hour)
+			for _, column := range board.Columns {
+				if _, err := f.tasks.CreateTask(ctx, CreateTaskInput{Title: "t", ColumnID: column.ID, Deadline: &deadline}); err != nil {
+					t.Fatalf("CreateTask(%s): %v", column.Name, err)
+				}
+			}
+			if _, err := f.columns.CreateColumn(ctx, CreateColumnInput{Name: "Extra", DeskID: board.ID}); err != nil {
+				t.Fatalf("CreateColumn(): %v", err)
+			}
+			assertTitles(t, columnNames(t, f), append(tt.want, "Extra")...)
+		})
+	}
+}
+
+func TestBoardTitlesAreUniqueRegardlessOfCase(t *testing.T) {
+	ctx := asUser(ownerID)
+	f := newFixture(t)
+
+	if _, err := f.boards.CreateBoard(ctx, CreateBoardInput{Title: "BOARD", Metodology: "simple"}); !errors.Is(err, ErrBoardExists) {
+		t.Fatalf("expected ErrBoardExists on create, got %v", err)
+	}
+
+	other, err := f.boards.CreateBoard(ctx, CreateBoardInput{Title: "other", Metodology: "simple"})
+	if err != nil {
+		t.Fatalf("CreateBoard(): %v", err)
+	}
+	title := "Board"
+	if _, err := f.boards.UpdateBoard(ctx, UpdateBoardInput{ID: other.ID, Title: &title}); !errors.Is(err, ErrBoardExists) {
+		t.Fatalf("expected ErrBoardExists on update, got %v", err)
+	}
+}
+
+func TestGetBoardsPagination(t *testing.T) {
+	ctx := asUser(ownerID)
+	f := newFixture(t)
+	delete(f.store.boards, f.boardID)
+
+	now := time.Now()
+	for i, title := range []string{"c", "a", "e", "b", "d"} {
+		id := uuid.New()
+		f.store.boards[id] = &models.Board{
+			ID:         id,
+			Title:      title,
+			User_id:    ownerID,
+			Category:   []string{"work", "home"}[i%2],
+			Favorite:   i < 2,
+			Created_at: now.Add(time.Duration(-i) * time.Hour),
+			Updated_at: now.Add(time.Duration(i) * time.Hour),
+		}
+	}
+
+	// list pages through all boards matching input and returns their titles.
+	list := func(t *testing.T, input GetBoardsInput) []string {
+		t.Helper()
+		var titles []string
+		for pages := 0; ; pages++ {
+			if pages > 10 {
+				t.Fatal("pagination does not terminate")
+			}
+			page, err := f.boards.GetBoards(ctx, input)
+			if err != nil {
+				t.Fatalf("GetBoards(): %v", err)
+			}
+			if len(page.Boards) > input.PageSize {
+				t.Fatalf("expected at most %d boards, got %d", input.PageSize, len(page.Boards))
+			}
+			for _, board := range page.Boards {
+				titles = append(titles, board.Title)
+			}
+			if page.NextPageToken == "" {
+				return titles
+			}
+			input.PageToken = page.NextPageToken
+		}
+	}
+
+	ascending, descending := false, true
+	work, favorite := "work", true
+	tests := []struct {
+		name  string
+		input GetBoardsInput
+		want  []string
+	}{
+		{name: "updated_at", input: GetBoardsInput{}, want: []string{"d", "b", "e", "a", "c"}},
+		{name: "created_at", input: GetBoardsInput{SortBy: "created_at"}, want: []string{"c", "a", "e", "b", "d"}},
+		{name: "title", input: GetBoardsInput{SortBy: "title"}, want: []string{"a", "b", "c", "d", "e"}},
+		{name: "title descending", input: GetBoardsInput{SortBy: "title", Descending: &descending}, want: []string{"e", "d", "c", "b", "a"}},
+		{name: "updated_at ascending", input: GetBoardsInput{Descending: &ascending}, want: []string{"c", "a", "e", "b", "d"}},
+		{name: "category", input: GetBoardsInput{Category: &work}, want: []string{"d", "e", "c"}},
+		{name: "favorite", input: GetBoardsInput{Favorite: &favorite, SortBy: "title"}, want: []string{"a", "c"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.input.PageSize = 2
+			assertTitles(t, list(t, tt.input), tt.want...)
+		})
+	}
+
+	t.Run("invalid input", func(t *testing.T) {
+		page, err := f.boards.GetBoards(ctx, GetBoardsInput{PageSize: 2})
+		if err != nil {
+			t.Fatalf("GetBoards(): %v", err)
+		}
+
+		invalid := []struct {
+			input GetBoardsInput
+			err   error
+		}{
+			{input: GetBoardsInput{PageSize: -1}, err: ErrInvalidPageSize},
+			{input: GetBoardsInput{SortBy: "progress"}, err: ErrInvalidSortField},
+			{input: GetBoardsInput{PageToken: "not a token"}, err: ErrInvalidPageToken},
+			{input: GetBoardsInput{PageToken: page.NextPageToken, SortBy: "title"}, err: ErrInvalidPageToken},
+		}
+		for _, tt := range invalid {
+			if _, err := f.boards.GetBoards(ctx, tt.input); err != tt.err {
+				t.Fatalf("GetBoards(%+v): expected %v, got %v", tt.input, tt.err, err)
+			}
+		}
+	})
+}
+
+func TestWatchBoard(t *testing.T) {
+	ctx := asUser(ownerID)
+	f := newFixture(t)
+
+	sub, err := f.boards.WatchBoard(ctx, f.boardID)
+	if err != nil {
+		t.Fatalf("WatchBoard(): %v", err)
+	}
+	defer sub.Close()
+
+	column, err := f.columns.CreateColumn(ctx, CreateColumnInput{Name: "Review", DeskID: f.boardID})
+	if err != nil {
+		t.Fatalf("CreateColumn(): %v", err)
+	}
+	deadline := time.Now().Add(time.Hour)
+	task, err := f.tasks.CreateTask(ctx, CreateTaskInput{Title: "t", ColumnID: f.columnID, Deadline: &deadline})
+	if err != nil {
+		t.Fatalf("CreateTask(): %v", err)
+	}
+	if _, err := f.tasks.MoveTask(ctx, MoveTaskInput{TaskID: task.ID, NewColumnID: column.ID}); err != nil {
+		t.Fatalf("MoveTask(): %v", err)
+	}
+	if err := f.columns.DeleteColumn(ctx, DeleteColumnInput{ID: column.ID, MoveTasksTo: &f.otherCol}); err != nil {
+		t.Fatalf("DeleteColumn(): %v", err)
+	}
+	if err := f.boards.DeleteBoard(ctx, f.boardID); err != nil {
+		t.Fatalf("DeleteBoard(): %v", err)
+	}
+
+	// The task is moved into the new column and handed over to the target
+	// when the column is deleted, which is reported for the whole target.
+	want := []watch.ChangeType{watch.ColumnCreated, watch.TaskCreated, watch.TaskMoved, watch.TasksReranked, watch.ColumnDeleted, watch.BoardDeleted}
+	for i, typ := range want {
+		change := <-sub.Changes()
+		if change.Type != typ || change.BoardID != f.boardID || change.UserID != ownerID {
+			t.Fatalf("change %d: expected %s, got %+v", i, typ, change)
+		}
+		if change.Type == watch.TaskMoved && change.Task.ID != task.ID {
+			t.Fatalf("change %d: expected task %s, got %+v", i, task.ID, change.Task)
+		}
+		if change.Type == watch.TasksReranked && change.Column.ID != f.otherCol {
+			t.Fatalf("change %d: expected column %s, got %+v", i, f.otherCol, change.Column)
+		}
+	}
+}
+
+func TestWatchBoardIsRevokedWithTheMembership(t *testing.T) {
+	f := newFixture(t)
+	if _, err := f.members.AddBoardMember(asUser(ownerID), AddMemberInput{BoardID: f.boardID, UserID: viewerID, Role: models.RoleViewer}); err != nil {
+		t.Fatalf("AddBoardMember(): %v", err)
+	}
+	sub, err := f.boards.WatchBoard(asUser(viewerID), f.boardID)
+	if err != nil {
+		t.Fatalf("WatchBoard(): %v", err)
+	}
+	defer sub.Close()
+
+	if err := f.members.RemoveBoardMember(asUser(ownerID), RemoveMemberInput{BoardID: f.boardID, UserID: viewerID}); err != nil {
+		t.Fatalf("RemoveBoardMember(): %v", err)
+	}
+	if _, err := f.columns.CreateColumn(asUser(ownerID), CreateColumnInput{Name: "Review", DeskID: f.boardID}); err != nil {
+		t.Fatalf("CreateColumn(): %v", err)
+	}
+	if change, ok := <-sub.Changes(); ok {
+		t.Fatalf("expected the removed member to stop receiving changes, got %+v", change)
+	}
+	if sub.Err() != watch.ErrRevoked {
+		t.Fatalf("expected watch.ErrRevoked, got %v", sub.Err())
+	}
+
+	if _, err := f.boards.WatchBoard(asUser(viewerID), f.boardID); err != ErrPermissionDenied {
+		t.Fatalf("expected ErrPermissionDenied, got %v", err)
+	}
+}
+
+func TestWatchBoardRebalanceIsOneChange(t *testing.T) {
+	ctx := asUser(ownerID)
+	f := newFixture(t)
+	sub, err := f.boards.WatchBoard(ctx, f.boardID)
+	if err != nil {
+		t.Fatalf("WatchBoard(): %v", err)
+	}
+	defer sub.Close()
+
+	// More tasks without a rank than a watcher may lag behind.
+	var anchor uuid.UUID
+	for i := 0; i < 100; i++ {
+		anchor = uuid.New()
+		f.store.tasks[anchor] = &models.Task{ID: anchor, Title: "legacy", Column_id: f.otherCol}
+	}
+	if _, err := f.tasks.MoveTask(ctx, MoveTaskInput{TaskID: f.taskID, NewColumnID: f.otherCol, BeforeTaskID: &anchor}); err != nil {
+		t.Fatalf("MoveTask(): %v", err)
+	}
+
+	for _, typ := range []watch.ChangeType{watch.TasksReranked, watch.TaskMoved} {
+		change, ok := <-sub.Changes()
+		if !ok {
+			t.Fatalf("expected %s, the watcher was dropped: %v", typ, sub.Err())
+		}
+		if change.Type != typ {
+			t.Fatalf("expected %s, got %+v", typ, change)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package service
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/SeiFlow-3P2/board_service/internal/models"
+	"github.com/SeiFlow-3P2/board_service/internal/repository"
+	"github.com/google/uuid"
+)
+
+const calendarTopic = "board.event"
+
+// calendarChange returns the calendar event that brings the calendar in line
+// with an updated task, if any.
+func calendarChange(before, after *models.Task) (string, bool) {
+	switch {
+	case !before.In_Calendar && after.In_Calendar:
+		return models.EventTypeCreate, true
+	case before.In_Calendar && !after.In_Calendar:
+		return models.EventTypeDelete, true
+	case after.In_Calendar && (before.Title != after.Title ||
+		before.Description != after.Description ||
+		!before.Deadline.Equal(after.Deadline)):
+		return models.EventTypeUpdate, true
+	}
+	return "", false
+}
+
+// calendarOwner returns the user whose calendar holds the task: the user who
+// put it there, or the owner of its board for tasks added to the calendar
+// before that was recorded.
+func calendarOwner(task *models.Task, board *models.Board) string {
+	if task.Calendar_user_id != "" {
+		return task.Calendar_user_id
+	}
+	return board.User_id
+}
+
+// calendarEvent returns the outbox event that tells the calendar service
+// about a task in the calendar of owner. It is stored together with the
+// change to the task and published by the outbox relay, keyed by owner so
+// that the events of one calendar stay in order.
+func calendarEvent(eventType string, task *models.Task, owner string) *models.OutboxEvent {
+	msg := models.BoardEvent{
+		EventID:     uuid.New(),
+		EventType:   eventType,
+		TaskID:      task.ID,
+		Title:       task.Title,
+		Description: task.Description,
+		Deadline:    task.Deadline,
+		UserID:      owner,
+	}
+	payload, _ := json.Marshal(msg)
+
+	now := time.Now()
+	return &models.OutboxEvent{
+		ID:              msg.EventID,
+		Topic:           calendarTopic,
+		Key:             owner,
+		Payload:         string(payload),
+		Created_at:      now,
+		Next_attempt_at: now,
+	}
+}
+
+// calendarDeleteEvents returns a delete event for every task in tasks that is
+// in the calendar. The tasks are on board.
+func calendarDeleteEvents(tasks []*models.Task, board *models.Board) []*models.OutboxEvent {
+	var events []*models.OutboxEvent
+	for _, task := range tasks {
+		if task.In_Calendar {
+			events = append(events, calendarEvent(models.EventTypeDelete, task, calendarOwner(task, board)))
+		}
+	}
+	return events
+}
+
+// deleteEvents returns the delete events for the tasks in the calendar that
+// the repository deletes on board, for building them in its transaction.
+func deleteEvents(board *models.Board) repository.DeleteEvents {
+	return func(tasks []*models.Task) []*models.OutboxEvent {
+		return calendarDeleteEvents(tasks, board)
+	}
+}
@@ -0,0 +1,115 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/SeiFlow-3P2/board_service/internal/interceptor"
+	"github.com/SeiFlow-3P2/board_service/internal/models"
+	"github.com/SeiFlow-3P2/board_service/internal/repository"
+	"github.com/SeiFlow-3P2/board_service/internal/watch"
+	"github.com/SeiFlow-3P2/shared/telemetry"
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+var ErrInvalidCalendarEvent = errors.New("invalid calendar event")
+
+// CalendarSyncService applies changes made in the calendar service back to
+// the tasks they belong to, on behalf of the user whose calendar changed.
+type CalendarSyncService struct {
+	taskRepo   repository.TaskRepository
+	authorizer *Authorizer
+	changes    *watch.Bus
+	logger     *slog.Logger
+}
+
+func NewCalendarSyncService(
+	taskRepo repository.TaskRepository,
+	authorizer *Authorizer,
+	changes *watch.Bus,
+	logger *slog.Logger,
+) *CalendarSyncService {
+	return &CalendarSyncService{
+		taskRepo:   taskRepo,
+		authorizer: authorizer,
+		changes:    changes,
+		logger:     logger,
+	}
+}
+
+// ApplyCalendarEvent moves the task's deadline or takes the task out of the
+// calendar. The user of the event needs editor access to the task's board.
+// Every event is applied at most once. Redelivered events are ignored, and
+// so are events for tasks that no longer exist, deadline moves of tasks that
+// left the calendar and events of users who lost access to the board, since
+// the calendar may report them late. Malformed events are reported with
+// ErrInvalidCalendarEvent.
+func (s *CalendarSyncService) ApplyCalendarEvent(ctx context.Context, event models.CalendarEvent) error {
+	ctx, span := telemetry.StartSpan(ctx, "CalendarSyncService.ApplyCalendarEvent")
+	defer span.End()
+
+	if event.EventID == uuid.Nil || event.TaskID == uuid.Nil || event.UserID == "" {
+		err := fmt.Errorf("%w: event, task and user ID are required", ErrInvalidCalendarEvent)
+		telemetry.RecordError(span, err)
+		return err
+	}
+
+	updates := &repository.TaskUpdates{}
+	switch event.EventType {
+	case models.EventTypeUpdate:
+		if event.Deadline.IsZero() {
+			err := fmt.Errorf("%w: deadline is required", ErrInvalidCalendarEvent)
+			telemetry.RecordError(span, err)
+			return err
+		}
+		updates.Deadline = &event.Deadline
+	case models.EventTypeDelete:
+		inCalendar := false
+		updates.In_Calendar = &inCalendar
+	default:
+		err := fmt.Errorf("%w: unknown event type %q", ErrInvalidCalendarEvent, event.EventType)
+		telemetry.RecordError(span, err)
+		return err
+	}
+
+	userCtx := context.WithValue(ctx, interceptor.UserIDKey, event.UserID)
+	task, column, err := s.authorizer.Task(userCtx, event.TaskID, models.RoleEditor)
+	switch err {
+	case nil:
+	case ErrTaskNotFound:
+		s.logger.DebugContext(ctx, "calendar event for a missing task ignored", "event_id", event.EventID, "task_id", event.TaskID)
+		return nil
+	case ErrPermissionDenied:
+		s.logger.DebugContext(ctx, "calendar event of a user without access ignored", "event_id", event.EventID, "task_id", event.TaskID, "user_id", event.UserID)
+		return nil
+	default:
+		telemetry.RecordError(span, err)
+		return err
+	}
+	if event.EventType == models.EventTypeUpdate && !task.In_Calendar {
+		s.logger.DebugContext(ctx, "calendar event for a task outside the calendar ignored", "event_id", event.EventID, "task_id", event.TaskID)
+		return nil
+	}
+
+	err = s.taskRepo.ApplyEventOnce(ctx, event.EventID, event.TaskID, updates)
+	if err == mongo.ErrNoDocuments {
+		s.logger.DebugContext(ctx, "calendar event for a missing task ignored", "event_id", event.EventID, "task_id", event.TaskID)
+		return nil
+	}
+	if err != nil {
+		telemetry.RecordError(span, err)
+		return err
+	}
+
+	// The event is applied, so a failure to report it must not fail it.
+	task, err = s.taskRepo.GetTask(ctx, event.TaskID)
+	if err != nil {
+		s.logger.WarnContext(ctx, "failed to load the task changed by a calendar event", "event_id", event.EventID, "task_id", event.TaskID, "error", err)
+		return nil
+	}
+	s.changes.Publish(watch.Change{Type: watch.TaskUpdated, BoardID: column.Desk_id, UserID: event.UserID, Task: task})
+	return nil
+}
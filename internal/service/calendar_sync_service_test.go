@@ -0,0 +1,97 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/SeiFlow-3P2/board_service/internal/models"
+	"github.com/SeiFlow-3P2/board_service/internal/watch"
+	"github.com/google/uuid"
+)
+
+func TestApplyCalendarEvent(t *testing.T) {
+	ctx := context.Background()
+	f := newFixture(t)
+	authorizer := NewAuthorizer(fakeBoardRepo{f.store}, fakeColumnRepo{f.store}, fakeTaskRepo{f.store}, fakeMemberRepo{f.store})
+	calendarSync := NewCalendarSyncService(fakeTaskRepo{f.store}, authorizer, f.changes, testLogger)
+	f.store.tasks[f.taskID].In_Calendar = true
+	sub := f.changes.Subscribe(f.boardID, ownerID)
+	defer sub.Close()
+
+	deadline := time.Now().Add(24 * time.Hour).Truncate(time.Second)
+	moved := models.CalendarEvent{EventID: uuid.New(), EventType: models.EventTypeUpdate, TaskID: f.taskID, Deadline: deadline, UserID: ownerID}
+	if err := calendarSync.ApplyCalendarEvent(ctx, moved); err != nil {
+		t.Fatalf("ApplyCalendarEvent(): %v", err)
+	}
+	if !f.store.tasks[f.taskID].Deadline.Equal(deadline) {
+		t.Fatalf("expected deadline %v, got %v", deadline, f.store.tasks[f.taskID].Deadline)
+	}
+	select {
+	case change := <-sub.Changes():
+		if change.Type != watch.TaskUpdated || change.Task.ID != f.taskID || !change.Task.Deadline.Equal(deadline) {
+			t.Fatalf("expected the moved deadline to be reported, got %+v", change)
+		}
+	default:
+		t.Fatal("expected watchers of the board to be told about the change")
+	}
+
+	// A redelivered event does not undo later changes.
+	f.store.tasks[f.taskID].Deadline = deadline.Add(time.Hour)
+	if err := calendarSync.ApplyCalendarEvent(ctx, moved); err != nil {
+		t.Fatalf("ApplyCalendarEvent(): %v", err)
+	}
+	if !f.store.tasks[f.taskID].Deadline.Equal(deadline.Add(time.Hour)) {
+		t.Fatal("expected the redelivered event to be skipped")
+	}
+
+	removed := models.CalendarEvent{EventID: uuid.New(), EventType: models.EventTypeDelete, TaskID: f.taskID, UserID: ownerID}
+	if err := calendarSync.ApplyCalendarEvent(ctx, removed); err != nil {
+		t.Fatalf("ApplyCalendarEvent(): %v", err)
+	}
+	if f.store.tasks[f.taskID].In_Calendar {
+		t.Fatal("expected the task to leave the calendar")
+	}
+
+	gone := models.CalendarEvent{EventID: uuid.New(), EventType: models.EventTypeDelete, TaskID: uuid.New(), UserID: ownerID}
+	if err := calendarSync.ApplyCalendarEvent(ctx, gone); err != nil {
+		t.Fatalf("expected events for missing tasks to be ignored, got %v", err)
+	}
+
+	// A deadline moved in the calendar after the task left it is stale.
+	late := models.CalendarEvent{EventID: uuid.New(), EventType: models.EventTypeUpdate, TaskID: f.taskID, Deadline: deadline.Add(2 * time.Hour), UserID: ownerID}
+	if err := calendarSync.ApplyCalendarEvent(ctx, late); err != nil {
+		t.Fatalf("expected deadline moves of tasks outside the calendar to be ignored, got %v", err)
+	}
+	if !f.store.tasks[f.taskID].Deadline.Equal(deadline.Add(time.Hour)) {
+		t.Fatal("expected the deadline of a task outside the calendar to stay")
+	}
+
+	// Only users who may edit the task can change it from their calendar.
+	// The others may have lost access since, so their events are ignored.
+	if _, err := f.members.AddBoardMember(asUser(ownerID), AddMemberInput{BoardID: f.boardID, UserID: viewerID, Role: models.RoleViewer}); err != nil {
+		t.Fatalf("AddBoardMember(): %v", err)
+	}
+	f.store.tasks[f.taskID].In_Calendar = true
+	for _, userID := range []string{viewerID, strangerID} {
+		event := models.CalendarEvent{EventID: uuid.New(), EventType: models.EventTypeDelete, TaskID: f.taskID, UserID: userID}
+		if err := calendarSync.ApplyCalendarEvent(ctx, event); err != nil {
+			t.Fatalf("expected the event of %s to be ignored, got %v", userID, err)
+		}
+	}
+	if !f.store.tasks[f.taskID].In_Calendar {
+		t.Fatal("expected the task to stay in the calendar")
+	}
+
+	for _, event := range []models.CalendarEvent{
+		{EventType: models.EventTypeDelete, TaskID: f.taskID, UserID: ownerID},
+		{EventID: uuid.New(), EventType: models.EventTypeDelete, TaskID: f.taskID},
+		{EventID: uuid.New(), EventType: models.EventTypeUpdate, TaskID: f.taskID, UserID: ownerID},
+		{EventID: uuid.New(), EventType: "create", TaskID: f.taskID, UserID: ownerID},
+	} {
+		if err := calendarSync.ApplyCalendarEvent(ctx, event); !errors.Is(err, ErrInvalidCalendarEvent) {
+			t.Fatalf("expected ErrInvalidCalendarEvent for %+v, got %v", event, err)
+		}
+	}
+}
@@ -0,0 +1,180 @@
+package service
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/SeiFlow-3P2/board_service/internal/models"
+	"github.com/google/uuid"
+)
+
+func TestCalendarChange(t *testing.T) {
+	deadline := time.Now().Add(time.Hour)
+	later := deadline.Add(time.Hour)
+
+	tests := []struct {
+		name   string
+		before models.Task
+		after  models.Task
+		want   string
+	}{
+		{"added to calendar", models.Task{Deadline: deadline}, models.Task{Deadline: deadline, In_Calendar: true}, models.EventTypeCreate},
+		{"removed from calendar", models.Task{Deadline: deadline, In_Calendar: true}, models.Task{Deadline: deadline}, models.EventTypeDelete},
+		{"deadline moved", models.Task{Deadline: deadline, In_Calendar: true}, models.Task{Deadline: later, In_Calendar: true}, models.EventTypeUpdate},
+		{"renamed", models.Task{Title: "a", Deadline: deadline, In_Calendar: true}, models.Task{Title: "b", Deadline: deadline, In_Calendar: true}, models.EventTypeUpdate},
+		{"deadline moved outside calendar", models.Task{Deadline: deadline}, models.Task{Deadline: later}, ""},
+		{"unchanged", models.Task{Deadline: deadline, In_Calendar: true}, models.Task{Deadline: deadline, In_Calendar: true}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := calendarChange(&tt.before, &tt.after)
+			if got != tt.want || ok != (tt.want != "") {
+				t.Fatalf("expected %q, got %q (%v)", tt.want, got, ok)
+			}
+		})
+	}
+}
+
+// outboxEvents returns the calendar events stored so far and empties the
+// outbox.
+func outboxEvents(t *testing.T, f *fixture) []models.BoardEvent {
+	t.Helper()
+
+	f.store.mu.Lock()
+	defer f.store.mu.Unlock()
+	var events []models.BoardEvent
+	for _, stored := range f.store.outbox {
+		var event models.BoardEvent
+		if err := json.Unmarshal([]byte(stored.Payload), &event); err != nil {
+			t.Fatalf("failed to decode event: %v", err)
+		}
+		if stored.ID != event.EventID || stored.Topic != calendarTopic || stored.Key != event.UserID {
+			t.Fatalf("unexpected outbox event %+v", stored)
+		}
+		events = append(events, event)
+	}
+	f.store.outbox = nil
+	return events
+}
+
+func assertEvents(t *testing.T, got []models.BoardEvent, taskID uuid.UUID, want ...string) {
+	t.Helper()
+
+	if len(got) != len(want) {
+		t.Fatalf("expected events %v, got %+v", want, got)
+	}
+	for i, event := range got {
+		if event.EventType != want[i] || event.TaskID != taskID {
+			t.Fatalf("expected %s event for task %s, got %+v", want[i], taskID, event)
+		}
+	}
+}
+
+func TestCalendarEvents(t *testing.T) {
+	ctx := asUser(ownerID)
+	deadline := time.Now().Add(time.Hour)
+
+	t.Run("task lifecycle", func(t *testing.T) {
+		f := newFixture(t)
+		task, err := f.tasks.CreateTask(ctx, CreateTaskInput{Title: "a", ColumnID: f.columnID, Deadline: &deadline, InCalendar: true})
+		if err != nil {
+			t.Fatalf("CreateTask(): %v", err)
+		}
+		assertEvents(t, outboxEvents(t, f), task.ID, models.EventTypeCreate)
+
+		title := "b"
+		if _, err := f.tasks.UpdateTask(ctx, UpdateTaskInput{TaskID: task.ID, Title: &title}); err != nil {
+			t.Fatalf("UpdateTask(): %v", err)
+		}
+		if _, err := f.tasks.MoveTask(ctx, MoveTaskInput{TaskID: task.ID, NewColumnID: f.otherCol}); err != nil {
+			t.Fatalf("MoveTask(): %v", err)
+		}
+		assertEvents(t, outboxEvents(t, f), task.ID, models.EventTypeUpdate, models.EventTypeUpdate)
+
+		if err := f.tasks.DeleteTask(ctx, DeleteTaskInput{TaskID: task.ID}); err != nil {
+			t.Fatalf("DeleteTask(): %v", err)
+		}
+		assertEvents(t, outboxEvents(t, f), task.ID, models.EventTypeDelete)
+	})
+
+	t.Run("tasks outside the calendar", func(t *testing.T) {
+		f := newFixture(t)
+		title := "renamed"
+		if _, err := f.tasks.UpdateTask(ctx, UpdateTaskInput{TaskID: f.taskID, Title: &title}); err != nil {
+			t.Fatalf("UpdateTask(): %v", err)
+		}
+		if err := f.tasks.DeleteTask(ctx, DeleteTaskInput{TaskID: f.taskID}); err != nil {
+			t.Fatalf("DeleteTask(): %v", err)
+		}
+		assertEvents(t, outboxEvents(t, f), f.taskID)
+	})
+
+	t.Run("toggling in_calendar", func(t *testing.T) {
+		f := newFixture(t)
+		inCalendar := true
+		if _, err := f.tasks.UpdateTask(ctx, UpdateTaskInput{TaskID: f.taskID, InCalendar: &inCalendar}); err != nil {
+			t.Fatalf("UpdateTask(): %v", err)
+		}
+		inCalendar = false
+		if _, err := f.tasks.UpdateTask(ctx, UpdateTaskInput{TaskID: f.taskID, InCalendar: &inCalendar}); err != nil {
+			t.Fatalf("UpdateTask(): %v", err)
+		}
+		assertEvents(t, outboxEvents(t, f), f.taskID, models.EventTypeCreate, models.EventTypeDelete)
+	})
+
+	t.Run("events go to the calendar owner", func(t *testing.T) {
+		f := newFixture(t)
+		if _, err := f.members.AddBoardMember(ctx, AddMemberInput{BoardID: f.boardID, UserID: editorID, Role: models.RoleEditor}); err != nil {
+			t.Fatalf("AddBoardMember(): %v", err)
+		}
+		task, err := f.tasks.CreateTask(asUser(editorID), CreateTaskInput{Title: "a", ColumnID: f.columnID, Deadline: &deadline, InCalendar: true})
+		if err != nil {
+			t.Fatalf("CreateTask(): %v", err)
+		}
+		title := "b"
+		if _, err := f.tasks.UpdateTask(ctx, UpdateTaskInput{TaskID: task.ID, Title: &title}); err != nil {
+			t.Fatalf("UpdateTask(): %v", err)
+		}
+		for _, event := range outboxEvents(t, f) {
+			if event.UserID != editorID {
+				t.Fatalf("expected the %s event in the calendar of %s, got %s", event.EventType, editorID, event.UserID)
+			}
+		}
+
+		// Tasks put in the calendar before the owner was recorded belong to
+		// the calendar of the board's owner.
+		f.store.tasks[f.taskID].In_Calendar = true
+		if err := f.tasks.DeleteTask(asUser(editorID), DeleteTaskInput{TaskID: f.taskID}); err != nil {
+			t.Fatalf("DeleteTask(): %v", err)
+		}
+		events := outboxEvents(t, f)
+		if len(events) != 1 || events[0].UserID != ownerID {
+			t.Fatalf("expected a delete event in the calendar of %s, got %+v", ownerID, events)
+		}
+	})
+
+	t.Run("bulk deletes", func(t *testing.T) {
+		f := newFixture(t)
+		f.store.tasks[f.taskID].In_Calendar = true
+		f.store.tasks[f.taskID].Calendar_user_id = editorID
+		if err := f.columns.DeleteColumn(ctx, DeleteColumnInput{ID: f.columnID, DeskID: f.boardID}); err != nil {
+			t.Fatalf("DeleteColumn(): %v", err)
+		}
+		events := outboxEvents(t, f)
+		assertEvents(t, events, f.taskID, models.EventTypeDelete)
+		if events[0].UserID != editorID {
+			t.Fatalf("expected the delete event in the calendar of %s, got %s", editorID, events[0].UserID)
+		}
+
+		task, err := f.tasks.CreateTask(ctx, CreateTaskInput{Title: "a", ColumnID: f.otherCol, Deadline: &deadline, InCalendar: true})
+		if err != nil {
+			t.Fatalf("CreateTask(): %v", err)
+		}
+		outboxEvents(t, f)
+		if err := f.boards.DeleteBoard(ctx, f.boardID); err != nil {
+			t.Fatalf("DeleteBoard(): %v", err)
+		}
+		assertEvents(t, outboxEvents(t, f), task.ID, models.EventTypeDelete)
+	})
+}
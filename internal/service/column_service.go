@@ -4,10 +4,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"strings"
+	"log/slog"
 
+	"github.com/SeiFlow-3P2/board_service/internal/metrics"
 	"github.com/SeiFlow-3P2/board_service/internal/models"
 	"github.com/SeiFlow-3P2/board_service/internal/repository"
+	"github.com/SeiFlow-3P2/board_service/internal/watch"
 	"github.com/SeiFlow-3P2/shared/telemetry"
 	"github.com/google/uuid"
 	"go.mongodb.org/mongo-driver/mongo"
@@ -19,17 +21,28 @@ var (
 	ErrEmptyOrderNumber = errors.New("order number cannot be empty")
 	ErrColumnExists     = errors.New("column with this name already exists in the board")
 	ErrColumnNotFound   = errors.New("column not found")
+	ErrInvalidOrder     = errors.New("order number is out of range")
+	ErrInvalidTarget    = errors.New("target column must be another column of the same board")
 )
 
 type ColumnService struct {
 	columnRepo repository.ColumnRepository
-	boardRepo  repository.BoardRepository
+	authorizer *Authorizer
+	changes    *watch.Bus
+	logger     *slog.Logger
 }
 
-func NewColumnService(columnRepo repository.ColumnRepository, boardRepo repository.BoardRepository) *ColumnService {
+func NewColumnService(
+	columnRepo repository.ColumnRepository,
+	authorizer *Authorizer,
+	changes *watch.Bus,
+	logger *slog.Logger,
+) *ColumnService {
 	return &ColumnService{
 		columnRepo: columnRepo,
-		boardRepo:  boardRepo,
+		authorizer: authorizer,
+		changes:    changes,
+		logger:     logger,
 	}
 }
 
@@ -45,58 +58,56 @@ type UpdateColumnInput struct {
 	OrderNumber *int
 }
 
+type MoveColumnInput struct {
+	ID          uuid.UUID
+	OrderNumber int
+}
+
+// DeleteColumnInput deletes the column's tasks with it unless MoveTasksTo
+// names another column of the same board to receive them.
 type DeleteColumnInput struct {
-	ID     uuid.UUID
-	DeskID uuid.UUID
+	ID          uuid.UUID
+	DeskID      uuid.UUID
+	MoveTasksTo *uuid.UUID
 }
 
 func (s *ColumnService) CreateColumn(ctx context.Context, input CreateColumnInput) (*models.Column, error) {
 	ctx, span := telemetry.StartSpan(ctx, "ColumnService.CreateColumn")
 	defer span.End()
 
-	_, err := s.boardRepo.GetBoardInfo(ctx, input.DeskID)
-	if err != nil {
-		if err == mongo.ErrNoDocuments {
-			telemetry.RecordError(span, ErrBoardNotFound)
-			return nil, ErrBoardNotFound
-		}
-		telemetry.RecordError(span, err)
-		return nil, fmt.Errorf("failed to get board: %w", err)
-	}
-
-	existColumns, err := s.columnRepo.GetColumns(ctx, input.DeskID)
+	userID, err := userIDFromContext(ctx)
 	if err != nil {
 		telemetry.RecordError(span, err)
-		return nil, fmt.Errorf("failed to get columns: %w", err)
+		return nil, err
 	}
 
-	for _, col := range existColumns {
-		if strings.EqualFold(col.Name, input.Name) {
-			telemetry.RecordError(span, ErrColumnExists)
-			return nil, ErrColumnExists
-		}
-	}
-
-	newOrderNumber, err := s.boardRepo.IncrementColumnsAmount(ctx, input.DeskID)
+	board, err := s.authorizer.Board(ctx, input.DeskID, models.RoleEditor)
 	if err != nil {
 		telemetry.RecordError(span, err)
-		return nil, fmt.Errorf("failed to increment columns amount: %w", err)
+		return nil, err
 	}
 
 	column := &models.Column{
-		ID:           uuid.New(),
-		Name:         input.Name,
-		Desk_id:      input.DeskID,
-		Order_number: newOrderNumber,
+		ID:      uuid.New(),
+		Name:    input.Name,
+		Desk_id: input.DeskID,
 	}
 
+	// The unique index on the board's column names rejects duplicates, also
+	// between concurrent requests.
 	column, err = s.columnRepo.CreateColumn(ctx, column)
+	if mongo.IsDuplicateKeyError(err) {
+		telemetry.RecordError(span, ErrColumnExists)
+		return nil, ErrColumnExists
+	}
 	if err != nil {
-		_ = s.boardRepo.DecrementColumnsAmount(ctx, input.DeskID)
 		telemetry.RecordError(span, err)
 		return nil, fmt.Errorf("failed to create column: %w", err)
 	}
 
+	metrics.Created(metrics.KindColumn, board.Metodology)
+	s.logger.DebugContext(ctx, "column created", "board_id", column.Desk_id, "column_id", column.ID)
+	s.changes.Publish(watch.Change{Type: watch.ColumnCreated, BoardID: column.Desk_id, UserID: userID, Column: column})
 	return column, nil
 }
 
@@ -104,35 +115,69 @@ func (s *ColumnService) UpdateColumn(ctx context.Context, input UpdateColumnInpu
 	ctx, span := telemetry.StartSpan(ctx, "ColumnService.UpdateColumn")
 	defer span.End()
 
-	column, err := s.columnRepo.GetColumnInfo(ctx, input.ID)
+	userID, err := userIDFromContext(ctx)
 	if err != nil {
-		if err == mongo.ErrNoDocuments {
-			telemetry.RecordError(span, ErrColumnNotFound)
-			return nil, ErrColumnNotFound
-		}
 		telemetry.RecordError(span, err)
-		return nil, fmt.Errorf("failed to get column info: %w", err)
+		return nil, err
 	}
 
-	updates := &repository.ColumnUpdates{}
+	column, err := s.authorizer.Column(ctx, input.ID, models.RoleEditor)
+	if err != nil {
+		telemetry.RecordError(span, err)
+		return nil, err
+	}
+
+	column, err = s.columnRepo.UpdateColumn(ctx, input.ID, &repository.ColumnUpdates{Name: input.Name})
+	if mongo.IsDuplicateKeyError(err) {
+		telemetry.RecordError(span, ErrColumnExists)
+		return nil, ErrColumnExists
+	}
+	if err != nil {
+		telemetry.RecordError(span, err)
+		return nil, err
+	}
 
 	if input.Name != nil {
-		existColumns, err := s.columnRepo.GetColumns(ctx, column.Desk_id)
-		if err != nil {
-			telemetry.RecordError(span, err)
-			return nil, fmt.Errorf("failed to get columns: %w", err)
-		}
-
-		for _, col := range existColumns {
-			if col.ID != column.ID && strings.EqualFold(col.Name, *input.Name) {
-				telemetry.RecordError(span, ErrColumnExists)
-				return nil, ErrColumnExists
-			}
-		}
-		updates.Name = input.Name
-	}
-
-	return s.columnRepo.UpdateColumn(ctx, input.ID, updates)
+		s.changes.Publish(watch.Change{Type: watch.ColumnRenamed, BoardID: column.Desk_id, UserID: userID, Column: column})
+	}
+	return column, nil
+}
+
+func (s *ColumnService) MoveColumn(ctx context.Context, input MoveColumnInput) (*models.Column, error) {
+	ctx, span := telemetry.StartSpan(ctx, "ColumnService.MoveColumn")
+	defer span.End()
+
+	userID, err := userIDFromContext(ctx)
+	if err != nil {
+		telemetry.RecordError(span, err)
+		return nil, err
+	}
+
+	column, err := s.authorizer.Column(ctx, input.ID, models.RoleEditor)
+	if err != nil {
+		telemetry.RecordError(span, err)
+		return nil, err
+	}
+
+	columns, err := s.columnRepo.GetColumns(ctx, column.Desk_id)
+	if err != nil {
+		telemetry.RecordError(span, err)
+		return nil, fmt.Errorf("failed to get columns: %w", err)
+	}
+
+	if input.OrderNumber < 1 || input.OrderNumber > len(columns) {
+		telemetry.RecordError(span, ErrInvalidOrder)
+		return nil, ErrInvalidOrder
+	}
+
+	column, err = s.columnRepo.MoveColumn(ctx, input.ID, input.OrderNumber)
+	if err != nil {
+		telemetry.RecordError(span, err)
+		return nil, fmt.Errorf("failed to move column: %w", err)
+	}
+
+	s.changes.Publish(watch.Change{Type: watch.ColumnMoved, BoardID: column.Desk_id, UserID: userID, Column: column})
+	return column, nil
 }
 
 func (s *ColumnService) DeleteColumn(ctx context.Context, input DeleteColumnInput) error {
@@ -144,33 +189,40 @@ func (s *ColumnService) DeleteColumn(ctx context.Context, input DeleteColumnInpu
 		return ErrEmptyID
 	}
 
-	column, err := s.columnRepo.GetColumnInfo(ctx, input.ID)
+	userID, err := userIDFromContext(ctx)
 	if err != nil {
-		if err == mongo.ErrNoDocuments {
-			telemetry.RecordError(span, ErrColumnNotFound)
-			return ErrColumnNotFound
-		}
 		telemetry.RecordError(span, err)
-		return fmt.Errorf("failed to get column info: %w", err)
+		return err
 	}
 
-	err = s.columnRepo.DeleteColumn(ctx, input.ID)
+	column, board, err := s.authorizer.columnWithBoard(ctx, input.ID, models.RoleEditor)
 	if err != nil {
 		telemetry.RecordError(span, err)
-		return fmt.Errorf("failed to delete column: %w", err)
+		return err
 	}
 
-	err = s.columnRepo.DecrementOrderNumbers(ctx, column.Desk_id, column.Order_number)
-	if err != nil {
-		telemetry.RecordError(span, err)
-		return fmt.Errorf("failed to decrement order numbers: %w", err)
+	// Tasks that are deleted with the column leave the calendar as well. The
+	// events are built from the tasks the repository actually deletes, in
+	// the same transaction. The target is checked in the transaction that
+	// moves the tasks, so that it cannot be deleted in between.
+	err = s.columnRepo.DeleteColumn(ctx, input.ID, input.MoveTasksTo, deleteEvents(board))
+	if err == repository.ErrInvalidTarget {
+		telemetry.RecordError(span, ErrInvalidTarget)
+		return ErrInvalidTarget
 	}
-
-	err = s.boardRepo.DecrementColumnsAmount(ctx, column.Desk_id)
 	if err != nil {
 		telemetry.RecordError(span, err)
-		return fmt.Errorf("failed to decrement columns amount: %w", err)
+		return fmt.Errorf("failed to delete column: %w", err)
 	}
 
+	// The handed over tasks got new ranks in the target, so its watchers
+	// reload it rather than receiving a move per task.
+	if input.MoveTasksTo != nil {
+		target := &models.Column{ID: *input.MoveTasksTo, Desk_id: column.Desk_id}
+		s.changes.Publish(watch.Change{Type: watch.TasksReranked, BoardID: column.Desk_id, UserID: userID, Column: target})
+	}
+	deleted := &models.Column{ID: column.ID, Desk_id: column.Desk_id}
+	s.logger.DebugContext(ctx, "column deleted", "board_id", column.Desk_id, "column_id", column.ID)
+	s.changes.Publish(watch.Change{Type: watch.ColumnDeleted, BoardID: column.Desk_id, UserID: userID, Column: deleted})
 	return nil
 }
@@ -0,0 +1,148 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/SeiFlow-3P2/board_service/internal/models"
+	"github.com/google/uuid"
+)
+
+func columnNames(t *testing.T, f *fixture) []string {
+	t.Helper()
+
+	columns, err := fakeColumnRepo{f.store}.GetColumns(context.Background(), f.boardID)
+	if err != nil {
+		t.Fatalf("GetColumns(): %v", err)
+	}
+	names := make([]string, 0, len(columns))
+	for i, column := range columns {
+		if column.Order_number != i+1 {
+			t.Fatalf("order numbers are not dense: %q has %d at index %d", column.Name, column.Order_number, i)
+		}
+		names = append(names, column.Name)
+	}
+	return names
+}
+
+func TestMoveColumn(t *testing.T) {
+	ctx := asUser(ownerID)
+
+	f := newFixture(t)
+	thirdID := uuid.New()
+	f.store.columns[thirdID] = &models.Column{ID: thirdID, Name: "Review", Order_number: 3, Desk_id: f.boardID}
+	assertTitles(t, columnNames(t, f), "To Do", "Done", "Review")
+
+	if _, err := f.columns.MoveColumn(ctx, MoveColumnInput{ID: thirdID, OrderNumber: 1}); err != nil {
+		t.Fatalf("MoveColumn(): %v", err)
+	}
+	assertTitles(t, columnNames(t, f), "Review", "To Do", "Done")
+
+	if _, err := f.columns.MoveColumn(ctx, MoveColumnInput{ID: thirdID, OrderNumber: 3}); err != nil {
+		t.Fatalf("MoveColumn(): %v", err)
+	}
+	assertTitles(t, columnNames(t, f), "To Do", "Done", "Review")
+
+	for _, orderNumber := range []int{0, 4} {
+		if _, err := f.columns.MoveColumn(ctx, MoveColumnInput{ID: thirdID, OrderNumber: orderNumber}); err != ErrInvalidOrder {
+			t.Fatalf("MoveColumn(%d): expected ErrInvalidOrder, got %v", orderNumber, err)
+		}
+	}
+
+	if _, err := f.columns.MoveColumn(asUser(strangerID), MoveColumnInput{ID: thirdID, OrderNumber: 1}); err != ErrPermissionDenied {
+		t.Fatalf("expected ErrPermissionDenied, got %v", err)
+	}
+}
+
+func TestDeleteColumn(t *testing.T) {
+	ctx := asUser(ownerID)
+
+	t.Run("deletes tasks", func(t *testing.T) {
+		f := newFixture(t)
+		if err := f.columns.DeleteColumn(ctx, DeleteColumnInput{ID: f.columnID}); err != nil {
+			t.Fatalf("DeleteColumn(): %v", err)
+		}
+		if _, ok := f.store.tasks[f.taskID]; ok {
+			t.Fatal("task of the deleted column was kept")
+		}
+		assertTitles(t, columnNames(t, f), "Done")
+		if amount := f.store.boards[f.boardID].Columns_amount; amount != 1 {
+			t.Fatalf("expected columns_amount 1, got %d", amount)
+		}
+	})
+
+	t.Run("moves tasks behind the target's tasks", func(t *testing.T) {
+		f := newFixture(t)
+		createTasks(t, f, ctx, f.columnID, "second")
+		createTasks(t, f, ctx, f.otherCol, "existing")
+
+		if err := f.columns.DeleteColumn(ctx, DeleteColumnInput{ID: f.columnID, MoveTasksTo: &f.otherCol}); err != nil {
+			t.Fatalf("DeleteColumn(): %v", err)
+		}
+		assertTitles(t, columnTitles(t, f, f.otherCol), "existing", "task", "second")
+		assertTitles(t, columnNames(t, f), "Done")
+	})
+
+	t.Run("rejects invalid targets", func(t *testing.T) {
+		f := newFixture(t)
+		foreignBoard, foreignColumn := uuid.New(), uuid.New()
+		f.store.boards[foreignBoard] = &models.Board{ID: foreignBoard, User_id: ownerID}
+		f.store.columns[foreignColumn] = &models.Column{ID: foreignColumn, Desk_id: foreignBoard}
+		missing := uuid.New()
+
+		for _, target := range []uuid.UUID{f.columnID, foreignColumn, missing} {
+			err := f.columns.DeleteColumn(ctx, DeleteColumnInput{ID: f.columnID, MoveTasksTo: &target})
+			if err != ErrInvalidTarget {
+				t.Fatalf("expected ErrInvalidTarget, got %v", err)
+			}
+		}
+		if _, ok := f.store.columns[f.columnID]; !ok {
+			t.Fatal("column was deleted despite an invalid target")
+		}
+	})
+}
+
+func TestColumnNamesAreUnique(t *testing.T) {
+	ctx := asUser(ownerID)
+	f := newFixture(t)
+
+	const n = 10
+	var wg sync.WaitGroup
+	errs := make(chan error, n)
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := f.columns.CreateColumn(ctx, CreateColumnInput{Name: "Review", DeskID: f.boardID})
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	created := 0
+	for err := range errs {
+		switch err {
+		case nil:
+			created++
+		case ErrColumnExists:
+		default:
+			t.Fatalf("CreateColumn(): %v", err)
+		}
+	}
+	if created != 1 {
+		t.Fatalf("expected one column to be created, got %d", created)
+	}
+
+	if _, err := f.columns.CreateColumn(ctx, CreateColumnInput{Name: "review", DeskID: f.boardID}); err != ErrColumnExists {
+		t.Fatalf("expected ErrColumnExists for another case, got %v", err)
+	}
+	name := "DONE"
+	if _, err := f.columns.UpdateColumn(ctx, UpdateColumnInput{ID: f.columnID, Name: &name}); err != ErrColumnExists {
+		t.Fatalf("expected ErrColumnExists on rename, got %v", err)
+	}
+	name = "to do"
+	if _, err := f.columns.UpdateColumn(ctx, UpdateColumnInput{ID: f.columnID, Name: &name}); err != nil {
+		t.Fatalf("expected the column to keep its own name in another case, got %v", err)
+	}
+}
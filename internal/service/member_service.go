@@ -0,0 +1,219 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/SeiFlow-3P2/board_service/internal/models"
+	"github.com/SeiFlow-3P2/board_service/internal/repository"
+	"github.com/SeiFlow-3P2/board_service/internal/watch"
+	"github.com/SeiFlow-3P2/shared/telemetry"
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+var (
+	ErrEmptyUserID    = errors.New("user ID cannot be empty")
+	ErrInvalidRole    = errors.New("role must be editor or viewer")
+	ErrMemberExists   = errors.New("user is already a member of the board")
+	ErrMemberNotFound = errors.New("member not found")
+)
+
+type MemberService struct {
+	memberRepo repository.MemberRepository
+	authorizer *Authorizer
+	changes    *watch.Bus
+	logger     *slog.Logger
+}
+
+func NewMemberService(memberRepo repository.MemberRepository, authorizer *Authorizer, changes *watch.Bus, logger *slog.Logger) *MemberService {
+	return &MemberService{
+		memberRepo: memberRepo,
+		authorizer: authorizer,
+		changes:    changes,
+		logger:     logger,
+	}
+}
+
+type AddMemberInput struct {
+	BoardID uuid.UUID
+	UserID  string
+	Role    models.Role
+}
+
+type UpdateMemberRoleInput struct {
+	BoardID uuid.UUID
+	UserID  string
+	Role    models.Role
+}
+
+type RemoveMemberInput struct {
+	BoardID uuid.UUID
+	UserID  string
+}
+
+// assignableRole reports whether role can be given to a member. Ownership is
+// tied to the board itself and cannot be granted.
+func assignableRole(role models.Role) bool {
+	return role == models.RoleEditor || role == models.RoleViewer
+}
+
+func (s *MemberService) AddBoardMember(ctx context.Context, input AddMemberInput) (*models.BoardMember, error) {
+	ctx, span := telemetry.StartSpan(ctx, "MemberService.AddBoardMember")
+	defer span.End()
+
+	if strings.TrimSpace(input.UserID) == "" {
+		telemetry.RecordError(span, ErrEmptyUserID)
+		return nil, ErrEmptyUserID
+	}
+	if !assignableRole(input.Role) {
+		telemetry.RecordError(span, ErrInvalidRole)
+		return nil, ErrInvalidRole
+	}
+
+	board, err := s.authorizer.Board(ctx, input.BoardID, models.RoleOwner)
+	if err != nil {
+		telemetry.RecordError(span, err)
+		return nil, err
+	}
+	if board.User_id == input.UserID {
+		telemetry.RecordError(span, ErrMemberExists)
+		return nil, ErrMemberExists
+	}
+
+	_, err = s.memberRepo.GetMember(ctx, input.BoardID, input.UserID)
+	if err == nil {
+		telemetry.RecordError(span, ErrMemberExists)
+		return nil, ErrMemberExists
+	}
+	if err != mongo.ErrNoDocuments {
+		telemetry.RecordError(span, err)
+		return nil, err
+	}
+
+	member := &models.BoardMember{
+		ID:         uuid.New(),
+		Board_id:   input.BoardID,
+		User_id:    input.UserID,
+		Role:       input.Role,
+		Created_at: time.Now(),
+	}
+
+	member, err = s.memberRepo.AddMember(ctx, member)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			err = ErrMemberExists
+		}
+		telemetry.RecordError(span, err)
+		return nil, err
+	}
+	s.logger.InfoContext(ctx, "member added", "board_id", member.Board_id, "member_id", member.User_id, "role", member.Role)
+	return member, nil
+}
+
+// ListBoardMembers returns the board owner followed by the members in the
+// order they were added.
+func (s *MemberService) ListBoardMembers(ctx context.Context, boardID uuid.UUID) ([]*models.BoardMember, error) {
+	ctx, span := telemetry.StartSpan(ctx, "MemberService.ListBoardMembers")
+	defer span.End()
+
+	board, err := s.authorizer.Board(ctx, boardID, models.RoleViewer)
+	if err != nil {
+		telemetry.RecordError(span, err)
+		return nil, err
+	}
+
+	members, err := s.memberRepo.GetMembers(ctx, boardID)
+	if err != nil {
+		telemetry.RecordError(span, err)
+		return nil, err
+	}
+
+	owner := &models.BoardMember{
+		Board_id:   board.ID,
+		User_id:    board.User_id,
+		Role:       models.RoleOwner,
+		Created_at: board.Created_at,
+	}
+	return append([]*models.BoardMember{owner}, members...), nil
+}
+
+func (s *MemberService) UpdateMemberRole(ctx context.Context, input UpdateMemberRoleInput) (*models.BoardMember, error) {
+	ctx, span := telemetry.StartSpan(ctx, "MemberService.UpdateMemberRole")
+	defer span.End()
+
+	if !assignableRole(input.Role) {
+		telemetry.RecordError(span, ErrInvalidRole)
+		return nil, ErrInvalidRole
+	}
+
+	if _, err := s.authorizer.Board(ctx, input.BoardID, models.RoleOwner); err != nil {
+		telemetry.RecordError(span, err)
+		return nil, err
+	}
+
+	if err := s.ensureMember(ctx, input.BoardID, input.UserID); err != nil {
+		telemetry.RecordError(span, err)
+		return nil, err
+	}
+
+	member, err := s.memberRepo.UpdateMemberRole(ctx, input.BoardID, input.UserID, input.Role)
+	if err != nil {
+		telemetry.RecordError(span, err)
+		return nil, err
+	}
+	// Every assignable role can view the board, so the member keeps watching
+	// it.
+	s.logger.InfoContext(ctx, "member role changed", "board_id", input.BoardID, "member_id", input.UserID, "role", input.Role)
+	return member, nil
+}
+
+// RemoveBoardMember removes a member from the board. The owner can remove
+// anybody, other members can only remove themselves.
+func (s *MemberService) RemoveBoardMember(ctx context.Context, input RemoveMemberInput) error {
+	ctx, span := telemetry.StartSpan(ctx, "MemberService.RemoveBoardMember")
+	defer span.End()
+
+	userID, err := userIDFromContext(ctx)
+	if err != nil {
+		telemetry.RecordError(span, err)
+		return err
+	}
+
+	required := models.RoleOwner
+	if userID == input.UserID {
+		required = models.RoleViewer
+	}
+
+	if _, err := s.authorizer.Board(ctx, input.BoardID, required); err != nil {
+		telemetry.RecordError(span, err)
+		return err
+	}
+
+	if err := s.ensureMember(ctx, input.BoardID, input.UserID); err != nil {
+		telemetry.RecordError(span, err)
+		return err
+	}
+
+	if err := s.memberRepo.RemoveMember(ctx, input.BoardID, input.UserID); err != nil {
+		telemetry.RecordError(span, err)
+		return err
+	}
+	s.changes.Revoke(input.BoardID, input.UserID)
+	s.logger.InfoContext(ctx, "member removed", "board_id", input.BoardID, "member_id", input.UserID)
+	return nil
+}
+
+func (s *MemberService) ensureMember(ctx context.Context, boardID uuid.UUID, userID string) error {
+	_, err := s.memberRepo.GetMember(ctx, boardID, userID)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return ErrMemberNotFound
+		}
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,160 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/SeiFlow-3P2/board_service/internal/models"
+)
+
+const (
+	editorID = "editor"
+	viewerID = "viewer"
+)
+
+func newSharedFixture(t *testing.T) *fixture {
+	t.Helper()
+
+	f := newFixture(t)
+	ctx := asUser(ownerID)
+	for userID, role := range map[string]models.Role{editorID: models.RoleEditor, viewerID: models.RoleViewer} {
+		if _, err := f.members.AddBoardMember(ctx, AddMemberInput{BoardID: f.boardID, UserID: userID, Role: role}); err != nil {
+			t.Fatalf("AddBoardMember(%s): %v", userID, err)
+		}
+	}
+	return f
+}
+
+func TestRolePermissions(t *testing.T) {
+	tests := []struct {
+		user    string
+		allowed map[string]bool
+	}{
+		{
+			user: editorID,
+			allowed: map[string]bool{
+				"GetBoardInfo": true, "UpdateBoard": true, "DeleteBoard": false,
+				"CreateColumn": true, "UpdateColumn": true, "DeleteColumn": true,
+				"CreateTask": true, "MoveTask": true, "UpdateTask": true, "DeleteTask": true,
+			},
+		},
+		{
+			user: viewerID,
+			allowed: map[string]bool{
+				"GetBoardInfo": true, "UpdateBoard": false, "DeleteBoard": false,
+				"CreateColumn": false, "UpdateColumn": false, "DeleteColumn": false,
+				"CreateTask": false, "MoveTask": false, "UpdateTask": false, "DeleteTask": false,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		for name, allowed := range tt.allowed {
+			t.Run(tt.user+"/"+name, func(t *testing.T) {
+				f := newSharedFixture(t)
+				err := f.operations()[name](asUser(tt.user))
+				if allowed && err != nil {
+					t.Fatalf("expected %s to be allowed, got %v", name, err)
+				}
+				if !allowed && err != ErrPermissionDenied {
+					t.Fatalf("expected ErrPermissionDenied, got %v", err)
+				}
+			})
+		}
+	}
+}
+
+func TestMemberManagement(t *testing.T) {
+	t.Run("only the owner adds members", func(t *testing.T) {
+		f := newSharedFixture(t)
+		_, err := f.members.AddBoardMember(asUser(editorID), AddMemberInput{BoardID: f.boardID, UserID: "new", Role: models.RoleViewer})
+		if err != ErrPermissionDenied {
+			t.Fatalf("expected ErrPermissionDenied, got %v", err)
+		}
+	})
+
+	t.Run("owner role cannot be assigned", func(t *testing.T) {
+		f := newSharedFixture(t)
+		_, err := f.members.AddBoardMember(asUser(ownerID), AddMemberInput{BoardID: f.boardID, UserID: "new", Role: models.RoleOwner})
+		if err != ErrInvalidRole {
+			t.Fatalf("expected ErrInvalidRole, got %v", err)
+		}
+		_, err = f.members.UpdateMemberRole(asUser(ownerID), UpdateMemberRoleInput{BoardID: f.boardID, UserID: viewerID, Role: models.RoleOwner})
+		if err != ErrInvalidRole {
+			t.Fatalf("expected ErrInvalidRole, got %v", err)
+		}
+	})
+
+	t.Run("duplicate member", func(t *testing.T) {
+		f := newSharedFixture(t)
+		for _, userID := range []string{viewerID, ownerID} {
+			_, err := f.members.AddBoardMember(asUser(ownerID), AddMemberInput{BoardID: f.boardID, UserID: userID, Role: models.RoleEditor})
+			if err != ErrMemberExists {
+				t.Fatalf("AddBoardMember(%s): expected ErrMemberExists, got %v", userID, err)
+			}
+		}
+	})
+
+	t.Run("promote viewer to editor", func(t *testing.T) {
+		f := newSharedFixture(t)
+		deadline := time.Now().Add(time.Hour)
+		input := CreateTaskInput{Title: "t", ColumnID: f.columnID, Deadline: &deadline}
+
+		if _, err := f.tasks.CreateTask(asUser(viewerID), input); err != ErrPermissionDenied {
+			t.Fatalf("expected ErrPermissionDenied before promotion, got %v", err)
+		}
+		if _, err := f.members.UpdateMemberRole(asUser(ownerID), UpdateMemberRoleInput{BoardID: f.boardID, UserID: viewerID, Role: models.RoleEditor}); err != nil {
+			t.Fatalf("UpdateMemberRole(): %v", err)
+		}
+		if _, err := f.tasks.CreateTask(asUser(viewerID), input); err != nil {
+			t.Fatalf("expected CreateTask to succeed after promotion, got %v", err)
+		}
+	})
+
+	t.Run("members can leave but not remove others", func(t *testing.T) {
+		f := newSharedFixture(t)
+		if err := f.members.RemoveBoardMember(asUser(viewerID), RemoveMemberInput{BoardID: f.boardID, UserID: editorID}); err != ErrPermissionDenied {
+			t.Fatalf("expected ErrPermissionDenied, got %v", err)
+		}
+		if err := f.members.RemoveBoardMember(asUser(viewerID), RemoveMemberInput{BoardID: f.boardID, UserID: viewerID}); err != nil {
+			t.Fatalf("RemoveBoardMember(self): %v", err)
+		}
+		if _, err := f.boards.GetBoardInfo(asUser(viewerID), f.boardID); err != ErrPermissionDenied {
+			t.Fatalf("expected ErrPermissionDenied after leaving, got %v", err)
+		}
+	})
+
+	t.Run("list members starts with the owner", func(t *testing.T) {
+		f := newSharedFixture(t)
+		members, err := f.members.ListBoardMembers(asUser(viewerID), f.boardID)
+		if err != nil {
+			t.Fatalf("ListBoardMembers(): %v", err)
+		}
+		if len(members) != 3 {
+			t.Fatalf("expected 3 members, got %d", len(members))
+		}
+		if members[0].User_id != ownerID || members[0].Role != models.RoleOwner {
+			t.Fatalf("expected owner first, got %+v", members[0])
+		}
+	})
+}
+
+func TestGetBoardsIncludesSharedBoards(t *testing.T) {
+	f := newSharedFixture(t)
+
+	page, err := f.boards.GetBoards(asUser(editorID), GetBoardsInput{})
+	if err != nil {
+		t.Fatalf("GetBoards(): %v", err)
+	}
+	if len(page.Boards) != 1 || page.Boards[0].ID != f.boardID {
+		t.Fatalf("expected the shared board, got %+v", page.Boards)
+	}
+
+	page, err = f.boards.GetBoards(asUser(strangerID), GetBoardsInput{})
+	if err != nil {
+		t.Fatalf("GetBoards(): %v", err)
+	}
+	if len(page.Boards) != 0 {
+		t.Fatalf("expected no boards for a stranger, got %d", len(page.Boards))
+	}
+}
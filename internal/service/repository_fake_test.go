@@ -0,0 +1,649 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/SeiFlow-3P2/board_service/internal/models"
+	"github.com/SeiFlow-3P2/board_service/internal/rank"
+	"github.com/SeiFlow-3P2/board_service/internal/repository"
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// memStore is an in-memory stand-in for the Mongo collections used by the
+// repository fakes below.
+type memStore struct {
+	mu      sync.Mutex
+	boards  map[uuid.UUID]*models.Board
+	columns map[uuid.UUID]*models.Column
+	tasks   map[uuid.UUID]*models.Task
+	members map[uuid.UUID]*models.BoardMember
+	outbox  []*models.OutboxEvent
+	// processed holds the IDs of the events applied with ApplyEventOnce.
+	processed map[uuid.UUID]bool
+}
+
+func newMemStore() *memStore {
+	return &memStore{
+		boards:    map[uuid.UUID]*models.Board{},
+		columns:   map[uuid.UUID]*models.Column{},
+		tasks:     map[uuid.UUID]*models.Task{},
+		members:   map[uuid.UUID]*models.BoardMember{},
+		processed: map[uuid.UUID]bool{},
+	}
+}
+
+type fakeBoardRepo struct{ s *memStore }
+
+type fakeColumnRepo struct{ s *memStore }
+
+type fakeTaskRepo struct{ s *memStore }
+
+type fakeMemberRepo struct{ s *memStore }
+
+var (
+	_ repository.BoardRepository  = fakeBoardRepo{}
+	_ repository.ColumnRepository = fakeColumnRepo{}
+	_ repository.TaskRepository   = fakeTaskRepo{}
+	_ repository.MemberRepository = fakeMemberRepo{}
+)
+
+func (r fakeBoardRepo) CreateBoard(ctx context.Context, board *models.Board) (*models.Board, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	b := *board
+	b.Columns = nil
+	r.s.boards[board.ID] = &b
+	for _, column := range board.Columns {
+		c := column
+		r.s.columns[column.ID] = &c
+	}
+	return board, nil
+}
+
+func (r fakeBoardRepo) GetBoard(ctx context.Context, id uuid.UUID) (*models.Board, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	board, ok := r.s.boards[id]
+	if !ok {
+		return nil, mongo.ErrNoDocuments
+	}
+	b := *board
+	return &b, nil
+}
+
+func (r fakeBoardRepo) GetBoardInfo(ctx context.Context, id uuid.UUID) (*models.Board, error) {
+	board, err := r.GetBoard(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	for _, column := range r.s.columns {
+		if column.Desk_id != id {
+			continue
+		}
+		c := *column
+		for _, task := range r.s.tasks {
+			if task.Column_id == c.ID {
+				c.Tasks = append(c.Tasks, *task)
+			}
+		}
+		board.Columns = append(board.Columns, c)
+	}
+	return board, nil
+}
+
+func (r fakeBoardRepo) GetBoards(ctx context.Context, userID string) ([]*models.Board, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	var boards []*models.Board
+	for _, board := range r.s.boards {
+		if board.User_id == userID {
+			b := *board
+			boards = append(boards, &b)
+		}
+	}
+	return boards, nil
+}
+
+func (r fakeBoardRepo) ListBoards(ctx context.Context, query *repository.BoardQuery) ([]*models.Board, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	shared := map[uuid.UUID]bool{}
+	for _, id := range query.SharedIDs {
+		shared[id] = true
+	}
+	// compare orders two boards by the query's sort field and then by ID.
+	compare := func(a *models.Board, value any, id uuid.UUID) int {
+		var c int
+		switch v := value.(type) {
+		case string:
+			c = strings.Compare(a.Title, v)
+		case time.Time:
+			field := a.Updated_at
+			if query.SortBy == repository.BoardSortCreatedAt {
+				field = a.Created_at
+			}
+			c = field.Compare(v)
+		}
+		if c == 0 {
+			c = bytes.Compare(a.ID[:], id[:])
+		}
+		if query.Descending {
+			c = -c
+		}
+		return c
+	}
+	sortValue := func(b *models.Board) any {
+		switch query.SortBy {
+		case repository.BoardSortTitle:
+			return b.Title
+		case repository.BoardSortCreatedAt:
+			return b.Created_at
+		}
+		return b.Updated_at
+	}
+
+	var boards []*models.Board
+	for _, board := range r.s.boards {
+		switch {
+		case board.User_id != query.UserID && !shared[board.ID]:
+		case query.Category != nil && board.Category != *query.Category:
+		case query.Methodology != nil && board.Metodology != *query.Methodology:
+		case query.Favorite != nil && board.Favorite != *query.Favorite:
+		case query.After != nil && compare(board, query.After.Value, query.After.ID) <= 0:
+		default:
+			b := *board
+			boards = append(boards, &b)
+		}
+	}
+	sort.Slice(boards, func(i, j int) bool {
+		return compare(boards[i], sortValue(boards[j]), boards[j].ID) < 0
+	})
+	if len(boards) > query.Limit {
+		boards = boards[:query.Limit]
+	}
+	return boards, nil
+}
+
+func (r fakeBoardRepo) UpdateBoard(ctx context.Context, id uuid.UUID, updates *repository.BoardUpdates) (*models.Board, error) {
+	r.s.mu.Lock()
+	board, ok := r.s.boards[id]
+	if !ok {
+		r.s.mu.Unlock()
+		return nil, mongo.ErrNoDocuments
+	}
+	if updates.Title != nil {
+		board.Title = *updates.Title
+	}
+	if updates.Description != nil {
+		board.Description = *updates.Description
+	}
+	if updates.Progress != nil {
+		board.Progress = *updates.Progress
+	}
+	if updates.Favorite != nil {
+		board.Favorite = *updates.Favorite
+	}
+	if updates.Updated_at != nil {
+		board.Updated_at = *updates.Updated_at
+	}
+	r.s.mu.Unlock()
+	return r.GetBoardInfo(ctx, id)
+}
+
+func (r fakeBoardRepo) DeleteBoard(ctx context.Context, id uuid.UUID, deleteEvents repository.DeleteEvents) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	var inCalendar []*models.Task
+	for columnID, column := range r.s.columns {
+		if column.Desk_id != id {
+			continue
+		}
+		for taskID, task := range r.s.tasks {
+			if task.Column_id != columnID {
+				continue
+			}
+			if task.In_Calendar {
+				inCalendar = append(inCalendar, task)
+			}
+			delete(r.s.tasks, taskID)
+		}
+		delete(r.s.columns, columnID)
+	}
+	if deleteEvents != nil {
+		r.s.outbox = append(r.s.outbox, deleteEvents(inCalendar)...)
+	}
+	for memberID, member := range r.s.members {
+		if member.Board_id == id {
+			delete(r.s.members, memberID)
+		}
+	}
+	delete(r.s.boards, id)
+	return nil
+}
+
+// errDuplicateKey is what Mongo returns when a unique index rejects a write.
+var errDuplicateKey = mongo.WriteException{WriteErrors: mongo.WriteErrors{{Code: 11000, Message: "duplicate key"}}}
+
+// nameTaken emulates the unique index on the column names of a board, which
+// ignores case. The caller holds the lock.
+func (r fakeColumnRepo) nameTaken(boardID, columnID uuid.UUID, name string) bool {
+	for _, column := range r.s.columns {
+		if column.Desk_id == boardID && column.ID != columnID && strings.EqualFold(column.Name, name) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r fakeColumnRepo) CreateColumn(ctx context.Context, column *models.Column) (*models.Column, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	board, ok := r.s.boards[column.Desk_id]
+	if !ok {
+		return nil, mongo.ErrNoDocuments
+	}
+	if r.nameTaken(column.Desk_id, column.ID, column.Name) {
+		return nil, errDuplicateKey
+	}
+	board.Columns_amount++
+	column.Order_number = board.Columns_amount
+	c := *column
+	r.s.columns[column.ID] = &c
+	return column, nil
+}
+
+func (r fakeColumnRepo) GetColumnInfo(ctx context.Context, id uuid.UUID) (*models.Column, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	column, ok := r.s.columns[id]
+	if !ok {
+		return nil, mongo.ErrNoDocuments
+	}
+	c := *column
+	return &c, nil
+}
+
+func (r fakeColumnRepo) GetColumns(ctx context.Context, boardID uuid.UUID) ([]*models.Column, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	var columns []*models.Column
+	for _, column := range r.s.columns {
+		if column.Desk_id == boardID {
+			c := *column
+			columns = append(columns, &c)
+		}
+	}
+	sort.Slice(columns, func(i, j int) bool {
+		return columns[i].Order_number < columns[j].Order_number
+	})
+	return columns, nil
+}
+
+func (r fakeColumnRepo) UpdateColumn(ctx context.Context, id uuid.UUID, updates *repository.ColumnUpdates) (*models.Column, error) {
+	r.s.mu.Lock()
+	column, ok := r.s.columns[id]
+	if !ok {
+		r.s.mu.Unlock()
+		return nil, mongo.ErrNoDocuments
+	}
+	if updates.Name != nil {
+		if r.nameTaken(column.Desk_id, id, *updates.Name) {
+			r.s.mu.Unlock()
+			return nil, errDuplicateKey
+		}
+		column.Name = *updates.Name
+	}
+	r.s.mu.Unlock()
+	return r.GetColumnInfo(ctx, id)
+}
+
+func (r fakeColumnRepo) DeleteColumn(ctx context.Context, id uuid.UUID, moveTasksTo *uuid.UUID, deleteEvents repository.DeleteEvents) error {
+	if moveTasksTo != nil {
+		column, err := r.GetColumnInfo(ctx, id)
+		if err != nil {
+			return err
+		}
+		target, err := r.GetColumnInfo(ctx, *moveTasksTo)
+		if err == mongo.ErrNoDocuments || (err == nil && (target.ID == id || target.Desk_id != column.Desk_id)) {
+			return repository.ErrInvalidTarget
+		}
+		if err != nil {
+			return err
+		}
+
+		tasks := fakeTaskRepo(r)
+		moved, err := tasks.GetColumnTasks(ctx, id)
+		if err != nil {
+			return err
+		}
+		last, err := tasks.GetLastRank(ctx, *moveTasksTo)
+		if err != nil {
+			return err
+		}
+		ranks, err := rank.After(last, len(moved))
+		if err != nil {
+			return err
+		}
+		for i, task := range moved {
+			if err := tasks.MoveTask(ctx, task.ID, *moveTasksTo, ranks[i]); err != nil {
+				return err
+			}
+		}
+	}
+
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	column, ok := r.s.columns[id]
+	if !ok {
+		return mongo.ErrNoDocuments
+	}
+	var inCalendar []*models.Task
+	for taskID, task := range r.s.tasks {
+		if task.Column_id == id {
+			if task.In_Calendar {
+				inCalendar = append(inCalendar, task)
+			}
+			delete(r.s.tasks, taskID)
+		}
+	}
+	if deleteEvents != nil {
+		r.s.outbox = append(r.s.outbox, deleteEvents(inCalendar)...)
+	}
+	delete(r.s.columns, id)
+	for _, c := range r.s.columns {
+		if c.Desk_id == column.Desk_id && c.Order_number > column.Order_number {
+			c.Order_number--
+		}
+	}
+	if board, ok := r.s.boards[column.Desk_id]; ok {
+		board.Columns_amount--
+	}
+	return nil
+}
+
+func (r fakeColumnRepo) MoveColumn(ctx context.Context, id uuid.UUID, orderNumber int) (*models.Column, error) {
+	r.s.mu.Lock()
+	column, ok := r.s.columns[id]
+	if !ok {
+		r.s.mu.Unlock()
+		return nil, mongo.ErrNoDocuments
+	}
+	from := column.Order_number
+	for _, c := range r.s.columns {
+		if c.Desk_id != column.Desk_id || c.ID == id {
+			continue
+		}
+		if orderNumber < from && c.Order_number >= orderNumber && c.Order_number < from {
+			c.Order_number++
+		}
+		if orderNumber > from && c.Order_number > from && c.Order_number <= orderNumber {
+			c.Order_number--
+		}
+	}
+	column.Order_number = orderNumber
+	r.s.mu.Unlock()
+	return r.GetColumnInfo(ctx, id)
+}
+
+func (r fakeTaskRepo) CreateTask(ctx context.Context, task *models.Task, events ...*models.OutboxEvent) (*models.Task, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	r.s.outbox = append(r.s.outbox, events...)
+	t := *task
+	r.s.tasks[task.ID] = &t
+	return task, nil
+}
+
+func (r fakeTaskRepo) GetTask(ctx context.Context, id uuid.UUID) (*models.Task, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	task, ok := r.s.tasks[id]
+	if !ok {
+		return nil, mongo.ErrNoDocuments
+	}
+	t := *task
+	return &t, nil
+}
+
+func (r fakeTaskRepo) GetColumnTasks(ctx context.Context, columnID uuid.UUID) ([]*models.Task, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	var tasks []*models.Task
+	for _, task := range r.s.tasks {
+		if task.Column_id == columnID {
+			t := *task
+			tasks = append(tasks, &t)
+		}
+	}
+	sort.Slice(tasks, func(i, j int) bool {
+		if tasks[i].Rank != tasks[j].Rank {
+			return tasks[i].Rank < tasks[j].Rank
+		}
+		return tasks[i].ID.String() < tasks[j].ID.String()
+	})
+	return tasks, nil
+}
+
+func (r fakeTaskRepo) GetLastRank(ctx context.Context, columnID uuid.UUID) (string, error) {
+	tasks, err := r.GetColumnTasks(ctx, columnID)
+	if err != nil || len(tasks) == 0 {
+		return "", err
+	}
+	return tasks[len(tasks)-1].Rank, nil
+}
+
+func (r fakeTaskRepo) MoveTask(ctx context.Context, id uuid.UUID, newColumnID uuid.UUID, rank string, events ...*models.OutboxEvent) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	r.s.outbox = append(r.s.outbox, events...)
+	if task, ok := r.s.tasks[id]; ok {
+		task.Column_id = newColumnID
+		task.Rank = rank
+	}
+	return nil
+}
+
+func (r fakeTaskRepo) UpdateRanks(ctx context.Context, columnID uuid.UUID, ranks map[uuid.UUID]string) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	for id, rank := range ranks {
+		if task, ok := r.s.tasks[id]; ok && task.Column_id == columnID {
+			task.Rank = rank
+		}
+	}
+	return nil
+}
+
+func (r fakeTaskRepo) UpdateTask(ctx context.Context, id uuid.UUID, updates *repository.TaskUpdates, events ...*models.OutboxEvent) (*models.Task, error) {
+	r.s.mu.Lock()
+	task, ok := r.s.tasks[id]
+	if !ok {
+		r.s.mu.Unlock()
+		return nil, mongo.ErrNoDocuments
+	}
+	r.s.outbox = append(r.s.outbox, events...)
+	if updates.Title != nil {
+		task.Title = *updates.Title
+	}
+	if updates.Description != nil {
+		task.Description = *updates.Description
+	}
+	if updates.Deadline != nil {
+		task.Deadline = *updates.Deadline
+	}
+	if updates.In_Calendar != nil {
+		task.In_Calendar = *updates.In_Calendar
+	}
+	if updates.Calendar_user_id != nil {
+		task.Calendar_user_id = *updates.Calendar_user_id
+	}
+	r.s.mu.Unlock()
+	return r.GetTask(ctx, id)
+}
+
+func (r fakeTaskRepo) DeleteTask(ctx context.Context, id uuid.UUID, events ...*models.OutboxEvent) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	r.s.outbox = append(r.s.outbox, events...)
+	delete(r.s.tasks, id)
+	return nil
+}
+
+func (r fakeTaskRepo) ApplyEventOnce(ctx context.Context, eventID uuid.UUID, id uuid.UUID, updates *repository.TaskUpdates) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	if r.s.processed[eventID] {
+		return nil
+	}
+	task, ok := r.s.tasks[id]
+	if !ok {
+		return mongo.ErrNoDocuments
+	}
+	if updates.Deadline != nil {
+		task.Deadline = *updates.Deadline
+	}
+	if updates.In_Calendar != nil {
+		task.In_Calendar = *updates.In_Calendar
+	}
+	r.s.processed[eventID] = true
+	return nil
+}
+
+// SearchTasks scores a task by the query words its title (3 each) and
+// description (1 each) contain, a rough stand-in for the text index.
+func (r fakeTaskRepo) SearchTasks(ctx context.Context, query *repository.TaskQuery) ([]*repository.TaskMatch, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	boards := map[uuid.UUID]bool{}
+	for _, id := range query.BoardIDs {
+		boards[id] = true
+	}
+	score := func(task *models.Task) float64 {
+		var score float64
+		for _, word := range strings.Fields(strings.ToLower(query.Text)) {
+			if strings.Contains(strings.ToLower(task.Title), word) {
+				score += 3
+			}
+			if strings.Contains(strings.ToLower(task.Description), word) {
+				score++
+			}
+		}
+		return score
+	}
+	// before reports whether a sorts before the position of score and id.
+	before := func(a *repository.TaskMatch, score float64, id uuid.UUID) bool {
+		if a.Score != score {
+			return a.Score > score
+		}
+		return bytes.Compare(a.ID[:], id[:]) < 0
+	}
+
+	var matches []*repository.TaskMatch
+	for _, task := range r.s.tasks {
+		column, ok := r.s.columns[task.Column_id]
+		if !ok {
+			continue
+		}
+		match := &repository.TaskMatch{Task: *task, Score: score(task), Column: *column, Board: *r.s.boards[column.Desk_id]}
+		switch {
+		case match.Score == 0:
+		case query.ColumnID != nil && task.Column_id != *query.ColumnID:
+		case query.ColumnID == nil && !boards[column.Desk_id]:
+		case query.DeadlineFrom != nil && task.Deadline.Before(*query.DeadlineFrom):
+		case query.DeadlineTo != nil && task.Deadline.After(*query.DeadlineTo):
+		case query.InCalendar != nil && task.In_Calendar != *query.InCalendar:
+		case query.After != nil && before(match, query.After.Score, query.After.ID):
+		case query.After != nil && match.Score == query.After.Score && match.ID == query.After.ID:
+		default:
+			matches = append(matches, match)
+		}
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		return before(matches[i], matches[j].Score, matches[j].ID)
+	})
+	if len(matches) > query.Limit {
+		matches = matches[:query.Limit]
+	}
+	return matches, nil
+}
+
+func (r fakeMemberRepo) AddMember(ctx context.Context, member *models.BoardMember) (*models.BoardMember, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	m := *member
+	r.s.members[member.ID] = &m
+	return member, nil
+}
+
+func (r fakeMemberRepo) find(boardID uuid.UUID, userID string) *models.BoardMember {
+	for _, member := range r.s.members {
+		if member.Board_id == boardID && member.User_id == userID {
+			return member
+		}
+	}
+	return nil
+}
+
+func (r fakeMemberRepo) GetMember(ctx context.Context, boardID uuid.UUID, userID string) (*models.BoardMember, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	member := r.find(boardID, userID)
+	if member == nil {
+		return nil, mongo.ErrNoDocuments
+	}
+	m := *member
+	return &m, nil
+}
+
+func (r fakeMemberRepo) GetMembers(ctx context.Context, boardID uuid.UUID) ([]*models.BoardMember, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	var members []*models.BoardMember
+	for _, member := range r.s.members {
+		if member.Board_id == boardID {
+			m := *member
+			members = append(members, &m)
+		}
+	}
+	return members, nil
+}
+
+func (r fakeMemberRepo) GetMemberBoardIDs(ctx context.Context, userID string) ([]uuid.UUID, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	var ids []uuid.UUID
+	for _, member := range r.s.members {
+		if member.User_id == userID {
+			ids = append(ids, member.Board_id)
+		}
+	}
+	return ids, nil
+}
+
+func (r fakeMemberRepo) UpdateMemberRole(ctx context.Context, boardID uuid.UUID, userID string, role models.Role) (*models.BoardMember, error) {
+	r.s.mu.Lock()
+	if member := r.find(boardID, userID); member != nil {
+		member.Role = role
+	}
+	r.s.mu.Unlock()
+	return r.GetMember(ctx, boardID, userID)
+}
+
+func (r fakeMemberRepo) RemoveMember(ctx context.Context, boardID uuid.UUID, userID string) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	if member := r.find(boardID, userID); member != nil {
+		delete(r.s.members, member.ID)
+	}
+	return nil
+}
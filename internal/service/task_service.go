@@ -2,16 +2,20 @@ package service
 
 import (
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
-	"log"
+	"log/slog"
+	"strings"
 	"time"
 
 	"github.com/SeiFlow-3P2/board_service/internal/interceptor"
+	"github.com/SeiFlow-3P2/board_service/internal/metrics"
 	"github.com/SeiFlow-3P2/board_service/internal/models"
+	"github.com/SeiFlow-3P2/board_service/internal/rank"
 	"github.com/SeiFlow-3P2/board_service/internal/repository"
-	"github.com/SeiFlow-3P2/shared/kafka"
+	"github.com/SeiFlow-3P2/board_service/internal/watch"
 	"github.com/SeiFlow-3P2/shared/telemetry"
 	"github.com/google/uuid"
 	"go.mongodb.org/mongo-driver/mongo"
@@ -25,23 +29,36 @@ var (
 	ErrTaskNotFound      = errors.New("task not found")
 	ErrNewColumnNotFound = errors.New("new column not found")
 	ErrGetColumnInfo     = errors.New("failed to get column info")
+	ErrInvalidPosition   = errors.New("position must not be negative")
+	ErrAnchorTaskInvalid = errors.New("anchor task must be another task in the target column")
+	ErrEmptyQuery        = errors.New("search query cannot be empty")
+	ErrInvalidDeadlines  = errors.New("deadline_from must not be after deadline_to")
 )
 
+// maxRankLength is the rank length above which a column gets fresh ranks.
+const maxRankLength = 32
+
 type TaskService struct {
 	taskRepo   repository.TaskRepository
 	columnRepo repository.ColumnRepository
-	producer   *kafka.Producer
+	authorizer *Authorizer
+	changes    *watch.Bus
+	logger     *slog.Logger
 }
 
 func NewTaskService(
 	taskRepo repository.TaskRepository,
 	columnRepo repository.ColumnRepository,
-	producer *kafka.Producer,
+	authorizer *Authorizer,
+	changes *watch.Bus,
+	logger *slog.Logger,
 ) *TaskService {
 	return &TaskService{
 		taskRepo:   taskRepo,
 		columnRepo: columnRepo,
-		producer:   producer,
+		authorizer: authorizer,
+		changes:    changes,
+		logger:     logger,
 	}
 }
 
@@ -53,9 +70,15 @@ type CreateTaskInput struct {
 	InCalendar  bool
 }
 
+// MoveTaskInput describes where a task goes. At most one of Position,
+// BeforeTaskID and AfterTaskID is expected; with none of them the task is
+// placed at the end of the target column.
 type MoveTaskInput struct {
-	TaskID      uuid.UUID
-	NewColumnID uuid.UUID
+	TaskID       uuid.UUID
+	NewColumnID  uuid.UUID
+	Position     *int
+	BeforeTaskID *uuid.UUID
+	AfterTaskID  *uuid.UUID
 }
 
 type UpdateTaskInput struct {
@@ -63,12 +86,37 @@ type UpdateTaskInput struct {
 	Title       *string
 	Description *string
 	Deadline    *time.Time
+	InCalendar  *bool
 }
 
 type DeleteTaskInput struct {
 	TaskID uuid.UUID
 }
 
+type SearchTasksInput struct {
+	Query        string
+	BoardID      *uuid.UUID
+	ColumnID     *uuid.UUID
+	DeadlineFrom *time.Time
+	DeadlineTo   *time.Time
+	InCalendar   *bool
+	PageSize     int
+	PageToken    string
+}
+
+type TasksPage struct {
+	Tasks         []*repository.TaskMatch
+	NextPageToken string
+}
+
+// searchPageToken is the page_token of SearchTasks. It records the query it
+// was issued for, as relevance scores of other queries do not compare.
+type searchPageToken struct {
+	Query string    `json:"q"`
+	Score float64   `json:"s"`
+	ID    uuid.UUID `json:"i"`
+}
+
 func (s *TaskService) CreateTask(ctx context.Context, input CreateTaskInput) (*models.Task, error) {
 	ctx, span := telemetry.StartSpan(ctx, "TaskService.CreateTask")
 	defer span.End()
@@ -79,6 +127,31 @@ func (s *TaskService) CreateTask(ctx context.Context, input CreateTaskInput) (*m
 		return nil, ErrUserNotInContext
 	}
 
+	column, board, err := s.authorizer.columnWithBoard(ctx, input.ColumnID, models.RoleEditor)
+	if err != nil {
+		telemetry.RecordError(span, err)
+		return nil, err
+	}
+
+	lastRank, err := s.taskRepo.GetLastRank(ctx, input.ColumnID)
+	if err != nil {
+		telemetry.RecordError(span, err)
+		return nil, err
+	}
+
+	taskRank, err := rank.Between(lastRank, "")
+	if err != nil {
+		telemetry.RecordError(span, err)
+		return nil, err
+	}
+	if len(taskRank) > maxRankLength {
+		taskRank, err = s.rebalanceColumn(ctx, column.Desk_id, userID, input.ColumnID)
+		if err != nil {
+			telemetry.RecordError(span, err)
+			return nil, err
+		}
+	}
+
 	task := &models.Task{
 		ID:          uuid.New(),
 		Title:       input.Title,
@@ -86,47 +159,24 @@ func (s *TaskService) CreateTask(ctx context.Context, input CreateTaskInput) (*m
 		Deadline:    *input.Deadline,
 		Column_id:   input.ColumnID,
 		In_Calendar: input.InCalendar,
+		Rank:        taskRank,
 	}
 
-	task, err := s.taskRepo.CreateTask(ctx, task)
+	var events []*models.OutboxEvent
+	if input.InCalendar {
+		task.Calendar_user_id = userID
+		events = append(events, calendarEvent(models.EventTypeCreate, task, userID))
+	}
+
+	task, err = s.taskRepo.CreateTask(ctx, task, events...)
 	if err != nil {
 		telemetry.RecordError(span, err)
 		return nil, err
 	}
 
-	if input.InCalendar {
-		go func() {
-			msg := models.BoardEvent{
-				EventType:   "create",
-				Title:       task.Title,
-				Description: task.Description,
-				Deadline:    task.Deadline,
-				UserID:      userID,
-			}
-
-			jsonMsg, err := json.Marshal(msg)
-			if err != nil {
-				telemetry.RecordError(span, err)
-				log.Printf("failed to marshal message: %v", err)
-				return
-			}
-
-			err = s.producer.Produce(
-				ctx,
-				string(jsonMsg),
-				"board.event",
-				userID,
-				time.Second*10,
-			)
-			if err != nil {
-				telemetry.RecordError(span, err)
-				log.Printf("failed to produce message: %v", err)
-				return
-			}
-		}()
-	}
-
-	fmt.Println("task", task)
+	metrics.Created(metrics.KindTask, board.Metodology)
+	s.logger.DebugContext(ctx, "task created", "board_id", column.Desk_id, "task_id", task.ID)
+	s.changes.Publish(watch.Change{Type: watch.TaskCreated, BoardID: column.Desk_id, UserID: userID, Task: task})
 	return task, nil
 }
 
@@ -134,45 +184,188 @@ func (s *TaskService) MoveTask(ctx context.Context, input MoveTaskInput) (*model
 	ctx, span := telemetry.StartSpan(ctx, "TaskService.MoveTask")
 	defer span.End()
 
-	_, err := s.taskRepo.GetTask(ctx, input.TaskID)
+	userID, err := userIDFromContext(ctx)
 	if err != nil {
-		if err == mongo.ErrNoDocuments {
-			telemetry.RecordError(span, ErrTaskNotFound)
-			return nil, ErrTaskNotFound
-		}
 		telemetry.RecordError(span, err)
 		return nil, err
 	}
 
-	_, err = s.columnRepo.GetColumnInfo(ctx, input.NewColumnID)
+	task, column, board, err := s.authorizer.taskWithBoard(ctx, input.TaskID, models.RoleEditor)
 	if err != nil {
-		if err == mongo.ErrNoDocuments {
+		telemetry.RecordError(span, err)
+		return nil, err
+	}
+
+	newColumn, err := s.authorizer.Column(ctx, input.NewColumnID, models.RoleEditor)
+	if err != nil {
+		switch err {
+		case ErrColumnNotFound:
 			telemetry.RecordError(span, ErrNewColumnNotFound)
 			return nil, ErrNewColumnNotFound
+		case ErrPermissionDenied:
+			telemetry.RecordError(span, err)
+			return nil, err
+		default:
+			telemetry.RecordError(span, err)
+			return nil, fmt.Errorf("%w: %w", ErrGetColumnInfo, err)
 		}
+	}
+
+	taskRank, err := s.rankForMove(ctx, newColumn.Desk_id, userID, input)
+	if err != nil {
+		telemetry.RecordError(span, err)
+		return nil, err
+	}
+
+	var events []*models.OutboxEvent
+	if task.In_Calendar {
+		events = append(events, calendarEvent(models.EventTypeUpdate, task, calendarOwner(task, board)))
+	}
+
+	err = s.taskRepo.MoveTask(ctx, input.TaskID, input.NewColumnID, taskRank, events...)
+	if err != nil {
 		telemetry.RecordError(span, err)
-		return nil, ErrGetColumnInfo
+		return nil, err
 	}
 
-	err = s.taskRepo.MoveTask(ctx, input.TaskID, input.NewColumnID)
+	moved, err := s.taskRepo.GetTask(ctx, input.TaskID)
 	if err != nil {
 		telemetry.RecordError(span, err)
 		return nil, err
 	}
 
-	return s.taskRepo.GetTask(ctx, input.TaskID)
+	// A task moved to another board disappears from the old one.
+	if column.Desk_id != newColumn.Desk_id {
+		deleted := &models.Task{ID: task.ID, Column_id: column.ID}
+		s.changes.Publish(watch.Change{Type: watch.TaskDeleted, BoardID: column.Desk_id, UserID: userID, Task: deleted})
+		s.changes.Publish(watch.Change{Type: watch.TaskCreated, BoardID: newColumn.Desk_id, UserID: userID, Task: moved})
+	} else {
+		s.changes.Publish(watch.Change{Type: watch.TaskMoved, BoardID: newColumn.Desk_id, UserID: userID, Task: moved})
+	}
+	return moved, nil
+}
+
+// rankForMove computes the rank that places the task at the requested
+// position among the other tasks of the target column on board boardID.
+func (s *TaskService) rankForMove(ctx context.Context, boardID uuid.UUID, userID string, input MoveTaskInput) (string, error) {
+	if input.Position != nil && *input.Position < 0 {
+		return "", ErrInvalidPosition
+	}
+
+	columnTasks, err := s.taskRepo.GetColumnTasks(ctx, input.NewColumnID)
+	if err != nil {
+		return "", err
+	}
+
+	siblings := make([]*models.Task, 0, len(columnTasks))
+	for _, task := range columnTasks {
+		if task.ID != input.TaskID {
+			siblings = append(siblings, task)
+		}
+	}
+
+	if !ranksAreOrdered(siblings) {
+		if err := s.rebalance(ctx, boardID, input.NewColumnID, userID, siblings); err != nil {
+			return "", err
+		}
+	}
+
+	index := len(siblings)
+	switch {
+	case input.Position != nil:
+		index = min(*input.Position, len(siblings))
+	case input.BeforeTaskID != nil:
+		index = indexOfTask(siblings, *input.BeforeTaskID)
+	case input.AfterTaskID != nil:
+		index = indexOfTask(siblings, *input.AfterTaskID)
+		if index >= 0 {
+			index++
+		}
+	}
+	if index < 0 {
+		return "", ErrAnchorTaskInvalid
+	}
+
+	var prev, next string
+	if index > 0 {
+		prev = siblings[index-1].Rank
+	}
+	if index < len(siblings) {
+		next = siblings[index].Rank
+	}
+	return rank.Between(prev, next)
+}
+
+// rebalanceColumn respaces the ranks of a column and returns a rank for a new
+// task at its end.
+func (s *TaskService) rebalanceColumn(ctx context.Context, boardID uuid.UUID, userID string, columnID uuid.UUID) (string, error) {
+	tasks, err := s.taskRepo.GetColumnTasks(ctx, columnID)
+	if err != nil {
+		return "", err
+	}
+	if err := s.rebalance(ctx, boardID, columnID, userID, tasks); err != nil {
+		return "", err
+	}
+
+	var last string
+	if len(tasks) > 0 {
+		last = tasks[len(tasks)-1].Rank
+	}
+	return rank.Between(last, "")
+}
+
+// rebalance assigns fresh, evenly spaced ranks to tasks, which must be the
+// tasks of the column in display order. It is needed for tasks created
+// before ranks existed and once repeated inserts at the same spot have made
+// ranks too long. Watchers of the board are told to reload the column.
+func (s *TaskService) rebalance(ctx context.Context, boardID, columnID uuid.UUID, userID string, tasks []*models.Task) error {
+	ranks := rank.Spread(len(tasks))
+	updates := make(map[uuid.UUID]string, len(tasks))
+	for i, task := range tasks {
+		task.Rank = ranks[i]
+		updates[task.ID] = ranks[i]
+	}
+	if err := s.taskRepo.UpdateRanks(ctx, columnID, updates); err != nil {
+		return err
+	}
+	column := &models.Column{ID: columnID, Desk_id: boardID}
+	s.changes.Publish(watch.Change{Type: watch.TasksReranked, BoardID: boardID, UserID: userID, Column: column})
+	return nil
+}
+
+func ranksAreOrdered(tasks []*models.Task) bool {
+	for i, task := range tasks {
+		if task.Rank == "" || len(task.Rank) > maxRankLength {
+			return false
+		}
+		if i > 0 && task.Rank <= tasks[i-1].Rank {
+			return false
+		}
+	}
+	return true
+}
+
+func indexOfTask(tasks []*models.Task, id uuid.UUID) int {
+	for i, task := range tasks {
+		if task.ID == id {
+			return i
+		}
+	}
+	return -1
 }
 
 func (s *TaskService) UpdateTask(ctx context.Context, input UpdateTaskInput) (*models.Task, error) {
 	ctx, span := telemetry.StartSpan(ctx, "TaskService.UpdateTask")
 	defer span.End()
 
-	_, err := s.taskRepo.GetTask(ctx, input.TaskID)
+	userID, err := userIDFromContext(ctx)
+	if err != nil {
+		telemetry.RecordError(span, err)
+		return nil, err
+	}
+
+	before, column, board, err := s.authorizer.taskWithBoard(ctx, input.TaskID, models.RoleEditor)
 	if err != nil {
-		if err == mongo.ErrNoDocuments {
-			telemetry.RecordError(span, ErrTaskNotFound)
-			return nil, ErrTaskNotFound
-		}
 		telemetry.RecordError(span, err)
 		return nil, err
 	}
@@ -181,16 +374,69 @@ func (s *TaskService) UpdateTask(ctx context.Context, input UpdateTaskInput) (*m
 		Title:       input.Title,
 		Description: input.Description,
 		Deadline:    input.Deadline,
+		In_Calendar: input.InCalendar,
 	}
+	// The task lands in the calendar of the user who puts it there.
+	if !before.In_Calendar && input.InCalendar != nil && *input.InCalendar {
+		updates.Calendar_user_id = &userID
+	}
+
+	after := updatedTask(before, updates)
+	var events []*models.OutboxEvent
+	if eventType, ok := calendarChange(before, after); ok {
+		events = append(events, calendarEvent(eventType, after, calendarOwner(after, board)))
+	}
+
+	task, err := s.taskRepo.UpdateTask(ctx, input.TaskID, updates, events...)
+	if err != nil {
+		telemetry.RecordError(span, err)
+		return nil, err
+	}
+
+	s.changes.Publish(watch.Change{Type: watch.TaskUpdated, BoardID: column.Desk_id, UserID: userID, Task: task})
+	return task, nil
+}
 
-	return s.taskRepo.UpdateTask(ctx, input.TaskID, updates)
+// updatedTask returns a copy of task with updates applied.
+func updatedTask(task *models.Task, updates *repository.TaskUpdates) *models.Task {
+	updated := *task
+	if updates.Title != nil {
+		updated.Title = *updates.Title
+	}
+	if updates.Description != nil {
+		updated.Description = *updates.Description
+	}
+	if updates.Deadline != nil {
+		updated.Deadline = *updates.Deadline
+	}
+	if updates.In_Calendar != nil {
+		updated.In_Calendar = *updates.In_Calendar
+	}
+	if updates.Calendar_user_id != nil {
+		updated.Calendar_user_id = *updates.Calendar_user_id
+	}
+	return &updated
 }
 
 func (s *TaskService) DeleteTask(ctx context.Context, input DeleteTaskInput) error {
 	ctx, span := telemetry.StartSpan(ctx, "TaskService.DeleteTask")
 	defer span.End()
 
-	err := s.taskRepo.DeleteTask(ctx, input.TaskID)
+	userID, err := userIDFromContext(ctx)
+	if err != nil {
+		telemetry.RecordError(span, err)
+		return err
+	}
+
+	task, column, board, err := s.authorizer.taskWithBoard(ctx, input.TaskID, models.RoleEditor)
+	if err != nil {
+		telemetry.RecordError(span, err)
+		return err
+	}
+
+	events := calendarDeleteEvents([]*models.Task{task}, board)
+
+	err = s.taskRepo.DeleteTask(ctx, input.TaskID, events...)
 	if err != nil {
 		if err == mongo.ErrNoDocuments {
 			telemetry.RecordError(span, ErrTaskNotFound)
@@ -200,5 +446,103 @@ func (s *TaskService) DeleteTask(ctx context.Context, input DeleteTaskInput) err
 		return err
 	}
 
+	deleted := &models.Task{ID: task.ID, Column_id: task.Column_id}
+	s.logger.DebugContext(ctx, "task deleted", "board_id", column.Desk_id, "task_id", deleted.ID)
+	s.changes.Publish(watch.Change{Type: watch.TaskDeleted, BoardID: column.Desk_id, UserID: userID, Task: deleted})
 	return nil
 }
+
+// SearchTasks returns a page of the tasks matching the query on the boards
+// the user can view, narrowed to a board or column if given.
+func (s *TaskService) SearchTasks(ctx context.Context, input SearchTasksInput) (*TasksPage, error) {
+	ctx, span := telemetry.StartSpan(ctx, "TaskService.SearchTasks")
+	defer span.End()
+
+	query, err := taskQuery(input)
+	if err != nil {
+		telemetry.RecordError(span, err)
+		return nil, err
+	}
+
+	switch {
+	case input.ColumnID != nil:
+		column, err := s.authorizer.Column(ctx, *input.ColumnID, models.RoleViewer)
+		if err != nil {
+			telemetry.RecordError(span, err)
+			return nil, err
+		}
+		if input.BoardID != nil && *input.BoardID != column.Desk_id {
+			return &TasksPage{}, nil
+		}
+		query.ColumnID = &column.ID
+	case input.BoardID != nil:
+		board, err := s.authorizer.Board(ctx, *input.BoardID, models.RoleViewer)
+		if err != nil {
+			telemetry.RecordError(span, err)
+			return nil, err
+		}
+		query.BoardIDs = []uuid.UUID{board.ID}
+	default:
+		query.BoardIDs, err = s.authorizer.BoardIDs(ctx)
+		if err != nil {
+			telemetry.RecordError(span, err)
+			return nil, err
+		}
+	}
+
+	// Fetch one task more than requested to learn whether there is a next page.
+	limit := query.Limit
+	query.Limit++
+	tasks, err := s.taskRepo.SearchTasks(ctx, query)
+	if err != nil {
+		telemetry.RecordError(span, err)
+		return nil, err
+	}
+
+	page := &TasksPage{Tasks: tasks}
+	if len(tasks) > limit {
+		page.Tasks = tasks[:limit]
+		last := page.Tasks[limit-1]
+		data, _ := json.Marshal(searchPageToken{Query: query.Text, Score: last.Score, ID: last.ID})
+		page.NextPageToken = base64.RawURLEncoding.EncodeToString(data)
+	}
+	return page, nil
+}
+
+func taskQuery(input SearchTasksInput) (*repository.TaskQuery, error) {
+	query := &repository.TaskQuery{
+		Text:         strings.TrimSpace(input.Query),
+		DeadlineFrom: input.DeadlineFrom,
+		DeadlineTo:   input.DeadlineTo,
+		InCalendar:   input.InCalendar,
+		Limit:        input.PageSize,
+	}
+	if query.Text == "" {
+		return nil, ErrEmptyQuery
+	}
+	if query.DeadlineFrom != nil && query.DeadlineTo != nil && query.DeadlineFrom.After(*query.DeadlineTo) {
+		return nil, ErrInvalidDeadlines
+	}
+
+	switch {
+	case query.Limit < 0:
+		return nil, ErrInvalidPageSize
+	case query.Limit == 0:
+		query.Limit = defaultPageSize
+	case query.Limit > maxPageSize:
+		query.Limit = maxPageSize
+	}
+
+	if input.PageToken != "" {
+		data, err := base64.RawURLEncoding.DecodeString(input.PageToken)
+		if err != nil {
+			return nil, ErrInvalidPageToken
+		}
+		var token searchPageToken
+		if err := json.Unmarshal(data, &token); err != nil || token.Query != query.Text {
+			return nil, ErrInvalidPageToken
+		}
+		query.After = &repository.TaskCursor{Score: token.Score, ID: token.ID}
+	}
+	return query, nil
+}
@@ -0,0 +1,246 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/SeiFlow-3P2/board_service/internal/models"
+	"github.com/google/uuid"
+)
+
+func createTasks(t *testing.T, f *fixture, ctx context.Context, columnID uuid.UUID, titles ...string) []uuid.UUID {
+	t.Helper()
+
+	deadline := time.Now().Add(time.Hour)
+	ids := make([]uuid.UUID, 0, len(titles))
+	for _, title := range titles {
+		task, err := f.tasks.CreateTask(ctx, CreateTaskInput{Title: title, ColumnID: columnID, Deadline: &deadline})
+		if err != nil {
+			t.Fatalf("CreateTask(%s): %v", title, err)
+		}
+		ids = append(ids, task.ID)
+	}
+	return ids
+}
+
+func columnTitles(t *testing.T, f *fixture, columnID uuid.UUID) []string {
+	t.Helper()
+
+	tasks, err := fakeTaskRepo{f.store}.GetColumnTasks(context.Background(), columnID)
+	if err != nil {
+		t.Fatalf("GetColumnTasks(): %v", err)
+	}
+	titles := make([]string, 0, len(tasks))
+	for _, task := range tasks {
+		titles = append(titles, task.Title)
+	}
+	return titles
+}
+
+func assertTitles(t *testing.T, got []string, want ...string) {
+	t.Helper()
+
+	if len(got) != len(want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("expected %v, got %v", want, got)
+		}
+	}
+}
+
+func TestMoveTaskOrdering(t *testing.T) {
+	ctx := asUser(ownerID)
+
+	t.Run("new tasks are appended", func(t *testing.T) {
+		f := newFixture(t)
+		delete(f.store.tasks, f.taskID)
+		createTasks(t, f, ctx, f.otherCol, "a", "b", "c")
+		assertTitles(t, columnTitles(t, f, f.otherCol), "a", "b", "c")
+	})
+
+	t.Run("position", func(t *testing.T) {
+		f := newFixture(t)
+		ids := createTasks(t, f, ctx, f.otherCol, "a", "b", "c")
+
+		position := 0
+		if _, err := f.tasks.MoveTask(ctx, MoveTaskInput{TaskID: ids[2], NewColumnID: f.otherCol, Position: &position}); err != nil {
+			t.Fatalf("MoveTask(): %v", err)
+		}
+		assertTitles(t, columnTitles(t, f, f.otherCol), "c", "a", "b")
+
+		position = 100
+		if _, err := f.tasks.MoveTask(ctx, MoveTaskInput{TaskID: ids[0], NewColumnID: f.otherCol, Position: &position}); err != nil {
+			t.Fatalf("MoveTask(): %v", err)
+		}
+		assertTitles(t, columnTitles(t, f, f.otherCol), "c", "b", "a")
+	})
+
+	t.Run("before and after", func(t *testing.T) {
+		f := newFixture(t)
+		ids := createTasks(t, f, ctx, f.otherCol, "a", "b", "c")
+
+		if _, err := f.tasks.MoveTask(ctx, MoveTaskInput{TaskID: ids[0], NewColumnID: f.otherCol, AfterTaskID: &ids[1]}); err != nil {
+			t.Fatalf("MoveTask(): %v", err)
+		}
+		assertTitles(t, columnTitles(t, f, f.otherCol), "b", "a", "c")
+
+		if _, err := f.tasks.MoveTask(ctx, MoveTaskInput{TaskID: f.taskID, NewColumnID: f.otherCol, BeforeTaskID: &ids[2]}); err != nil {
+			t.Fatalf("MoveTask(): %v", err)
+		}
+		assertTitles(t, columnTitles(t, f, f.otherCol), "b", "a", "task", "c")
+		assertTitles(t, columnTitles(t, f, f.columnID))
+	})
+
+	t.Run("invalid anchors", func(t *testing.T) {
+		f := newFixture(t)
+		ids := createTasks(t, f, ctx, f.otherCol, "a")
+
+		if _, err := f.tasks.MoveTask(ctx, MoveTaskInput{TaskID: ids[0], NewColumnID: f.otherCol, BeforeTaskID: &ids[0]}); err != ErrAnchorTaskInvalid {
+			t.Fatalf("expected ErrAnchorTaskInvalid for self anchor, got %v", err)
+		}
+		if _, err := f.tasks.MoveTask(ctx, MoveTaskInput{TaskID: ids[0], NewColumnID: f.otherCol, AfterTaskID: &f.taskID}); err != ErrAnchorTaskInvalid {
+			t.Fatalf("expected ErrAnchorTaskInvalid for anchor in another column, got %v", err)
+		}
+		position := -1
+		if _, err := f.tasks.MoveTask(ctx, MoveTaskInput{TaskID: ids[0], NewColumnID: f.otherCol, Position: &position}); err != ErrInvalidPosition {
+			t.Fatalf("expected ErrInvalidPosition, got %v", err)
+		}
+	})
+
+	t.Run("tasks without rank are rebalanced", func(t *testing.T) {
+		f := newFixture(t)
+		// Tasks stored before ranks existed sort by ID, so the IDs are fixed
+		// to keep a before b.
+		ids := []uuid.UUID{
+			uuid.MustParse("00000000-0000-0000-0000-00000000000a"),
+			uuid.MustParse("00000000-0000-0000-0000-00000000000b"),
+		}
+		for i, title := range []string{"a", "b"} {
+			f.store.tasks[ids[i]] = &models.Task{ID: ids[i], Title: title, Column_id: f.otherCol}
+		}
+
+		if _, err := f.tasks.MoveTask(ctx, MoveTaskInput{TaskID: f.taskID, NewColumnID: f.otherCol, BeforeTaskID: &ids[1]}); err != nil {
+			t.Fatalf("MoveTask(): %v", err)
+		}
+		titles := columnTitles(t, f, f.otherCol)
+		if len(titles) != 3 || titles[1] != "task" {
+			t.Fatalf("expected task in the middle, got %v", titles)
+		}
+	})
+}
+
+func TestUpdateTaskDeadline(t *testing.T) {
+	ctx := asUser(ownerID)
+	f := newFixture(t)
+
+	deadline := time.Now().Add(time.Hour).Truncate(time.Second)
+	task, err := f.tasks.UpdateTask(ctx, UpdateTaskInput{TaskID: f.taskID, Deadline: &deadline})
+	if err != nil {
+		t.Fatalf("UpdateTask(): %v", err)
+	}
+	if !task.Deadline.Equal(deadline) {
+		t.Fatalf("expected deadline %v, got %v", deadline, task.Deadline)
+	}
+	if task.In_Calendar {
+		t.Fatal("expected the task to stay out of the calendar")
+	}
+}
+
+func TestSearchTasks(t *testing.T) {
+	ctx := asUser(ownerID)
+	f := newFixture(t)
+	createTasks(t, f, ctx, f.columnID, "release notes", "release tag", "unrelated")
+	createTasks(t, f, ctx, f.otherCol, "old release")
+
+	// Two boards of somebody else, the first one shared with the owner.
+	addBoard := func(title string) uuid.UUID {
+		boardID, columnID, taskID := uuid.New(), uuid.New(), uuid.New()
+		f.store.boards[boardID] = &models.Board{ID: boardID, Title: title, User_id: strangerID}
+		f.store.columns[columnID] = &models.Column{ID: columnID, Name: "Backlog", Desk_id: boardID}
+		f.store.tasks[taskID] = &models.Task{ID: taskID, Title: title + " release", Column_id: columnID}
+		return boardID
+	}
+	sharedBoard, hiddenBoard := addBoard("shared"), addBoard("hidden")
+	if _, err := f.members.AddBoardMember(asUser(strangerID), AddMemberInput{BoardID: sharedBoard, UserID: ownerID, Role: models.RoleViewer}); err != nil {
+		t.Fatalf("AddBoardMember(): %v", err)
+	}
+
+	t.Run("pages over the caller's boards", func(t *testing.T) {
+		input := SearchTasksInput{Query: "release", PageSize: 3}
+		var titles []string
+		for {
+			page, err := f.tasks.SearchTasks(ctx, input)
+			if err != nil {
+				t.Fatalf("SearchTasks(): %v", err)
+			}
+			for _, match := range page.Tasks {
+				if match.Board.ID != match.Column.Desk_id {
+					t.Fatalf("expected the board of column %v, got %v", match.Column.ID, match.Board.ID)
+				}
+				titles = append(titles, match.Title)
+			}
+			if page.NextPageToken == "" {
+				break
+			}
+			input.PageToken = page.NextPageToken
+		}
+		if len(titles) != 4 {
+			t.Fatalf("expected the four release tasks on the owner's and the shared board, got %v", titles)
+		}
+		for _, title := range titles {
+			if title == "hidden release" {
+				t.Fatal("found a task on a board the caller cannot view")
+			}
+		}
+	})
+
+	t.Run("filters", func(t *testing.T) {
+		page, err := f.tasks.SearchTasks(ctx, SearchTasksInput{Query: "release", ColumnID: &f.otherCol})
+		if err != nil {
+			t.Fatalf("SearchTasks(): %v", err)
+		}
+		if len(page.Tasks) != 1 || page.Tasks[0].Title != "old release" || page.Tasks[0].Column.Name != "Done" {
+			t.Fatalf("expected old release in Done, got %v", page.Tasks)
+		}
+
+		page, err = f.tasks.SearchTasks(ctx, SearchTasksInput{Query: "release", BoardID: &sharedBoard})
+		if err != nil {
+			t.Fatalf("SearchTasks(): %v", err)
+		}
+		if len(page.Tasks) != 1 || page.Tasks[0].Board.Title != "shared" {
+			t.Fatalf("expected the shared release, got %v", page.Tasks)
+		}
+
+		if _, err := f.tasks.SearchTasks(ctx, SearchTasksInput{Query: "release", BoardID: &hiddenBoard}); err != ErrPermissionDenied {
+			t.Fatalf("expected ErrPermissionDenied, got %v", err)
+		}
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		from, to := time.Now(), time.Now().Add(-time.Hour)
+		for _, tc := range []struct {
+			input SearchTasksInput
+			want  error
+		}{
+			{SearchTasksInput{Query: " "}, ErrEmptyQuery},
+			{SearchTasksInput{Query: "release", DeadlineFrom: &from, DeadlineTo: &to}, ErrInvalidDeadlines},
+			{SearchTasksInput{Query: "release", PageSize: -1}, ErrInvalidPageSize},
+			{SearchTasksInput{Query: "release", PageToken: "garbage"}, ErrInvalidPageToken},
+		} {
+			if _, err := f.tasks.SearchTasks(ctx, tc.input); err != tc.want {
+				t.Fatalf("expected %v for %+v, got %v", tc.want, tc.input, err)
+			}
+		}
+
+		page, err := f.tasks.SearchTasks(ctx, SearchTasksInput{Query: "release", PageSize: 1})
+		if err != nil {
+			t.Fatalf("SearchTasks(): %v", err)
+		}
+		if _, err := f.tasks.SearchTasks(ctx, SearchTasksInput{Query: "notes", PageToken: page.NextPageToken}); err != ErrInvalidPageToken {
+			t.Fatalf("expected ErrInvalidPageToken for a token of another query, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,93 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/SeiFlow-3P2/board_service/internal/repository"
+	"github.com/SeiFlow-3P2/board_service/internal/watch"
+	"github.com/SeiFlow-3P2/shared/telemetry"
+	"github.com/google/uuid"
+)
+
+var ErrInvalidUserEvent = errors.New("invalid user event")
+
+// PurgeProgress is reported after every board removed by PurgeUser.
+type PurgeProgress struct {
+	UserID  string
+	BoardID uuid.UUID
+	Deleted int
+	Total   int
+}
+
+// UserPurgeService removes the data of deleted users.
+type UserPurgeService struct {
+	boardRepo  repository.BoardRepository
+	memberRepo repository.MemberRepository
+	changes    *watch.Bus
+	logger     *slog.Logger
+}
+
+func NewUserPurgeService(
+	boardRepo repository.BoardRepository,
+	memberRepo repository.MemberRepository,
+	changes *watch.Bus,
+	logger *slog.Logger,
+) *UserPurgeService {
+	return &UserPurgeService{
+		boardRepo:  boardRepo,
+		memberRepo: memberRepo,
+		changes:    changes,
+		logger:     logger,
+	}
+}
+
+// PurgeUser deletes the boards owned by the user, with their columns, tasks
+// and members, and removes the user from the boards of others. Every board
+// is deleted in its own transaction, so an interrupted purge leaves whole
+// boards behind and running it again picks up where it stopped. progress may
+// be nil.
+func (s *UserPurgeService) PurgeUser(ctx context.Context, userID string, progress func(PurgeProgress)) error {
+	ctx, span := telemetry.StartSpan(ctx, "UserPurgeService.PurgeUser")
+	defer span.End()
+
+	if userID == "" {
+		telemetry.RecordError(span, ErrInvalidUserEvent)
+		return ErrInvalidUserEvent
+	}
+
+	boards, err := s.boardRepo.GetBoards(ctx, userID)
+	if err != nil {
+		telemetry.RecordError(span, err)
+		return err
+	}
+
+	for i, board := range boards {
+		// The tasks of the board leave the calendar together with it.
+		if err := s.boardRepo.DeleteBoard(ctx, board.ID, deleteEvents(board)); err != nil {
+			telemetry.RecordError(span, err)
+			return err
+		}
+		s.changes.Publish(watch.Change{Type: watch.BoardDeleted, BoardID: board.ID, UserID: userID})
+		if progress != nil {
+			progress(PurgeProgress{UserID: userID, BoardID: board.ID, Deleted: i + 1, Total: len(boards)})
+		}
+	}
+
+	sharedIDs, err := s.memberRepo.GetMemberBoardIDs(ctx, userID)
+	if err != nil {
+		telemetry.RecordError(span, err)
+		return err
+	}
+	for _, boardID := range sharedIDs {
+		if err := s.memberRepo.RemoveMember(ctx, boardID, userID); err != nil {
+			telemetry.RecordError(span, err)
+			return err
+		}
+		s.changes.Revoke(boardID, userID)
+	}
+
+	s.logger.InfoContext(ctx, "user purged", "user_id", userID, "boards", len(boards), "memberships", len(sharedIDs))
+	return nil
+}
@@ -0,0 +1,53 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/SeiFlow-3P2/board_service/internal/models"
+	"github.com/google/uuid"
+)
+
+func TestPurgeUser(t *testing.T) {
+	ctx := context.Background()
+	f := newFixture(t)
+	purge := NewUserPurgeService(fakeBoardRepo{f.store}, fakeMemberRepo{f.store}, f.changes, testLogger)
+
+	secondID := uuid.New()
+	f.store.boards[secondID] = &models.Board{ID: secondID, Title: "second", User_id: ownerID}
+	f.store.tasks[f.taskID].In_Calendar = true
+
+	otherID := uuid.New()
+	f.store.boards[otherID] = &models.Board{ID: otherID, Title: "other", User_id: strangerID}
+	memberID := uuid.New()
+	f.store.members[memberID] = &models.BoardMember{ID: memberID, Board_id: otherID, User_id: ownerID, Role: models.RoleEditor}
+
+	var reports []PurgeProgress
+	if err := purge.PurgeUser(ctx, ownerID, func(p PurgeProgress) { reports = append(reports, p) }); err != nil {
+		t.Fatalf("PurgeUser(): %v", err)
+	}
+	if len(reports) != 2 || reports[1].Deleted != 2 || reports[1].Total != 2 {
+		t.Fatalf("expected progress for two boards, got %+v", reports)
+	}
+	if len(f.store.boards) != 1 || f.store.boards[otherID] == nil {
+		t.Fatalf("expected only the stranger's board to remain, got %d boards", len(f.store.boards))
+	}
+	if len(f.store.columns) != 0 || len(f.store.tasks) != 0 || len(f.store.members) != 0 {
+		t.Fatal("expected columns, tasks and memberships to be removed")
+	}
+	assertEvents(t, outboxEvents(t, f), f.taskID, models.EventTypeDelete)
+
+	// Running the purge again finds nothing left to do.
+	reports = nil
+	if err := purge.PurgeUser(ctx, ownerID, func(p PurgeProgress) { reports = append(reports, p) }); err != nil {
+		t.Fatalf("PurgeUser(): %v", err)
+	}
+	if len(reports) != 0 || len(f.store.boards) != 1 {
+		t.Fatalf("expected the second run to do nothing, got %+v", reports)
+	}
+
+	if err := purge.PurgeUser(ctx, "", nil); !errors.Is(err, ErrInvalidUserEvent) {
+		t.Fatalf("expected ErrInvalidUserEvent, got %v", err)
+	}
+}
@@ -0,0 +1,161 @@
+// Package watch delivers the changes made to a board to the clients that
+// watch it. The bus is in-process, so a watcher only sees the changes made
+// through the same instance of the service.
+package watch
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/SeiFlow-3P2/board_service/internal/models"
+	"github.com/google/uuid"
+)
+
+type ChangeType string
+
+const (
+	ColumnCreated ChangeType = "column_created"
+	ColumnRenamed ChangeType = "column_renamed"
+	ColumnMoved   ChangeType = "column_moved"
+	ColumnDeleted ChangeType = "column_deleted"
+	TaskCreated   ChangeType = "task_created"
+	TaskUpdated   ChangeType = "task_updated"
+	TaskMoved     ChangeType = "task_moved"
+	TaskDeleted   ChangeType = "task_deleted"
+	BoardDeleted  ChangeType = "board_deleted"
+	// TasksReranked replaces the moves of the tasks of a column whose ranks
+	// were respaced or that received the tasks of a deleted column. Column
+	// identifies the column, whose tasks should be reloaded.
+	TasksReranked ChangeType = "tasks_reranked"
+)
+
+// Change is a change to a board. Column is set for column changes and Task
+// for task changes.
+type Change struct {
+	Type       ChangeType
+	BoardID    uuid.UUID
+	UserID     string
+	Column     *models.Column
+	Task       *models.Task
+	OccurredAt time.Time
+}
+
+// subscriberBuffer is the number of changes a subscriber may lag behind
+// before it is dropped.
+const subscriberBuffer = 64
+
+// Err returns one of these after the channel of a subscription was closed
+// by the bus.
+var (
+	ErrFellBehind = errors.New("watcher fell behind")
+	ErrRevoked    = errors.New("watcher lost access to the board")
+)
+
+// Bus fans the changes of each board out to its subscribers. A nil *Bus
+// drops all changes.
+type Bus struct {
+	mu          sync.Mutex
+	subscribers map[uuid.UUID]map[*Subscription]struct{}
+}
+
+func NewBus() *Bus {
+	return &Bus{subscribers: map[uuid.UUID]map[*Subscription]struct{}{}}
+}
+
+// Subscription receives the changes of one board until it is closed.
+type Subscription struct {
+	bus     *Bus
+	boardID uuid.UUID
+	userID  string
+	changes chan Change
+	err     error
+}
+
+// Subscribe starts receiving the changes of the board for the user.
+func (b *Bus) Subscribe(boardID uuid.UUID, userID string) *Subscription {
+	sub := &Subscription{bus: b, boardID: boardID, userID: userID, changes: make(chan Change, subscriberBuffer)}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.subscribers[boardID] == nil {
+		b.subscribers[boardID] = map[*Subscription]struct{}{}
+	}
+	b.subscribers[boardID][sub] = struct{}{}
+	return sub
+}
+
+// Publish delivers the change to the subscribers of its board without
+// waiting for them. A subscriber whose buffer is full is dropped, which
+// closes its channel.
+func (b *Bus) Publish(change Change) {
+	if b == nil {
+		return
+	}
+	if change.OccurredAt.IsZero() {
+		change.OccurredAt = time.Now()
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for sub := range b.subscribers[change.BoardID] {
+		select {
+		case sub.changes <- change:
+		default:
+			b.remove(sub, ErrFellBehind)
+		}
+	}
+}
+
+// Revoke closes the subscriptions of the user to the board, for example
+// once the user is no longer a member of it.
+func (b *Bus) Revoke(boardID uuid.UUID, userID string) {
+	if b == nil {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for sub := range b.subscribers[boardID] {
+		if sub.userID == userID {
+			b.remove(sub, ErrRevoked)
+		}
+	}
+}
+
+func (b *Bus) remove(sub *Subscription, err error) {
+	subs, ok := b.subscribers[sub.boardID]
+	if !ok {
+		return
+	}
+	if _, ok := subs[sub]; !ok {
+		return
+	}
+	delete(subs, sub)
+	if len(subs) == 0 {
+		delete(b.subscribers, sub.boardID)
+	}
+	sub.err = err
+	close(sub.changes)
+}
+
+// Changes returns the channel of changes. It is closed when the
+// subscription is closed, has fallen behind or was revoked.
+func (s *Subscription) Changes() <-chan Change {
+	return s.changes
+}
+
+// Err tells why the bus closed the channel: ErrFellBehind or ErrRevoked. It
+// is nil while the subscription is open and after Close.
+func (s *Subscription) Err() error {
+	s.bus.mu.Lock()
+	defer s.bus.mu.Unlock()
+	return s.err
+}
+
+// Close stops the subscription. It is safe to call more than once.
+func (s *Subscription) Close() {
+	s.bus.mu.Lock()
+	defer s.bus.mu.Unlock()
+	s.bus.remove(s, nil)
+}
@@ -0,0 +1,81 @@
+package watch
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestBusDeliversChangesOfTheBoard(t *testing.T) {
+	bus := NewBus()
+	boardID := uuid.New()
+	sub := bus.Subscribe(boardID, "owner")
+	other := bus.Subscribe(uuid.New(), "owner")
+
+	bus.Publish(Change{Type: ColumnCreated, BoardID: boardID})
+
+	change := <-sub.Changes()
+	if change.Type != ColumnCreated || change.OccurredAt.IsZero() {
+		t.Fatalf("unexpected change %+v", change)
+	}
+	if len(other.Changes()) != 0 {
+		t.Fatal("expected no changes for the other board")
+	}
+
+	sub.Close()
+	sub.Close()
+	if _, ok := <-sub.Changes(); ok {
+		t.Fatal("expected the channel to be closed")
+	}
+	bus.Publish(Change{Type: ColumnCreated, BoardID: boardID})
+}
+
+func TestBusDropsSlowSubscribers(t *testing.T) {
+	bus := NewBus()
+	boardID := uuid.New()
+	sub := bus.Subscribe(boardID, "owner")
+
+	for i := 0; i <= subscriberBuffer; i++ {
+		bus.Publish(Change{Type: TaskUpdated, BoardID: boardID})
+	}
+
+	received := 0
+	for range sub.Changes() {
+		received++
+	}
+	if received != subscriberBuffer {
+		t.Fatalf("expected %d buffered changes before the channel closed, got %d", subscriberBuffer, received)
+	}
+	if sub.Err() != ErrFellBehind {
+		t.Fatalf("expected ErrFellBehind, got %v", sub.Err())
+	}
+	sub.Close()
+
+	var nilBus *Bus
+	nilBus.Publish(Change{Type: TaskUpdated, BoardID: boardID})
+}
+
+func TestBusRevokesSubscriptionsOfAUser(t *testing.T) {
+	bus := NewBus()
+	boardID := uuid.New()
+	member, owner := bus.Subscribe(boardID, "member"), bus.Subscribe(boardID, "owner")
+	elsewhere := bus.Subscribe(uuid.New(), "member")
+
+	bus.Revoke(boardID, "member")
+	if _, ok := <-member.Changes(); ok || member.Err() != ErrRevoked {
+		t.Fatalf("expected the member's subscription to be revoked, got %v", member.Err())
+	}
+
+	bus.Publish(Change{Type: ColumnCreated, BoardID: boardID})
+	if len(owner.Changes()) != 1 || owner.Err() != nil {
+		t.Fatal("expected the owner to keep watching")
+	}
+	if elsewhere.Err() != nil {
+		t.Fatal("expected the subscription to another board to stay open")
+	}
+
+	owner.Close()
+	if owner.Err() != nil {
+		t.Fatalf("expected no error after Close, got %v", owner.Err())
+	}
+}
@@ -2,8 +2,10 @@ package env
 
 import (
 	"fmt"
+	"log/slog"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -25,10 +27,22 @@ func LoadEnv() error {
 	}
 
 	kafkaBrokers := os.Getenv("KAFKA_BROKERS")
-	if kafkaBrokers == "" {
+	if kafkaBrokers == "" && GetEventPublisher() == "kafka" {
 		return fmt.Errorf("KAFKA_BROKERS is not set")
 	}
 
+	if ttl := os.Getenv("CACHE_TTL"); ttl != "" {
+		if _, err := time.ParseDuration(ttl); err != nil {
+			return fmt.Errorf("CACHE_TTL is not a duration: %v", err)
+		}
+	}
+
+	if level := os.Getenv("LOG_LEVEL"); level != "" {
+		if err := new(slog.Level).UnmarshalText([]byte(level)); err != nil {
+			return fmt.Errorf("LOG_LEVEL is not a level: %v", err)
+		}
+	}
+
 	otelEndpoint := os.Getenv("OTEL_ADDR")
 	if otelEndpoint == "" {
 		return fmt.Errorf("OTEL_ADDR is not set")
@@ -49,6 +63,16 @@ func GetPort() string {
 	return GetEnvDefault("PORT", "8090")
 }
 
+func GetHTTPPort() string {
+	return GetEnvDefault("HTTP_PORT", "8080")
+}
+
+// GetMetricsPort returns the port of the Prometheus /metrics endpoint, which
+// is kept off the public HTTP gateway.
+func GetMetricsPort() string {
+	return GetEnvDefault("METRICS_PORT", "9090")
+}
+
 func GetAppName() string {
 	return GetEnvDefault("APP_NAME", "board")
 }
@@ -57,6 +81,87 @@ func GetKafkaBrokers() []string {
 	return strings.Split(os.Getenv("KAFKA_BROKERS"), ",")
 }
 
+// GetEventPublisher returns kafka, memory or noop. The last two run the
+// service without Kafka.
+func GetEventPublisher() string {
+	return GetEnvDefault("EVENT_PUBLISHER", "kafka")
+}
+
+func GetKafkaGroupID() string {
+	return GetEnvDefault("KAFKA_GROUP_ID", "board_service")
+}
+
+func GetCalendarTopic() string {
+	return GetEnvDefault("CALENDAR_TOPIC", "calendar.event")
+}
+
+func GetUserDeletedTopic() string {
+	return GetEnvDefault("USER_DELETED_TOPIC", "user.deleted")
+}
+
+// GetInterceptors returns the gRPC interceptors in the order they run.
+func GetInterceptors() []string {
+	return strings.Split(GetEnvDefault("GRPC_INTERCEPTORS", "request_id,otel,metrics,logging,recovery,auth"), ",")
+}
+
+// GetAuthMode returns trusted_gateway, which trusts the x-user-id header, or
+// jwt, which verifies bearer tokens with the JWT_* settings.
+func GetAuthMode() string {
+	return GetEnvDefault("AUTH_MODE", "trusted_gateway")
+}
+
+func GetJWTHMACSecret() string {
+	return os.Getenv("JWT_HMAC_SECRET")
+}
+
+func GetJWTJWKSFile() string {
+	return os.Getenv("JWT_JWKS_FILE")
+}
+
+func GetJWTJWKSURL() string {
+	return os.Getenv("JWT_JWKS_URL")
+}
+
+func GetJWTIssuer() string {
+	return os.Getenv("JWT_ISSUER")
+}
+
+func GetJWTAudience() string {
+	return os.Getenv("JWT_AUDIENCE")
+}
+
+// GetRedisAddr returns the address of the Redis server that caches boards,
+// or an empty string if REDIS_HOST is not set and the cache is disabled.
+func GetRedisAddr() string {
+	host := os.Getenv("REDIS_HOST")
+	if host == "" {
+		return ""
+	}
+	return host + ":" + GetEnvDefault("REDIS_PORT", "6379")
+}
+
+func GetRedisPassword() string {
+	return os.Getenv("REDIS_PASSWORD")
+}
+
+// GetCacheTTL returns how long a cached board may be served, 1m by default.
+func GetCacheTTL() time.Duration {
+	ttl, err := time.ParseDuration(GetEnvDefault("CACHE_TTL", "1m"))
+	if err != nil {
+		return time.Minute
+	}
+	return ttl
+}
+
+// GetLogLevel returns the minimum level of logged records, info by default.
+func GetLogLevel() slog.Level {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(GetEnvDefault("LOG_LEVEL", "info"))); err != nil {
+		return slog.LevelInfo
+	}
+	return level
+}
+
 func GetOtelEndpoint() string {
 	return os.Getenv("OTEL_ADDR")
 }
@@ -25,6 +25,241 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type BoardChangeType int32
+
+const (
+	BoardChangeType_BOARD_CHANGE_TYPE_UNSPECIFIED    BoardChangeType = 0
+	BoardChangeType_BOARD_CHANGE_TYPE_COLUMN_CREATED BoardChangeType = 1
+	BoardChangeType_BOARD_CHANGE_TYPE_COLUMN_RENAMED BoardChangeType = 2
+	// The other columns between the old and the new position are shifted by
+	// one.
+	BoardChangeType_BOARD_CHANGE_TYPE_COLUMN_MOVED BoardChangeType = 3
+	// Sent after the TASKS_RERANKED of the column that received its tasks,
+	// if any.
+	BoardChangeType_BOARD_CHANGE_TYPE_COLUMN_DELETED BoardChangeType = 4
+	BoardChangeType_BOARD_CHANGE_TYPE_TASK_CREATED   BoardChangeType = 5
+	BoardChangeType_BOARD_CHANGE_TYPE_TASK_UPDATED   BoardChangeType = 6
+	BoardChangeType_BOARD_CHANGE_TYPE_TASK_MOVED     BoardChangeType = 7
+	BoardChangeType_BOARD_CHANGE_TYPE_TASK_DELETED   BoardChangeType = 8
+	// The last change of the stream.
+	BoardChangeType_BOARD_CHANGE_TYPE_BOARD_DELETED BoardChangeType = 9
+	// The ranks of many tasks of the column changed at once, because they
+	// were respaced or the tasks of a deleted column were handed over to it.
+	// Reload the tasks of the column.
+	BoardChangeType_BOARD_CHANGE_TYPE_TASKS_RERANKED BoardChangeType = 10
+)
+
+// Enum value maps for BoardChangeType.
+var (
+	BoardChangeType_name = map[int32]string{
+		0:  "BOARD_CHANGE_TYPE_UNSPECIFIED",
+		1:  "BOARD_CHANGE_TYPE_COLUMN_CREATED",
+		2:  "BOARD_CHANGE_TYPE_COLUMN_RENAMED",
+		3:  "BOARD_CHANGE_TYPE_COLUMN_MOVED",
+		4:  "BOARD_CHANGE_TYPE_COLUMN_DELETED",
+		5:  "BOARD_CHANGE_TYPE_TASK_CREATED",
+		6:  "BOARD_CHANGE_TYPE_TASK_UPDATED",
+		7:  "BOARD_CHANGE_TYPE_TASK_MOVED",
+		8:  "BOARD_CHANGE_TYPE_TASK_DELETED",
+		9:  "BOARD_CHANGE_TYPE_BOARD_DELETED",
+		10: "BOARD_CHANGE_TYPE_TASKS_RERANKED",
+	}
+	BoardChangeType_value = map[string]int32{
+		"BOARD_CHANGE_TYPE_UNSPECIFIED":    0,
+		"BOARD_CHANGE_TYPE_COLUMN_CREATED": 1,
+		"BOARD_CHANGE_TYPE_COLUMN_RENAMED": 2,
+		"BOARD_CHANGE_TYPE_COLUMN_MOVED":   3,
+		"BOARD_CHANGE_TYPE_COLUMN_DELETED": 4,
+		"BOARD_CHANGE_TYPE_TASK_CREATED":   5,
+		"BOARD_CHANGE_TYPE_TASK_UPDATED":   6,
+		"BOARD_CHANGE_TYPE_TASK_MOVED":     7,
+		"BOARD_CHANGE_TYPE_TASK_DELETED":   8,
+		"BOARD_CHANGE_TYPE_BOARD_DELETED":  9,
+		"BOARD_CHANGE_TYPE_TASKS_RERANKED": 10,
+	}
+)
+
+func (x BoardChangeType) Enum() *BoardChangeType {
+	p := new(BoardChangeType)
+	*p = x
+	return p
+}
+
+func (x BoardChangeType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (BoardChangeType) Descriptor() protoreflect.EnumDescriptor {
+	return file_board_proto_enumTypes[0].Descriptor()
+}
+
+func (BoardChangeType) Type() protoreflect.EnumType {
+	return &file_board_proto_enumTypes[0]
+}
+
+func (x BoardChangeType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use BoardChangeType.Descriptor instead.
+func (BoardChangeType) EnumDescriptor() ([]byte, []int) {
+	return file_board_proto_rawDescGZIP(), []int{0}
+}
+
+type BoardSortField int32
+
+const (
+	// Same as BOARD_SORT_FIELD_UPDATED_AT.
+	BoardSortField_BOARD_SORT_FIELD_UNSPECIFIED BoardSortField = 0
+	BoardSortField_BOARD_SORT_FIELD_UPDATED_AT  BoardSortField = 1
+	BoardSortField_BOARD_SORT_FIELD_CREATED_AT  BoardSortField = 2
+	BoardSortField_BOARD_SORT_FIELD_TITLE       BoardSortField = 3
+)
+
+// Enum value maps for BoardSortField.
+var (
+	BoardSortField_name = map[int32]string{
+		0: "BOARD_SORT_FIELD_UNSPECIFIED",
+		1: "BOARD_SORT_FIELD_UPDATED_AT",
+		2: "BOARD_SORT_FIELD_CREATED_AT",
+		3: "BOARD_SORT_FIELD_TITLE",
+	}
+	BoardSortField_value = map[string]int32{
+		"BOARD_SORT_FIELD_UNSPECIFIED": 0,
+		"BOARD_SORT_FIELD_UPDATED_AT":  1,
+		"BOARD_SORT_FIELD_CREATED_AT":  2,
+		"BOARD_SORT_FIELD_TITLE":       3,
+	}
+)
+
+func (x BoardSortField) Enum() *BoardSortField {
+	p := new(BoardSortField)
+	*p = x
+	return p
+}
+
+func (x BoardSortField) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (BoardSortField) Descriptor() protoreflect.EnumDescriptor {
+	return file_board_proto_enumTypes[1].Descriptor()
+}
+
+func (BoardSortField) Type() protoreflect.EnumType {
+	return &file_board_proto_enumTypes[1]
+}
+
+func (x BoardSortField) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use BoardSortField.Descriptor instead.
+func (BoardSortField) EnumDescriptor() ([]byte, []int) {
+	return file_board_proto_rawDescGZIP(), []int{1}
+}
+
+type SortOrder int32
+
+const (
+	// Descending for timestamps, ascending for titles.
+	SortOrder_SORT_ORDER_UNSPECIFIED SortOrder = 0
+	SortOrder_SORT_ORDER_ASC         SortOrder = 1
+	SortOrder_SORT_ORDER_DESC        SortOrder = 2
+)
+
+// Enum value maps for SortOrder.
+var (
+	SortOrder_name = map[int32]string{
+		0: "SORT_ORDER_UNSPECIFIED",
+		1: "SORT_ORDER_ASC",
+		2: "SORT_ORDER_DESC",
+	}
+	SortOrder_value = map[string]int32{
+		"SORT_ORDER_UNSPECIFIED": 0,
+		"SORT_ORDER_ASC":         1,
+		"SORT_ORDER_DESC":        2,
+	}
+)
+
+func (x SortOrder) Enum() *SortOrder {
+	p := new(SortOrder)
+	*p = x
+	return p
+}
+
+func (x SortOrder) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SortOrder) Descriptor() protoreflect.EnumDescriptor {
+	return file_board_proto_enumTypes[2].Descriptor()
+}
+
+func (SortOrder) Type() protoreflect.EnumType {
+	return &file_board_proto_enumTypes[2]
+}
+
+func (x SortOrder) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SortOrder.Descriptor instead.
+func (SortOrder) EnumDescriptor() ([]byte, []int) {
+	return file_board_proto_rawDescGZIP(), []int{2}
+}
+
+type DeleteColumnMode int32
+
+const (
+	// Same as DELETE_COLUMN_MODE_DELETE_TASKS.
+	DeleteColumnMode_DELETE_COLUMN_MODE_UNSPECIFIED  DeleteColumnMode = 0
+	DeleteColumnMode_DELETE_COLUMN_MODE_DELETE_TASKS DeleteColumnMode = 1
+	// Moves the tasks to the end of target_column_id.
+	DeleteColumnMode_DELETE_COLUMN_MODE_MOVE_TASKS DeleteColumnMode = 2
+)
+
+// Enum value maps for DeleteColumnMode.
+var (
+	DeleteColumnMode_name = map[int32]string{
+		0: "DELETE_COLUMN_MODE_UNSPECIFIED",
+		1: "DELETE_COLUMN_MODE_DELETE_TASKS",
+		2: "DELETE_COLUMN_MODE_MOVE_TASKS",
+	}
+	DeleteColumnMode_value = map[string]int32{
+		"DELETE_COLUMN_MODE_UNSPECIFIED":  0,
+		"DELETE_COLUMN_MODE_DELETE_TASKS": 1,
+		"DELETE_COLUMN_MODE_MOVE_TASKS":   2,
+	}
+)
+
+func (x DeleteColumnMode) Enum() *DeleteColumnMode {
+	p := new(DeleteColumnMode)
+	*p = x
+	return p
+}
+
+func (x DeleteColumnMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DeleteColumnMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_board_proto_enumTypes[3].Descriptor()
+}
+
+func (DeleteColumnMode) Type() protoreflect.EnumType {
+	return &file_board_proto_enumTypes[3]
+}
+
+func (x DeleteColumnMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DeleteColumnMode.Descriptor instead.
+func (DeleteColumnMode) EnumDescriptor() ([]byte, []int) {
+	return file_board_proto_rawDescGZIP(), []int{3}
+}
+
 type CreateBoardRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
@@ -194,8 +429,10 @@ func (x *BoardResponse) GetUpdatedAt() *timestamppb.Timestamp {
 }
 
 type BoardsListResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Boards        []*BoardResponse       `protobuf:"bytes,1,rep,name=boards,proto3" json:"boards,omitempty"`
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Boards []*BoardResponse       `protobuf:"bytes,1,rep,name=boards,proto3" json:"boards,omitempty"`
+	// Empty on the last page.
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -237,8 +474,25 @@ func (x *BoardsListResponse) GetBoards() []*BoardResponse {
 	return nil
 }
 
+func (x *BoardsListResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
 type GetBoardsRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Defaults to 20, at most 100.
+	PageSize int32 `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// next_page_token of the previous response. The sorting must not change
+	// between pages.
+	PageToken     string         `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	Category      string         `protobuf:"bytes,3,opt,name=category,proto3" json:"category,omitempty"`
+	Methodology   string         `protobuf:"bytes,4,opt,name=methodology,proto3" json:"methodology,omitempty"`
+	Favorite      *bool          `protobuf:"varint,5,opt,name=favorite,proto3,oneof" json:"favorite,omitempty"`
+	SortBy        BoardSortField `protobuf:"varint,6,opt,name=sort_by,json=sortBy,proto3,enum=board_v1.BoardSortField" json:"sort_by,omitempty"`
+	SortOrder     SortOrder      `protobuf:"varint,7,opt,name=sort_order,json=sortOrder,proto3,enum=board_v1.SortOrder" json:"sort_order,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -273,6 +527,55 @@ func (*GetBoardsRequest) Descriptor() ([]byte, []int) {
 	return file_board_proto_rawDescGZIP(), []int{3}
 }
 
+func (x *GetBoardsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *GetBoardsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *GetBoardsRequest) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *GetBoardsRequest) GetMethodology() string {
+	if x != nil {
+		return x.Methodology
+	}
+	return ""
+}
+
+func (x *GetBoardsRequest) GetFavorite() bool {
+	if x != nil && x.Favorite != nil {
+		return *x.Favorite
+	}
+	return false
+}
+
+func (x *GetBoardsRequest) GetSortBy() BoardSortField {
+	if x != nil {
+		return x.SortBy
+	}
+	return BoardSortField_BOARD_SORT_FIELD_UNSPECIFIED
+}
+
+func (x *GetBoardsRequest) GetSortOrder() SortOrder {
+	if x != nil {
+		return x.SortOrder
+	}
+	return SortOrder_SORT_ORDER_UNSPECIFIED
+}
+
 type GetBoardInfoRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -325,6 +628,7 @@ type TaskInfo struct {
 	Deadline      string                 `protobuf:"bytes,4,opt,name=deadline,proto3" json:"deadline,omitempty"`
 	InCalendar    bool                   `protobuf:"varint,5,opt,name=in_calendar,json=inCalendar,proto3" json:"in_calendar,omitempty"`
 	ColumnId      string                 `protobuf:"bytes,6,opt,name=column_id,json=columnId,proto3" json:"column_id,omitempty"`
+	Rank          string                 `protobuf:"bytes,7,opt,name=rank,proto3" json:"rank,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -401,6 +705,13 @@ func (x *TaskInfo) GetColumnId() string {
 	return ""
 }
 
+func (x *TaskInfo) GetRank() string {
+	if x != nil {
+		return x.Rank
+	}
+	return ""
+}
+
 type ColumnInfo struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -773,28 +1084,27 @@ func (x *DeleteBoardRequest) GetId() string {
 	return ""
 }
 
-type CreateColumnRequest struct {
+type WatchBoardRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	BoardId       string                 `protobuf:"bytes,2,opt,name=board_id,json=boardId,proto3" json:"board_id,omitempty"`
+	BoardId       string                 `protobuf:"bytes,1,opt,name=board_id,json=boardId,proto3" json:"board_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateColumnRequest) Reset() {
-	*x = CreateColumnRequest{}
+func (x *WatchBoardRequest) Reset() {
+	*x = WatchBoardRequest{}
 	mi := &file_board_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateColumnRequest) String() string {
+func (x *WatchBoardRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateColumnRequest) ProtoMessage() {}
+func (*WatchBoardRequest) ProtoMessage() {}
 
-func (x *CreateColumnRequest) ProtoReflect() protoreflect.Message {
+func (x *WatchBoardRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_board_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -806,49 +1116,51 @@ func (x *CreateColumnRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateColumnRequest.ProtoReflect.Descriptor instead.
-func (*CreateColumnRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use WatchBoardRequest.ProtoReflect.Descriptor instead.
+func (*WatchBoardRequest) Descriptor() ([]byte, []int) {
 	return file_board_proto_rawDescGZIP(), []int{11}
 }
 
-func (x *CreateColumnRequest) GetName() string {
-	if x != nil {
-		return x.Name
-	}
-	return ""
-}
-
-func (x *CreateColumnRequest) GetBoardId() string {
+func (x *WatchBoardRequest) GetBoardId() string {
 	if x != nil {
 		return x.BoardId
 	}
 	return ""
 }
 
-type ColumnResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	BoardId       string                 `protobuf:"bytes,3,opt,name=board_id,json=boardId,proto3" json:"board_id,omitempty"`
-	OrderNumber   int64                  `protobuf:"varint,4,opt,name=order_number,json=orderNumber,proto3" json:"order_number,omitempty"`
+// A change to a board. Deletions carry only the IDs of the subject. A stream
+// that falls too far behind is closed with ABORTED; the client should reload
+// the board and watch again.
+type BoardChange struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Type    BoardChangeType        `protobuf:"varint,1,opt,name=type,proto3,enum=board_v1.BoardChangeType" json:"type,omitempty"`
+	BoardId string                 `protobuf:"bytes,2,opt,name=board_id,json=boardId,proto3" json:"board_id,omitempty"`
+	// The user who made the change.
+	UserId     string                 `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	OccurredAt *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
+	// Types that are valid to be assigned to Subject:
+	//
+	//	*BoardChange_Column
+	//	*BoardChange_Task
+	Subject       isBoardChange_Subject `protobuf_oneof:"subject"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ColumnResponse) Reset() {
-	*x = ColumnResponse{}
+func (x *BoardChange) Reset() {
+	*x = BoardChange{}
 	mi := &file_board_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ColumnResponse) String() string {
+func (x *BoardChange) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ColumnResponse) ProtoMessage() {}
+func (*BoardChange) ProtoMessage() {}
 
-func (x *ColumnResponse) ProtoReflect() protoreflect.Message {
+func (x *BoardChange) ProtoReflect() protoreflect.Message {
 	mi := &file_board_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -860,60 +1172,105 @@ func (x *ColumnResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ColumnResponse.ProtoReflect.Descriptor instead.
-func (*ColumnResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use BoardChange.ProtoReflect.Descriptor instead.
+func (*BoardChange) Descriptor() ([]byte, []int) {
 	return file_board_proto_rawDescGZIP(), []int{12}
 }
 
-func (x *ColumnResponse) GetId() string {
+func (x *BoardChange) GetType() BoardChangeType {
 	if x != nil {
-		return x.Id
+		return x.Type
 	}
-	return ""
+	return BoardChangeType_BOARD_CHANGE_TYPE_UNSPECIFIED
 }
 
-func (x *ColumnResponse) GetName() string {
+func (x *BoardChange) GetBoardId() string {
 	if x != nil {
-		return x.Name
+		return x.BoardId
 	}
 	return ""
 }
 
-func (x *ColumnResponse) GetBoardId() string {
+func (x *BoardChange) GetUserId() string {
 	if x != nil {
-		return x.BoardId
+		return x.UserId
 	}
 	return ""
 }
 
-func (x *ColumnResponse) GetOrderNumber() int64 {
+func (x *BoardChange) GetOccurredAt() *timestamppb.Timestamp {
 	if x != nil {
-		return x.OrderNumber
+		return x.OccurredAt
 	}
-	return 0
+	return nil
 }
 
-type DeleteColumnRequest struct {
+func (x *BoardChange) GetSubject() isBoardChange_Subject {
+	if x != nil {
+		return x.Subject
+	}
+	return nil
+}
+
+func (x *BoardChange) GetColumn() *ColumnResponse {
+	if x != nil {
+		if x, ok := x.Subject.(*BoardChange_Column); ok {
+			return x.Column
+		}
+	}
+	return nil
+}
+
+func (x *BoardChange) GetTask() *TaskResponse {
+	if x != nil {
+		if x, ok := x.Subject.(*BoardChange_Task); ok {
+			return x.Task
+		}
+	}
+	return nil
+}
+
+type isBoardChange_Subject interface {
+	isBoardChange_Subject()
+}
+
+type BoardChange_Column struct {
+	Column *ColumnResponse `protobuf:"bytes,5,opt,name=column,proto3,oneof"`
+}
+
+type BoardChange_Task struct {
+	Task *TaskResponse `protobuf:"bytes,6,opt,name=task,proto3,oneof"`
+}
+
+func (*BoardChange_Column) isBoardChange_Subject() {}
+
+func (*BoardChange_Task) isBoardChange_Subject() {}
+
+// Roles are "owner", "editor" and "viewer". Only editor and viewer can be
+// assigned, the owner is the user who created the board.
+type AddBoardMemberRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	BoardId       string                 `protobuf:"bytes,1,opt,name=board_id,json=boardId,proto3" json:"board_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Role          string                 `protobuf:"bytes,3,opt,name=role,proto3" json:"role,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteColumnRequest) Reset() {
-	*x = DeleteColumnRequest{}
+func (x *AddBoardMemberRequest) Reset() {
+	*x = AddBoardMemberRequest{}
 	mi := &file_board_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteColumnRequest) String() string {
+func (x *AddBoardMemberRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteColumnRequest) ProtoMessage() {}
+func (*AddBoardMemberRequest) ProtoMessage() {}
 
-func (x *DeleteColumnRequest) ProtoReflect() protoreflect.Message {
+func (x *AddBoardMemberRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_board_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -925,40 +1282,54 @@ func (x *DeleteColumnRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteColumnRequest.ProtoReflect.Descriptor instead.
-func (*DeleteColumnRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use AddBoardMemberRequest.ProtoReflect.Descriptor instead.
+func (*AddBoardMemberRequest) Descriptor() ([]byte, []int) {
 	return file_board_proto_rawDescGZIP(), []int{13}
 }
 
-func (x *DeleteColumnRequest) GetId() string {
+func (x *AddBoardMemberRequest) GetBoardId() string {
 	if x != nil {
-		return x.Id
+		return x.BoardId
 	}
 	return ""
 }
 
-type UpdateColumnRequest struct {
-	state         protoimpl.MessageState  `protogen:"open.v1"`
-	Id            string                  `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name          *wrapperspb.StringValue `protobuf:"bytes,2,opt,name=name,proto3,oneof" json:"name,omitempty"`
+func (x *AddBoardMemberRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *AddBoardMemberRequest) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+type RemoveBoardMemberRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BoardId       string                 `protobuf:"bytes,1,opt,name=board_id,json=boardId,proto3" json:"board_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateColumnRequest) Reset() {
-	*x = UpdateColumnRequest{}
+func (x *RemoveBoardMemberRequest) Reset() {
+	*x = RemoveBoardMemberRequest{}
 	mi := &file_board_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateColumnRequest) String() string {
+func (x *RemoveBoardMemberRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateColumnRequest) ProtoMessage() {}
+func (*RemoveBoardMemberRequest) ProtoMessage() {}
 
-func (x *UpdateColumnRequest) ProtoReflect() protoreflect.Message {
+func (x *RemoveBoardMemberRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_board_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -970,50 +1341,46 @@ func (x *UpdateColumnRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateColumnRequest.ProtoReflect.Descriptor instead.
-func (*UpdateColumnRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use RemoveBoardMemberRequest.ProtoReflect.Descriptor instead.
+func (*RemoveBoardMemberRequest) Descriptor() ([]byte, []int) {
 	return file_board_proto_rawDescGZIP(), []int{14}
 }
 
-func (x *UpdateColumnRequest) GetId() string {
+func (x *RemoveBoardMemberRequest) GetBoardId() string {
 	if x != nil {
-		return x.Id
+		return x.BoardId
 	}
 	return ""
 }
 
-func (x *UpdateColumnRequest) GetName() *wrapperspb.StringValue {
+func (x *RemoveBoardMemberRequest) GetUserId() string {
 	if x != nil {
-		return x.Name
+		return x.UserId
 	}
-	return nil
+	return ""
 }
 
-type CreateTaskRequest struct {
+type ListBoardMembersRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Description   string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
-	Deadline      string                 `protobuf:"bytes,3,opt,name=deadline,proto3" json:"deadline,omitempty"`
-	InCalendar    bool                   `protobuf:"varint,4,opt,name=in_calendar,json=inCalendar,proto3" json:"in_calendar,omitempty"`
-	ColumnId      string                 `protobuf:"bytes,5,opt,name=column_id,json=columnId,proto3" json:"column_id,omitempty"`
+	BoardId       string                 `protobuf:"bytes,1,opt,name=board_id,json=boardId,proto3" json:"board_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateTaskRequest) Reset() {
-	*x = CreateTaskRequest{}
+func (x *ListBoardMembersRequest) Reset() {
+	*x = ListBoardMembersRequest{}
 	mi := &file_board_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateTaskRequest) String() string {
+func (x *ListBoardMembersRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateTaskRequest) ProtoMessage() {}
+func (*ListBoardMembersRequest) ProtoMessage() {}
 
-func (x *CreateTaskRequest) ProtoReflect() protoreflect.Message {
+func (x *ListBoardMembersRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_board_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1025,9 +1392,515 @@ func (x *CreateTaskRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateTaskRequest.ProtoReflect.Descriptor instead.
+// Deprecated: Use ListBoardMembersRequest.ProtoReflect.Descriptor instead.
+func (*ListBoardMembersRequest) Descriptor() ([]byte, []int) {
+	return file_board_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ListBoardMembersRequest) GetBoardId() string {
+	if x != nil {
+		return x.BoardId
+	}
+	return ""
+}
+
+type UpdateMemberRoleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BoardId       string                 `protobuf:"bytes,1,opt,name=board_id,json=boardId,proto3" json:"board_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Role          string                 `protobuf:"bytes,3,opt,name=role,proto3" json:"role,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateMemberRoleRequest) Reset() {
+	*x = UpdateMemberRoleRequest{}
+	mi := &file_board_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateMemberRoleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateMemberRoleRequest) ProtoMessage() {}
+
+func (x *UpdateMemberRoleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_board_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateMemberRoleRequest.ProtoReflect.Descriptor instead.
+func (*UpdateMemberRoleRequest) Descriptor() ([]byte, []int) {
+	return file_board_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *UpdateMemberRoleRequest) GetBoardId() string {
+	if x != nil {
+		return x.BoardId
+	}
+	return ""
+}
+
+func (x *UpdateMemberRoleRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *UpdateMemberRoleRequest) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+type BoardMemberResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BoardId       string                 `protobuf:"bytes,1,opt,name=board_id,json=boardId,proto3" json:"board_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Role          string                 `protobuf:"bytes,3,opt,name=role,proto3" json:"role,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BoardMemberResponse) Reset() {
+	*x = BoardMemberResponse{}
+	mi := &file_board_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BoardMemberResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BoardMemberResponse) ProtoMessage() {}
+
+func (x *BoardMemberResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_board_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BoardMemberResponse.ProtoReflect.Descriptor instead.
+func (*BoardMemberResponse) Descriptor() ([]byte, []int) {
+	return file_board_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *BoardMemberResponse) GetBoardId() string {
+	if x != nil {
+		return x.BoardId
+	}
+	return ""
+}
+
+func (x *BoardMemberResponse) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *BoardMemberResponse) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *BoardMemberResponse) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type BoardMembersListResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Members       []*BoardMemberResponse `protobuf:"bytes,1,rep,name=members,proto3" json:"members,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BoardMembersListResponse) Reset() {
+	*x = BoardMembersListResponse{}
+	mi := &file_board_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BoardMembersListResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BoardMembersListResponse) ProtoMessage() {}
+
+func (x *BoardMembersListResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_board_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BoardMembersListResponse.ProtoReflect.Descriptor instead.
+func (*BoardMembersListResponse) Descriptor() ([]byte, []int) {
+	return file_board_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *BoardMembersListResponse) GetMembers() []*BoardMemberResponse {
+	if x != nil {
+		return x.Members
+	}
+	return nil
+}
+
+type CreateColumnRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	BoardId       string                 `protobuf:"bytes,2,opt,name=board_id,json=boardId,proto3" json:"board_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateColumnRequest) Reset() {
+	*x = CreateColumnRequest{}
+	mi := &file_board_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateColumnRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateColumnRequest) ProtoMessage() {}
+
+func (x *CreateColumnRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_board_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateColumnRequest.ProtoReflect.Descriptor instead.
+func (*CreateColumnRequest) Descriptor() ([]byte, []int) {
+	return file_board_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *CreateColumnRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateColumnRequest) GetBoardId() string {
+	if x != nil {
+		return x.BoardId
+	}
+	return ""
+}
+
+type ColumnResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	BoardId       string                 `protobuf:"bytes,3,opt,name=board_id,json=boardId,proto3" json:"board_id,omitempty"`
+	OrderNumber   int64                  `protobuf:"varint,4,opt,name=order_number,json=orderNumber,proto3" json:"order_number,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ColumnResponse) Reset() {
+	*x = ColumnResponse{}
+	mi := &file_board_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ColumnResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ColumnResponse) ProtoMessage() {}
+
+func (x *ColumnResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_board_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ColumnResponse.ProtoReflect.Descriptor instead.
+func (*ColumnResponse) Descriptor() ([]byte, []int) {
+	return file_board_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ColumnResponse) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ColumnResponse) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ColumnResponse) GetBoardId() string {
+	if x != nil {
+		return x.BoardId
+	}
+	return ""
+}
+
+func (x *ColumnResponse) GetOrderNumber() int64 {
+	if x != nil {
+		return x.OrderNumber
+	}
+	return 0
+}
+
+type DeleteColumnRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Mode           DeleteColumnMode       `protobuf:"varint,2,opt,name=mode,proto3,enum=board_v1.DeleteColumnMode" json:"mode,omitempty"`
+	TargetColumnId string                 `protobuf:"bytes,3,opt,name=target_column_id,json=targetColumnId,proto3" json:"target_column_id,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *DeleteColumnRequest) Reset() {
+	*x = DeleteColumnRequest{}
+	mi := &file_board_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteColumnRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteColumnRequest) ProtoMessage() {}
+
+func (x *DeleteColumnRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_board_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteColumnRequest.ProtoReflect.Descriptor instead.
+func (*DeleteColumnRequest) Descriptor() ([]byte, []int) {
+	return file_board_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *DeleteColumnRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *DeleteColumnRequest) GetMode() DeleteColumnMode {
+	if x != nil {
+		return x.Mode
+	}
+	return DeleteColumnMode_DELETE_COLUMN_MODE_UNSPECIFIED
+}
+
+func (x *DeleteColumnRequest) GetTargetColumnId() string {
+	if x != nil {
+		return x.TargetColumnId
+	}
+	return ""
+}
+
+type UpdateColumnRequest struct {
+	state         protoimpl.MessageState  `protogen:"open.v1"`
+	Id            string                  `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          *wrapperspb.StringValue `protobuf:"bytes,2,opt,name=name,proto3,oneof" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateColumnRequest) Reset() {
+	*x = UpdateColumnRequest{}
+	mi := &file_board_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateColumnRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateColumnRequest) ProtoMessage() {}
+
+func (x *UpdateColumnRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_board_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateColumnRequest.ProtoReflect.Descriptor instead.
+func (*UpdateColumnRequest) Descriptor() ([]byte, []int) {
+	return file_board_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *UpdateColumnRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateColumnRequest) GetName() *wrapperspb.StringValue {
+	if x != nil {
+		return x.Name
+	}
+	return nil
+}
+
+// Order numbers start at 1. The columns between the old and the new position
+// are shifted by one.
+type MoveColumnRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	NewOrderNumber int64                  `protobuf:"varint,2,opt,name=new_order_number,json=newOrderNumber,proto3" json:"new_order_number,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *MoveColumnRequest) Reset() {
+	*x = MoveColumnRequest{}
+	mi := &file_board_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MoveColumnRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MoveColumnRequest) ProtoMessage() {}
+
+func (x *MoveColumnRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_board_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MoveColumnRequest.ProtoReflect.Descriptor instead.
+func (*MoveColumnRequest) Descriptor() ([]byte, []int) {
+	return file_board_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *MoveColumnRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *MoveColumnRequest) GetNewOrderNumber() int64 {
+	if x != nil {
+		return x.NewOrderNumber
+	}
+	return 0
+}
+
+type CreateTaskRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Description   string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Deadline      string                 `protobuf:"bytes,3,opt,name=deadline,proto3" json:"deadline,omitempty"`
+	InCalendar    bool                   `protobuf:"varint,4,opt,name=in_calendar,json=inCalendar,proto3" json:"in_calendar,omitempty"`
+	ColumnId      string                 `protobuf:"bytes,5,opt,name=column_id,json=columnId,proto3" json:"column_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateTaskRequest) Reset() {
+	*x = CreateTaskRequest{}
+	mi := &file_board_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTaskRequest) ProtoMessage() {}
+
+func (x *CreateTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_board_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTaskRequest.ProtoReflect.Descriptor instead.
 func (*CreateTaskRequest) Descriptor() ([]byte, []int) {
-	return file_board_proto_rawDescGZIP(), []int{15}
+	return file_board_proto_rawDescGZIP(), []int{24}
 }
 
 func (x *CreateTaskRequest) GetName() string {
@@ -1073,13 +1946,14 @@ type TaskResponse struct {
 	Deadline      string                 `protobuf:"bytes,4,opt,name=deadline,proto3" json:"deadline,omitempty"`
 	InCalendar    bool                   `protobuf:"varint,5,opt,name=in_calendar,json=inCalendar,proto3" json:"in_calendar,omitempty"`
 	ColumnId      string                 `protobuf:"bytes,6,opt,name=column_id,json=columnId,proto3" json:"column_id,omitempty"`
+	Rank          string                 `protobuf:"bytes,7,opt,name=rank,proto3" json:"rank,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *TaskResponse) Reset() {
 	*x = TaskResponse{}
-	mi := &file_board_proto_msgTypes[16]
+	mi := &file_board_proto_msgTypes[25]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1091,7 +1965,7 @@ func (x *TaskResponse) String() string {
 func (*TaskResponse) ProtoMessage() {}
 
 func (x *TaskResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_board_proto_msgTypes[16]
+	mi := &file_board_proto_msgTypes[25]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1104,7 +1978,7 @@ func (x *TaskResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TaskResponse.ProtoReflect.Descriptor instead.
 func (*TaskResponse) Descriptor() ([]byte, []int) {
-	return file_board_proto_rawDescGZIP(), []int{16}
+	return file_board_proto_rawDescGZIP(), []int{25}
 }
 
 func (x *TaskResponse) GetId() string {
@@ -1149,17 +2023,32 @@ func (x *TaskResponse) GetColumnId() string {
 	return ""
 }
 
+func (x *TaskResponse) GetRank() string {
+	if x != nil {
+		return x.Rank
+	}
+	return ""
+}
+
+// Tasks inside a column are ordered by rank. Without a placement the task is
+// moved to the end of the new column.
 type MoveTaskRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	TaskId        string                 `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
-	NewColumnId   string                 `protobuf:"bytes,2,opt,name=new_column_id,json=newColumnId,proto3" json:"new_column_id,omitempty"`
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	TaskId      string                 `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	NewColumnId string                 `protobuf:"bytes,2,opt,name=new_column_id,json=newColumnId,proto3" json:"new_column_id,omitempty"`
+	// Types that are valid to be assigned to Placement:
+	//
+	//	*MoveTaskRequest_Position
+	//	*MoveTaskRequest_BeforeTaskId
+	//	*MoveTaskRequest_AfterTaskId
+	Placement     isMoveTaskRequest_Placement `protobuf_oneof:"placement"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *MoveTaskRequest) Reset() {
 	*x = MoveTaskRequest{}
-	mi := &file_board_proto_msgTypes[17]
+	mi := &file_board_proto_msgTypes[26]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1171,7 +2060,7 @@ func (x *MoveTaskRequest) String() string {
 func (*MoveTaskRequest) ProtoMessage() {}
 
 func (x *MoveTaskRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_board_proto_msgTypes[17]
+	mi := &file_board_proto_msgTypes[26]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1184,7 +2073,7 @@ func (x *MoveTaskRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MoveTaskRequest.ProtoReflect.Descriptor instead.
 func (*MoveTaskRequest) Descriptor() ([]byte, []int) {
-	return file_board_proto_rawDescGZIP(), []int{17}
+	return file_board_proto_rawDescGZIP(), []int{26}
 }
 
 func (x *MoveTaskRequest) GetTaskId() string {
@@ -1201,17 +2090,75 @@ func (x *MoveTaskRequest) GetNewColumnId() string {
 	return ""
 }
 
+func (x *MoveTaskRequest) GetPlacement() isMoveTaskRequest_Placement {
+	if x != nil {
+		return x.Placement
+	}
+	return nil
+}
+
+func (x *MoveTaskRequest) GetPosition() int32 {
+	if x != nil {
+		if x, ok := x.Placement.(*MoveTaskRequest_Position); ok {
+			return x.Position
+		}
+	}
+	return 0
+}
+
+func (x *MoveTaskRequest) GetBeforeTaskId() string {
+	if x != nil {
+		if x, ok := x.Placement.(*MoveTaskRequest_BeforeTaskId); ok {
+			return x.BeforeTaskId
+		}
+	}
+	return ""
+}
+
+func (x *MoveTaskRequest) GetAfterTaskId() string {
+	if x != nil {
+		if x, ok := x.Placement.(*MoveTaskRequest_AfterTaskId); ok {
+			return x.AfterTaskId
+		}
+	}
+	return ""
+}
+
+type isMoveTaskRequest_Placement interface {
+	isMoveTaskRequest_Placement()
+}
+
+type MoveTaskRequest_Position struct {
+	// Zero-based index among the other tasks of the new column.
+	Position int32 `protobuf:"varint,3,opt,name=position,proto3,oneof"`
+}
+
+type MoveTaskRequest_BeforeTaskId struct {
+	BeforeTaskId string `protobuf:"bytes,4,opt,name=before_task_id,json=beforeTaskId,proto3,oneof"`
+}
+
+type MoveTaskRequest_AfterTaskId struct {
+	AfterTaskId string `protobuf:"bytes,5,opt,name=after_task_id,json=afterTaskId,proto3,oneof"`
+}
+
+func (*MoveTaskRequest_Position) isMoveTaskRequest_Placement() {}
+
+func (*MoveTaskRequest_BeforeTaskId) isMoveTaskRequest_Placement() {}
+
+func (*MoveTaskRequest_AfterTaskId) isMoveTaskRequest_Placement() {}
+
 type MoveTaskResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	TaskId        string                 `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
 	NewColumnId   string                 `protobuf:"bytes,2,opt,name=new_column_id,json=newColumnId,proto3" json:"new_column_id,omitempty"`
+	Rank          string                 `protobuf:"bytes,3,opt,name=rank,proto3" json:"rank,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *MoveTaskResponse) Reset() {
 	*x = MoveTaskResponse{}
-	mi := &file_board_proto_msgTypes[18]
+	mi := &file_board_proto_msgTypes[27]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1223,7 +2170,7 @@ func (x *MoveTaskResponse) String() string {
 func (*MoveTaskResponse) ProtoMessage() {}
 
 func (x *MoveTaskResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_board_proto_msgTypes[18]
+	mi := &file_board_proto_msgTypes[27]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1236,7 +2183,7 @@ func (x *MoveTaskResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MoveTaskResponse.ProtoReflect.Descriptor instead.
 func (*MoveTaskResponse) Descriptor() ([]byte, []int) {
-	return file_board_proto_rawDescGZIP(), []int{18}
+	return file_board_proto_rawDescGZIP(), []int{27}
 }
 
 func (x *MoveTaskResponse) GetTaskId() string {
@@ -1253,18 +2200,30 @@ func (x *MoveTaskResponse) GetNewColumnId() string {
 	return ""
 }
 
+func (x *MoveTaskResponse) GetRank() string {
+	if x != nil {
+		return x.Rank
+	}
+	return ""
+}
+
 type UpdateTaskRequest struct {
-	state         protoimpl.MessageState  `protogen:"open.v1"`
-	Id            string                  `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name          *wrapperspb.StringValue `protobuf:"bytes,2,opt,name=name,proto3,oneof" json:"name,omitempty"`
-	Description   *wrapperspb.StringValue `protobuf:"bytes,3,opt,name=description,proto3,oneof" json:"description,omitempty"`
+	state       protoimpl.MessageState  `protogen:"open.v1"`
+	Id          string                  `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name        *wrapperspb.StringValue `protobuf:"bytes,2,opt,name=name,proto3,oneof" json:"name,omitempty"`
+	Description *wrapperspb.StringValue `protobuf:"bytes,3,opt,name=description,proto3,oneof" json:"description,omitempty"`
+	// RFC 3339, must be in the future.
+	Deadline *wrapperspb.StringValue `protobuf:"bytes,4,opt,name=deadline,proto3,oneof" json:"deadline,omitempty"`
+	// Adding a task to the calendar or removing it creates or deletes its
+	// calendar event.
+	InCalendar    *wrapperspb.BoolValue `protobuf:"bytes,5,opt,name=in_calendar,json=inCalendar,proto3,oneof" json:"in_calendar,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *UpdateTaskRequest) Reset() {
 	*x = UpdateTaskRequest{}
-	mi := &file_board_proto_msgTypes[19]
+	mi := &file_board_proto_msgTypes[28]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1276,7 +2235,7 @@ func (x *UpdateTaskRequest) String() string {
 func (*UpdateTaskRequest) ProtoMessage() {}
 
 func (x *UpdateTaskRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_board_proto_msgTypes[19]
+	mi := &file_board_proto_msgTypes[28]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1289,7 +2248,7 @@ func (x *UpdateTaskRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateTaskRequest.ProtoReflect.Descriptor instead.
 func (*UpdateTaskRequest) Descriptor() ([]byte, []int) {
-	return file_board_proto_rawDescGZIP(), []int{19}
+	return file_board_proto_rawDescGZIP(), []int{28}
 }
 
 func (x *UpdateTaskRequest) GetId() string {
@@ -1313,6 +2272,20 @@ func (x *UpdateTaskRequest) GetDescription() *wrapperspb.StringValue {
 	return nil
 }
 
+func (x *UpdateTaskRequest) GetDeadline() *wrapperspb.StringValue {
+	if x != nil {
+		return x.Deadline
+	}
+	return nil
+}
+
+func (x *UpdateTaskRequest) GetInCalendar() *wrapperspb.BoolValue {
+	if x != nil {
+		return x.InCalendar
+	}
+	return nil
+}
+
 type DeleteTaskRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -1322,7 +2295,7 @@ type DeleteTaskRequest struct {
 
 func (x *DeleteTaskRequest) Reset() {
 	*x = DeleteTaskRequest{}
-	mi := &file_board_proto_msgTypes[20]
+	mi := &file_board_proto_msgTypes[29]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1334,7 +2307,7 @@ func (x *DeleteTaskRequest) String() string {
 func (*DeleteTaskRequest) ProtoMessage() {}
 
 func (x *DeleteTaskRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_board_proto_msgTypes[20]
+	mi := &file_board_proto_msgTypes[29]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1347,7 +2320,7 @@ func (x *DeleteTaskRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteTaskRequest.ProtoReflect.Descriptor instead.
 func (*DeleteTaskRequest) Descriptor() ([]byte, []int) {
-	return file_board_proto_rawDescGZIP(), []int{20}
+	return file_board_proto_rawDescGZIP(), []int{29}
 }
 
 func (x *DeleteTaskRequest) GetId() string {
@@ -1357,6 +2330,235 @@ func (x *DeleteTaskRequest) GetId() string {
 	return ""
 }
 
+// Results are sorted by relevance, matches in the title weigh more than
+// matches in the description.
+type SearchTasksRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Words to search for, stemmed and case-insensitive. "Quoted phrases"
+	// must appear as is, words prefixed with - must not appear.
+	Query    string `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	BoardId  string `protobuf:"bytes,2,opt,name=board_id,json=boardId,proto3" json:"board_id,omitempty"`
+	ColumnId string `protobuf:"bytes,3,opt,name=column_id,json=columnId,proto3" json:"column_id,omitempty"`
+	// RFC 3339, both inclusive.
+	DeadlineFrom string `protobuf:"bytes,4,opt,name=deadline_from,json=deadlineFrom,proto3" json:"deadline_from,omitempty"`
+	DeadlineTo   string `protobuf:"bytes,5,opt,name=deadline_to,json=deadlineTo,proto3" json:"deadline_to,omitempty"`
+	InCalendar   *bool  `protobuf:"varint,6,opt,name=in_calendar,json=inCalendar,proto3,oneof" json:"in_calendar,omitempty"`
+	// Defaults to 20, at most 100.
+	PageSize int32 `protobuf:"varint,7,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// next_page_token of the previous response. The query must not change
+	// between pages.
+	PageToken     string `protobuf:"bytes,8,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchTasksRequest) Reset() {
+	*x = SearchTasksRequest{}
+	mi := &file_board_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchTasksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchTasksRequest) ProtoMessage() {}
+
+func (x *SearchTasksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_board_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchTasksRequest.ProtoReflect.Descriptor instead.
+func (*SearchTasksRequest) Descriptor() ([]byte, []int) {
+	return file_board_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *SearchTasksRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *SearchTasksRequest) GetBoardId() string {
+	if x != nil {
+		return x.BoardId
+	}
+	return ""
+}
+
+func (x *SearchTasksRequest) GetColumnId() string {
+	if x != nil {
+		return x.ColumnId
+	}
+	return ""
+}
+
+func (x *SearchTasksRequest) GetDeadlineFrom() string {
+	if x != nil {
+		return x.DeadlineFrom
+	}
+	return ""
+}
+
+func (x *SearchTasksRequest) GetDeadlineTo() string {
+	if x != nil {
+		return x.DeadlineTo
+	}
+	return ""
+}
+
+func (x *SearchTasksRequest) GetInCalendar() bool {
+	if x != nil && x.InCalendar != nil {
+		return *x.InCalendar
+	}
+	return false
+}
+
+func (x *SearchTasksRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *SearchTasksRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type TaskSearchResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Task          *TaskResponse          `protobuf:"bytes,1,opt,name=task,proto3" json:"task,omitempty"`
+	BoardId       string                 `protobuf:"bytes,2,opt,name=board_id,json=boardId,proto3" json:"board_id,omitempty"`
+	BoardName     string                 `protobuf:"bytes,3,opt,name=board_name,json=boardName,proto3" json:"board_name,omitempty"`
+	ColumnName    string                 `protobuf:"bytes,4,opt,name=column_name,json=columnName,proto3" json:"column_name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TaskSearchResult) Reset() {
+	*x = TaskSearchResult{}
+	mi := &file_board_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TaskSearchResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaskSearchResult) ProtoMessage() {}
+
+func (x *TaskSearchResult) ProtoReflect() protoreflect.Message {
+	mi := &file_board_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaskSearchResult.ProtoReflect.Descriptor instead.
+func (*TaskSearchResult) Descriptor() ([]byte, []int) {
+	return file_board_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *TaskSearchResult) GetTask() *TaskResponse {
+	if x != nil {
+		return x.Task
+	}
+	return nil
+}
+
+func (x *TaskSearchResult) GetBoardId() string {
+	if x != nil {
+		return x.BoardId
+	}
+	return ""
+}
+
+func (x *TaskSearchResult) GetBoardName() string {
+	if x != nil {
+		return x.BoardName
+	}
+	return ""
+}
+
+func (x *TaskSearchResult) GetColumnName() string {
+	if x != nil {
+		return x.ColumnName
+	}
+	return ""
+}
+
+type SearchTasksResponse struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Results []*TaskSearchResult    `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	// Empty on the last page.
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchTasksResponse) Reset() {
+	*x = SearchTasksResponse{}
+	mi := &file_board_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchTasksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchTasksResponse) ProtoMessage() {}
+
+func (x *SearchTasksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_board_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchTasksResponse.ProtoReflect.Descriptor instead.
+func (*SearchTasksResponse) Descriptor() ([]byte, []int) {
+	return file_board_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *SearchTasksResponse) GetResults() []*TaskSearchResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *SearchTasksResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
 var File_board_proto protoreflect.FileDescriptor
 
 const file_board_proto_rawDesc = "" +
@@ -1376,12 +2578,23 @@ const file_board_proto_rawDesc = "" +
 	"\bprogress\x18\x06 \x01(\x03R\bprogress\x12\x1a\n" +
 	"\bfavorite\x18\a \x01(\bR\bfavorite\x129\n" +
 	"\n" +
-	"updated_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"E\n" +
+	"updated_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"m\n" +
 	"\x12BoardsListResponse\x12/\n" +
-	"\x06boards\x18\x01 \x03(\v2\x17.board_v1.BoardResponseR\x06boards\"\x12\n" +
-	"\x10GetBoardsRequest\"%\n" +
+	"\x06boards\x18\x01 \x03(\v2\x17.board_v1.BoardResponseR\x06boards\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"\xa1\x02\n" +
+	"\x10GetBoardsRequest\x12\x1b\n" +
+	"\tpage_size\x18\x01 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x02 \x01(\tR\tpageToken\x12\x1a\n" +
+	"\bcategory\x18\x03 \x01(\tR\bcategory\x12 \n" +
+	"\vmethodology\x18\x04 \x01(\tR\vmethodology\x12\x1f\n" +
+	"\bfavorite\x18\x05 \x01(\bH\x00R\bfavorite\x88\x01\x01\x121\n" +
+	"\asort_by\x18\x06 \x01(\x0e2\x18.board_v1.BoardSortFieldR\x06sortBy\x122\n" +
+	"\n" +
+	"sort_order\x18\a \x01(\x0e2\x13.board_v1.SortOrderR\tsortOrderB\v\n" +
+	"\t_favorite\"%\n" +
 	"\x13GetBoardInfoRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"\xaa\x01\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\xbe\x01\n" +
 	"\bTaskInfo\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
@@ -1389,7 +2602,8 @@ const file_board_proto_rawDesc = "" +
 	"\bdeadline\x18\x04 \x01(\tR\bdeadline\x12\x1f\n" +
 	"\vin_calendar\x18\x05 \x01(\bR\n" +
 	"inCalendar\x12\x1b\n" +
-	"\tcolumn_id\x18\x06 \x01(\tR\bcolumnId\"\x98\x01\n" +
+	"\tcolumn_id\x18\x06 \x01(\tR\bcolumnId\x12\x12\n" +
+	"\x04rank\x18\a \x01(\tR\x04rank\"\x98\x01\n" +
 	"\n" +
 	"ColumnInfo\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
@@ -1426,7 +2640,39 @@ const file_board_proto_rawDesc = "" +
 	"\t_progressB\v\n" +
 	"\t_favorite\"$\n" +
 	"\x12DeleteBoardRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"D\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\".\n" +
+	"\x11WatchBoardRequest\x12\x19\n" +
+	"\bboard_id\x18\x01 \x01(\tR\aboardId\"\x9a\x02\n" +
+	"\vBoardChange\x12-\n" +
+	"\x04type\x18\x01 \x01(\x0e2\x19.board_v1.BoardChangeTypeR\x04type\x12\x19\n" +
+	"\bboard_id\x18\x02 \x01(\tR\aboardId\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\tR\x06userId\x12;\n" +
+	"\voccurred_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"occurredAt\x122\n" +
+	"\x06column\x18\x05 \x01(\v2\x18.board_v1.ColumnResponseH\x00R\x06column\x12,\n" +
+	"\x04task\x18\x06 \x01(\v2\x16.board_v1.TaskResponseH\x00R\x04taskB\t\n" +
+	"\asubject\"_\n" +
+	"\x15AddBoardMemberRequest\x12\x19\n" +
+	"\bboard_id\x18\x01 \x01(\tR\aboardId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x12\n" +
+	"\x04role\x18\x03 \x01(\tR\x04role\"N\n" +
+	"\x18RemoveBoardMemberRequest\x12\x19\n" +
+	"\bboard_id\x18\x01 \x01(\tR\aboardId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\"4\n" +
+	"\x17ListBoardMembersRequest\x12\x19\n" +
+	"\bboard_id\x18\x01 \x01(\tR\aboardId\"a\n" +
+	"\x17UpdateMemberRoleRequest\x12\x19\n" +
+	"\bboard_id\x18\x01 \x01(\tR\aboardId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x12\n" +
+	"\x04role\x18\x03 \x01(\tR\x04role\"\x98\x01\n" +
+	"\x13BoardMemberResponse\x12\x19\n" +
+	"\bboard_id\x18\x01 \x01(\tR\aboardId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x12\n" +
+	"\x04role\x18\x03 \x01(\tR\x04role\x129\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"S\n" +
+	"\x18BoardMembersListResponse\x127\n" +
+	"\amembers\x18\x01 \x03(\v2\x1d.board_v1.BoardMemberResponseR\amembers\"D\n" +
 	"\x13CreateColumnRequest\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12\x19\n" +
 	"\bboard_id\x18\x02 \x01(\tR\aboardId\"r\n" +
@@ -1434,20 +2680,25 @@ const file_board_proto_rawDesc = "" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12\x19\n" +
 	"\bboard_id\x18\x03 \x01(\tR\aboardId\x12!\n" +
-	"\forder_number\x18\x04 \x01(\x03R\vorderNumber\"%\n" +
+	"\forder_number\x18\x04 \x01(\x03R\vorderNumber\"\x7f\n" +
 	"\x13DeleteColumnRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12.\n" +
+	"\x04mode\x18\x02 \x01(\x0e2\x1a.board_v1.DeleteColumnModeR\x04mode\x12(\n" +
+	"\x10target_column_id\x18\x03 \x01(\tR\x0etargetColumnId\"e\n" +
 	"\x13UpdateColumnRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x125\n" +
 	"\x04name\x18\x02 \x01(\v2\x1c.google.protobuf.StringValueH\x00R\x04name\x88\x01\x01B\a\n" +
-	"\x05_name\"\xa3\x01\n" +
+	"\x05_name\"M\n" +
+	"\x11MoveColumnRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12(\n" +
+	"\x10new_order_number\x18\x02 \x01(\x03R\x0enewOrderNumber\"\xa3\x01\n" +
 	"\x11CreateTaskRequest\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12 \n" +
 	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x1a\n" +
 	"\bdeadline\x18\x03 \x01(\tR\bdeadline\x12\x1f\n" +
 	"\vin_calendar\x18\x04 \x01(\bR\n" +
 	"inCalendar\x12\x1b\n" +
-	"\tcolumn_id\x18\x05 \x01(\tR\bcolumnId\"\xae\x01\n" +
+	"\tcolumn_id\x18\x05 \x01(\tR\bcolumnId\"\xc2\x01\n" +
 	"\fTaskResponse\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
@@ -1455,21 +2706,81 @@ const file_board_proto_rawDesc = "" +
 	"\bdeadline\x18\x04 \x01(\tR\bdeadline\x12\x1f\n" +
 	"\vin_calendar\x18\x05 \x01(\bR\n" +
 	"inCalendar\x12\x1b\n" +
-	"\tcolumn_id\x18\x06 \x01(\tR\bcolumnId\"N\n" +
+	"\tcolumn_id\x18\x06 \x01(\tR\bcolumnId\x12\x12\n" +
+	"\x04rank\x18\a \x01(\tR\x04rank\"\xc7\x01\n" +
 	"\x0fMoveTaskRequest\x12\x17\n" +
 	"\atask_id\x18\x01 \x01(\tR\x06taskId\x12\"\n" +
-	"\rnew_column_id\x18\x02 \x01(\tR\vnewColumnId\"O\n" +
+	"\rnew_column_id\x18\x02 \x01(\tR\vnewColumnId\x12\x1c\n" +
+	"\bposition\x18\x03 \x01(\x05H\x00R\bposition\x12&\n" +
+	"\x0ebefore_task_id\x18\x04 \x01(\tH\x00R\fbeforeTaskId\x12$\n" +
+	"\rafter_task_id\x18\x05 \x01(\tH\x00R\vafterTaskIdB\v\n" +
+	"\tplacement\"c\n" +
 	"\x10MoveTaskResponse\x12\x17\n" +
 	"\atask_id\x18\x01 \x01(\tR\x06taskId\x12\"\n" +
-	"\rnew_column_id\x18\x02 \x01(\tR\vnewColumnId\"\xb8\x01\n" +
+	"\rnew_column_id\x18\x02 \x01(\tR\vnewColumnId\x12\x12\n" +
+	"\x04rank\x18\x03 \x01(\tR\x04rank\"\xd6\x02\n" +
 	"\x11UpdateTaskRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x125\n" +
 	"\x04name\x18\x02 \x01(\v2\x1c.google.protobuf.StringValueH\x00R\x04name\x88\x01\x01\x12C\n" +
-	"\vdescription\x18\x03 \x01(\v2\x1c.google.protobuf.StringValueH\x01R\vdescription\x88\x01\x01B\a\n" +
+	"\vdescription\x18\x03 \x01(\v2\x1c.google.protobuf.StringValueH\x01R\vdescription\x88\x01\x01\x12=\n" +
+	"\bdeadline\x18\x04 \x01(\v2\x1c.google.protobuf.StringValueH\x02R\bdeadline\x88\x01\x01\x12@\n" +
+	"\vin_calendar\x18\x05 \x01(\v2\x1a.google.protobuf.BoolValueH\x03R\n" +
+	"inCalendar\x88\x01\x01B\a\n" +
 	"\x05_nameB\x0e\n" +
-	"\f_description\"#\n" +
+	"\f_descriptionB\v\n" +
+	"\t_deadlineB\x0e\n" +
+	"\f_in_calendar\"#\n" +
 	"\x11DeleteTaskRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id2\xca\t\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x9a\x02\n" +
+	"\x12SearchTasksRequest\x12\x14\n" +
+	"\x05query\x18\x01 \x01(\tR\x05query\x12\x19\n" +
+	"\bboard_id\x18\x02 \x01(\tR\aboardId\x12\x1b\n" +
+	"\tcolumn_id\x18\x03 \x01(\tR\bcolumnId\x12#\n" +
+	"\rdeadline_from\x18\x04 \x01(\tR\fdeadlineFrom\x12\x1f\n" +
+	"\vdeadline_to\x18\x05 \x01(\tR\n" +
+	"deadlineTo\x12$\n" +
+	"\vin_calendar\x18\x06 \x01(\bH\x00R\n" +
+	"inCalendar\x88\x01\x01\x12\x1b\n" +
+	"\tpage_size\x18\a \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\b \x01(\tR\tpageTokenB\x0e\n" +
+	"\f_in_calendar\"\x99\x01\n" +
+	"\x10TaskSearchResult\x12*\n" +
+	"\x04task\x18\x01 \x01(\v2\x16.board_v1.TaskResponseR\x04task\x12\x19\n" +
+	"\bboard_id\x18\x02 \x01(\tR\aboardId\x12\x1d\n" +
+	"\n" +
+	"board_name\x18\x03 \x01(\tR\tboardName\x12\x1f\n" +
+	"\vcolumn_name\x18\x04 \x01(\tR\n" +
+	"columnName\"s\n" +
+	"\x13SearchTasksResponse\x124\n" +
+	"\aresults\x18\x01 \x03(\v2\x1a.board_v1.TaskSearchResultR\aresults\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken*\xa3\x03\n" +
+	"\x0fBoardChangeType\x12!\n" +
+	"\x1dBOARD_CHANGE_TYPE_UNSPECIFIED\x10\x00\x12$\n" +
+	" BOARD_CHANGE_TYPE_COLUMN_CREATED\x10\x01\x12$\n" +
+	" BOARD_CHANGE_TYPE_COLUMN_RENAMED\x10\x02\x12\"\n" +
+	"\x1eBOARD_CHANGE_TYPE_COLUMN_MOVED\x10\x03\x12$\n" +
+	" BOARD_CHANGE_TYPE_COLUMN_DELETED\x10\x04\x12\"\n" +
+	"\x1eBOARD_CHANGE_TYPE_TASK_CREATED\x10\x05\x12\"\n" +
+	"\x1eBOARD_CHANGE_TYPE_TASK_UPDATED\x10\x06\x12 \n" +
+	"\x1cBOARD_CHANGE_TYPE_TASK_MOVED\x10\a\x12\"\n" +
+	"\x1eBOARD_CHANGE_TYPE_TASK_DELETED\x10\b\x12#\n" +
+	"\x1fBOARD_CHANGE_TYPE_BOARD_DELETED\x10\t\x12$\n" +
+	" BOARD_CHANGE_TYPE_TASKS_RERANKED\x10\n" +
+	"*\x90\x01\n" +
+	"\x0eBoardSortField\x12 \n" +
+	"\x1cBOARD_SORT_FIELD_UNSPECIFIED\x10\x00\x12\x1f\n" +
+	"\x1bBOARD_SORT_FIELD_UPDATED_AT\x10\x01\x12\x1f\n" +
+	"\x1bBOARD_SORT_FIELD_CREATED_AT\x10\x02\x12\x1a\n" +
+	"\x16BOARD_SORT_FIELD_TITLE\x10\x03*P\n" +
+	"\tSortOrder\x12\x1a\n" +
+	"\x16SORT_ORDER_UNSPECIFIED\x10\x00\x12\x12\n" +
+	"\x0eSORT_ORDER_ASC\x10\x01\x12\x13\n" +
+	"\x0fSORT_ORDER_DESC\x10\x02*~\n" +
+	"\x10DeleteColumnMode\x12\"\n" +
+	"\x1eDELETE_COLUMN_MODE_UNSPECIFIED\x10\x00\x12#\n" +
+	"\x1fDELETE_COLUMN_MODE_DELETE_TASKS\x10\x01\x12!\n" +
+	"\x1dDELETE_COLUMN_MODE_MOVE_TASKS\x10\x022\x8d\x10\n" +
 	"\fBoardService\x12b\n" +
 	"\vCreateBoard\x12\x1c.board_v1.CreateBoardRequest\x1a\x1e.board_v1.GetBoardInfoResponse\"\x15\x82\xd3\xe4\x93\x02\x0f:\x01*\"\n" +
 	"/v1/boards\x12Y\n" +
@@ -1477,9 +2788,17 @@ const file_board_proto_rawDesc = "" +
 	"/v1/boards\x12f\n" +
 	"\fGetBoardInfo\x12\x1d.board_v1.GetBoardInfoRequest\x1a\x1e.board_v1.GetBoardInfoResponse\"\x17\x82\xd3\xe4\x93\x02\x11\x12\x0f/v1/boards/{id}\x12g\n" +
 	"\vUpdateBoard\x12\x1c.board_v1.UpdateBoardRequest\x1a\x1e.board_v1.GetBoardInfoResponse\"\x1a\x82\xd3\xe4\x93\x02\x14:\x01*2\x0f/v1/boards/{id}\x12\\\n" +
-	"\vDeleteBoard\x12\x1c.board_v1.DeleteBoardRequest\x1a\x16.google.protobuf.Empty\"\x17\x82\xd3\xe4\x93\x02\x11*\x0f/v1/boards/{id}\x12q\n" +
+	"\vDeleteBoard\x12\x1c.board_v1.DeleteBoardRequest\x1a\x16.google.protobuf.Empty\"\x17\x82\xd3\xe4\x93\x02\x11*\x0f/v1/boards/{id}\x12g\n" +
+	"\n" +
+	"WatchBoard\x12\x1b.board_v1.WatchBoardRequest\x1a\x15.board_v1.BoardChange\"#\x82\xd3\xe4\x93\x02\x1d\x12\x1b/v1/boards/{board_id}/watch0\x01\x12z\n" +
+	"\x0eAddBoardMember\x12\x1f.board_v1.AddBoardMemberRequest\x1a\x1d.board_v1.BoardMemberResponse\"(\x82\xd3\xe4\x93\x02\":\x01*\"\x1d/v1/boards/{board_id}/members\x12\x80\x01\n" +
+	"\x11RemoveBoardMember\x12\".board_v1.RemoveBoardMemberRequest\x1a\x16.google.protobuf.Empty\"/\x82\xd3\xe4\x93\x02)*'/v1/boards/{board_id}/members/{user_id}\x12\x80\x01\n" +
+	"\x10ListBoardMembers\x12!.board_v1.ListBoardMembersRequest\x1a\".board_v1.BoardMembersListResponse\"%\x82\xd3\xe4\x93\x02\x1f\x12\x1d/v1/boards/{board_id}/members\x12\x88\x01\n" +
+	"\x10UpdateMemberRole\x12!.board_v1.UpdateMemberRoleRequest\x1a\x1d.board_v1.BoardMemberResponse\"2\x82\xd3\xe4\x93\x02,:\x01*2'/v1/boards/{board_id}/members/{user_id}\x12q\n" +
 	"\fCreateColumn\x12\x1d.board_v1.CreateColumnRequest\x1a\x18.board_v1.ColumnResponse\"(\x82\xd3\xe4\x93\x02\":\x01*\"\x1d/v1/boards/{board_id}/columns\x12d\n" +
-	"\fUpdateColumn\x12\x1d.board_v1.UpdateColumnRequest\x1a\x18.board_v1.ColumnResponse\"\x1b\x82\xd3\xe4\x93\x02\x15:\x01*2\x10/v1/columns/{id}\x12_\n" +
+	"\fUpdateColumn\x12\x1d.board_v1.UpdateColumnRequest\x1a\x18.board_v1.ColumnResponse\"\x1b\x82\xd3\xe4\x93\x02\x15:\x01*2\x10/v1/columns/{id}\x12e\n" +
+	"\n" +
+	"MoveColumn\x12\x1b.board_v1.MoveColumnRequest\x1a\x18.board_v1.ColumnResponse\" \x82\xd3\xe4\x93\x02\x1a:\x01*\"\x15/v1/columns/{id}/move\x12_\n" +
 	"\fDeleteColumn\x12\x1d.board_v1.DeleteColumnRequest\x1a\x16.google.protobuf.Empty\"\x18\x82\xd3\xe4\x93\x02\x12*\x10/v1/columns/{id}\x12k\n" +
 	"\n" +
 	"CreateTask\x12\x1b.board_v1.CreateTaskRequest\x1a\x16.board_v1.TaskResponse\"(\x82\xd3\xe4\x93\x02\":\x01*\"\x1d/v1/columns/{column_id}/tasks\x12l\n" +
@@ -1487,7 +2806,8 @@ const file_board_proto_rawDesc = "" +
 	"\n" +
 	"UpdateTask\x12\x1b.board_v1.UpdateTaskRequest\x1a\x16.board_v1.TaskResponse\"\x19\x82\xd3\xe4\x93\x02\x13:\x01*2\x0e/v1/tasks/{id}\x12Y\n" +
 	"\n" +
-	"DeleteTask\x12\x1b.board_v1.DeleteTaskRequest\x1a\x16.google.protobuf.Empty\"\x16\x82\xd3\xe4\x93\x02\x10*\x0e/v1/tasks/{id}B+Z)board_service/pkg/proto/board/v1;board_v1b\x06proto3"
+	"DeleteTask\x12\x1b.board_v1.DeleteTaskRequest\x1a\x16.google.protobuf.Empty\"\x16\x82\xd3\xe4\x93\x02\x10*\x0e/v1/tasks/{id}\x12d\n" +
+	"\vSearchTasks\x12\x1c.board_v1.SearchTasksRequest\x1a\x1d.board_v1.SearchTasksResponse\"\x18\x82\xd3\xe4\x93\x02\x12\x12\x10/v1/tasks/searchB+Z)board_service/pkg/proto/board/v1;board_v1b\x06proto3"
 
 var (
 	file_board_proto_rawDescOnce sync.Once
@@ -1501,79 +2821,123 @@ func file_board_proto_rawDescGZIP() []byte {
 	return file_board_proto_rawDescData
 }
 
-var file_board_proto_msgTypes = make([]protoimpl.MessageInfo, 21)
+var file_board_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
+var file_board_proto_msgTypes = make([]protoimpl.MessageInfo, 33)
 var file_board_proto_goTypes = []any{
-	(*CreateBoardRequest)(nil),     // 0: board_v1.CreateBoardRequest
-	(*BoardResponse)(nil),          // 1: board_v1.BoardResponse
-	(*BoardsListResponse)(nil),     // 2: board_v1.BoardsListResponse
-	(*GetBoardsRequest)(nil),       // 3: board_v1.GetBoardsRequest
-	(*GetBoardInfoRequest)(nil),    // 4: board_v1.GetBoardInfoRequest
-	(*TaskInfo)(nil),               // 5: board_v1.TaskInfo
-	(*ColumnInfo)(nil),             // 6: board_v1.ColumnInfo
-	(*BoardInfo)(nil),              // 7: board_v1.BoardInfo
-	(*GetBoardInfoResponse)(nil),   // 8: board_v1.GetBoardInfoResponse
-	(*UpdateBoardRequest)(nil),     // 9: board_v1.UpdateBoardRequest
-	(*DeleteBoardRequest)(nil),     // 10: board_v1.DeleteBoardRequest
-	(*CreateColumnRequest)(nil),    // 11: board_v1.CreateColumnRequest
-	(*ColumnResponse)(nil),         // 12: board_v1.ColumnResponse
-	(*DeleteColumnRequest)(nil),    // 13: board_v1.DeleteColumnRequest
-	(*UpdateColumnRequest)(nil),    // 14: board_v1.UpdateColumnRequest
-	(*CreateTaskRequest)(nil),      // 15: board_v1.CreateTaskRequest
-	(*TaskResponse)(nil),           // 16: board_v1.TaskResponse
-	(*MoveTaskRequest)(nil),        // 17: board_v1.MoveTaskRequest
-	(*MoveTaskResponse)(nil),       // 18: board_v1.MoveTaskResponse
-	(*UpdateTaskRequest)(nil),      // 19: board_v1.UpdateTaskRequest
-	(*DeleteTaskRequest)(nil),      // 20: board_v1.DeleteTaskRequest
-	(*timestamppb.Timestamp)(nil),  // 21: google.protobuf.Timestamp
-	(*wrapperspb.StringValue)(nil), // 22: google.protobuf.StringValue
-	(*wrapperspb.Int32Value)(nil),  // 23: google.protobuf.Int32Value
-	(*wrapperspb.BoolValue)(nil),   // 24: google.protobuf.BoolValue
-	(*emptypb.Empty)(nil),          // 25: google.protobuf.Empty
+	(BoardChangeType)(0),             // 0: board_v1.BoardChangeType
+	(BoardSortField)(0),              // 1: board_v1.BoardSortField
+	(SortOrder)(0),                   // 2: board_v1.SortOrder
+	(DeleteColumnMode)(0),            // 3: board_v1.DeleteColumnMode
+	(*CreateBoardRequest)(nil),       // 4: board_v1.CreateBoardRequest
+	(*BoardResponse)(nil),            // 5: board_v1.BoardResponse
+	(*BoardsListResponse)(nil),       // 6: board_v1.BoardsListResponse
+	(*GetBoardsRequest)(nil),         // 7: board_v1.GetBoardsRequest
+	(*GetBoardInfoRequest)(nil),      // 8: board_v1.GetBoardInfoRequest
+	(*TaskInfo)(nil),                 // 9: board_v1.TaskInfo
+	(*ColumnInfo)(nil),               // 10: board_v1.ColumnInfo
+	(*BoardInfo)(nil),                // 11: board_v1.BoardInfo
+	(*GetBoardInfoResponse)(nil),     // 12: board_v1.GetBoardInfoResponse
+	(*UpdateBoardRequest)(nil),       // 13: board_v1.UpdateBoardRequest
+	(*DeleteBoardRequest)(nil),       // 14: board_v1.DeleteBoardRequest
+	(*WatchBoardRequest)(nil),        // 15: board_v1.WatchBoardRequest
+	(*BoardChange)(nil),              // 16: board_v1.BoardChange
+	(*AddBoardMemberRequest)(nil),    // 17: board_v1.AddBoardMemberRequest
+	(*RemoveBoardMemberRequest)(nil), // 18: board_v1.RemoveBoardMemberRequest
+	(*ListBoardMembersRequest)(nil),  // 19: board_v1.ListBoardMembersRequest
+	(*UpdateMemberRoleRequest)(nil),  // 20: board_v1.UpdateMemberRoleRequest
+	(*BoardMemberResponse)(nil),      // 21: board_v1.BoardMemberResponse
+	(*BoardMembersListResponse)(nil), // 22: board_v1.BoardMembersListResponse
+	(*CreateColumnRequest)(nil),      // 23: board_v1.CreateColumnRequest
+	(*ColumnResponse)(nil),           // 24: board_v1.ColumnResponse
+	(*DeleteColumnRequest)(nil),      // 25: board_v1.DeleteColumnRequest
+	(*UpdateColumnRequest)(nil),      // 26: board_v1.UpdateColumnRequest
+	(*MoveColumnRequest)(nil),        // 27: board_v1.MoveColumnRequest
+	(*CreateTaskRequest)(nil),        // 28: board_v1.CreateTaskRequest
+	(*TaskResponse)(nil),             // 29: board_v1.TaskResponse
+	(*MoveTaskRequest)(nil),          // 30: board_v1.MoveTaskRequest
+	(*MoveTaskResponse)(nil),         // 31: board_v1.MoveTaskResponse
+	(*UpdateTaskRequest)(nil),        // 32: board_v1.UpdateTaskRequest
+	(*DeleteTaskRequest)(nil),        // 33: board_v1.DeleteTaskRequest
+	(*SearchTasksRequest)(nil),       // 34: board_v1.SearchTasksRequest
+	(*TaskSearchResult)(nil),         // 35: board_v1.TaskSearchResult
+	(*SearchTasksResponse)(nil),      // 36: board_v1.SearchTasksResponse
+	(*timestamppb.Timestamp)(nil),    // 37: google.protobuf.Timestamp
+	(*wrapperspb.StringValue)(nil),   // 38: google.protobuf.StringValue
+	(*wrapperspb.Int32Value)(nil),    // 39: google.protobuf.Int32Value
+	(*wrapperspb.BoolValue)(nil),     // 40: google.protobuf.BoolValue
+	(*emptypb.Empty)(nil),            // 41: google.protobuf.Empty
 }
 var file_board_proto_depIdxs = []int32{
-	21, // 0: board_v1.BoardResponse.updated_at:type_name -> google.protobuf.Timestamp
-	1,  // 1: board_v1.BoardsListResponse.boards:type_name -> board_v1.BoardResponse
-	5,  // 2: board_v1.ColumnInfo.tasks:type_name -> board_v1.TaskInfo
-	21, // 3: board_v1.BoardInfo.updated_at:type_name -> google.protobuf.Timestamp
-	21, // 4: board_v1.BoardInfo.created_at:type_name -> google.protobuf.Timestamp
-	6,  // 5: board_v1.BoardInfo.columns:type_name -> board_v1.ColumnInfo
-	7,  // 6: board_v1.GetBoardInfoResponse.board:type_name -> board_v1.BoardInfo
-	22, // 7: board_v1.UpdateBoardRequest.name:type_name -> google.protobuf.StringValue
-	22, // 8: board_v1.UpdateBoardRequest.description:type_name -> google.protobuf.StringValue
-	23, // 9: board_v1.UpdateBoardRequest.progress:type_name -> google.protobuf.Int32Value
-	24, // 10: board_v1.UpdateBoardRequest.favorite:type_name -> google.protobuf.BoolValue
-	22, // 11: board_v1.UpdateColumnRequest.name:type_name -> google.protobuf.StringValue
-	22, // 12: board_v1.UpdateTaskRequest.name:type_name -> google.protobuf.StringValue
-	22, // 13: board_v1.UpdateTaskRequest.description:type_name -> google.protobuf.StringValue
-	0,  // 14: board_v1.BoardService.CreateBoard:input_type -> board_v1.CreateBoardRequest
-	3,  // 15: board_v1.BoardService.GetBoards:input_type -> board_v1.GetBoardsRequest
-	4,  // 16: board_v1.BoardService.GetBoardInfo:input_type -> board_v1.GetBoardInfoRequest
-	9,  // 17: board_v1.BoardService.UpdateBoard:input_type -> board_v1.UpdateBoardRequest
-	10, // 18: board_v1.BoardService.DeleteBoard:input_type -> board_v1.DeleteBoardRequest
-	11, // 19: board_v1.BoardService.CreateColumn:input_type -> board_v1.CreateColumnRequest
-	14, // 20: board_v1.BoardService.UpdateColumn:input_type -> board_v1.UpdateColumnRequest
-	13, // 21: board_v1.BoardService.DeleteColumn:input_type -> board_v1.DeleteColumnRequest
-	15, // 22: board_v1.BoardService.CreateTask:input_type -> board_v1.CreateTaskRequest
-	17, // 23: board_v1.BoardService.MoveTask:input_type -> board_v1.MoveTaskRequest
-	19, // 24: board_v1.BoardService.UpdateTask:input_type -> board_v1.UpdateTaskRequest
-	20, // 25: board_v1.BoardService.DeleteTask:input_type -> board_v1.DeleteTaskRequest
-	8,  // 26: board_v1.BoardService.CreateBoard:output_type -> board_v1.GetBoardInfoResponse
-	2,  // 27: board_v1.BoardService.GetBoards:output_type -> board_v1.BoardsListResponse
-	8,  // 28: board_v1.BoardService.GetBoardInfo:output_type -> board_v1.GetBoardInfoResponse
-	8,  // 29: board_v1.BoardService.UpdateBoard:output_type -> board_v1.GetBoardInfoResponse
-	25, // 30: board_v1.BoardService.DeleteBoard:output_type -> google.protobuf.Empty
-	12, // 31: board_v1.BoardService.CreateColumn:output_type -> board_v1.ColumnResponse
-	12, // 32: board_v1.BoardService.UpdateColumn:output_type -> board_v1.ColumnResponse
-	25, // 33: board_v1.BoardService.DeleteColumn:output_type -> google.protobuf.Empty
-	16, // 34: board_v1.BoardService.CreateTask:output_type -> board_v1.TaskResponse
-	18, // 35: board_v1.BoardService.MoveTask:output_type -> board_v1.MoveTaskResponse
-	16, // 36: board_v1.BoardService.UpdateTask:output_type -> board_v1.TaskResponse
-	25, // 37: board_v1.BoardService.DeleteTask:output_type -> google.protobuf.Empty
-	26, // [26:38] is the sub-list for method output_type
-	14, // [14:26] is the sub-list for method input_type
-	14, // [14:14] is the sub-list for extension type_name
-	14, // [14:14] is the sub-list for extension extendee
-	0,  // [0:14] is the sub-list for field type_name
+	37, // 0: board_v1.BoardResponse.updated_at:type_name -> google.protobuf.Timestamp
+	5,  // 1: board_v1.BoardsListResponse.boards:type_name -> board_v1.BoardResponse
+	1,  // 2: board_v1.GetBoardsRequest.sort_by:type_name -> board_v1.BoardSortField
+	2,  // 3: board_v1.GetBoardsRequest.sort_order:type_name -> board_v1.SortOrder
+	9,  // 4: board_v1.ColumnInfo.tasks:type_name -> board_v1.TaskInfo
+	37, // 5: board_v1.BoardInfo.updated_at:type_name -> google.protobuf.Timestamp
+	37, // 6: board_v1.BoardInfo.created_at:type_name -> google.protobuf.Timestamp
+	10, // 7: board_v1.BoardInfo.columns:type_name -> board_v1.ColumnInfo
+	11, // 8: board_v1.GetBoardInfoResponse.board:type_name -> board_v1.BoardInfo
+	38, // 9: board_v1.UpdateBoardRequest.name:type_name -> google.protobuf.StringValue
+	38, // 10: board_v1.UpdateBoardRequest.description:type_name -> google.protobuf.StringValue
+	39, // 11: board_v1.UpdateBoardRequest.progress:type_name -> google.protobuf.Int32Value
+	40, // 12: board_v1.UpdateBoardRequest.favorite:type_name -> google.protobuf.BoolValue
+	0,  // 13: board_v1.BoardChange.type:type_name -> board_v1.BoardChangeType
+	37, // 14: board_v1.BoardChange.occurred_at:type_name -> google.protobuf.Timestamp
+	24, // 15: board_v1.BoardChange.column:type_name -> board_v1.ColumnResponse
+	29, // 16: board_v1.BoardChange.task:type_name -> board_v1.TaskResponse
+	37, // 17: board_v1.BoardMemberResponse.created_at:type_name -> google.protobuf.Timestamp
+	21, // 18: board_v1.BoardMembersListResponse.members:type_name -> board_v1.BoardMemberResponse
+	3,  // 19: board_v1.DeleteColumnRequest.mode:type_name -> board_v1.DeleteColumnMode
+	38, // 20: board_v1.UpdateColumnRequest.name:type_name -> google.protobuf.StringValue
+	38, // 21: board_v1.UpdateTaskRequest.name:type_name -> google.protobuf.StringValue
+	38, // 22: board_v1.UpdateTaskRequest.description:type_name -> google.protobuf.StringValue
+	38, // 23: board_v1.UpdateTaskRequest.deadline:type_name -> google.protobuf.StringValue
+	40, // 24: board_v1.UpdateTaskRequest.in_calendar:type_name -> google.protobuf.BoolValue
+	29, // 25: board_v1.TaskSearchResult.task:type_name -> board_v1.TaskResponse
+	35, // 26: board_v1.SearchTasksResponse.results:type_name -> board_v1.TaskSearchResult
+	4,  // 27: board_v1.BoardService.CreateBoard:input_type -> board_v1.CreateBoardRequest
+	7,  // 28: board_v1.BoardService.GetBoards:input_type -> board_v1.GetBoardsRequest
+	8,  // 29: board_v1.BoardService.GetBoardInfo:input_type -> board_v1.GetBoardInfoRequest
+	13, // 30: board_v1.BoardService.UpdateBoard:input_type -> board_v1.UpdateBoardRequest
+	14, // 31: board_v1.BoardService.DeleteBoard:input_type -> board_v1.DeleteBoardRequest
+	15, // 32: board_v1.BoardService.WatchBoard:input_type -> board_v1.WatchBoardRequest
+	17, // 33: board_v1.BoardService.AddBoardMember:input_type -> board_v1.AddBoardMemberRequest
+	18, // 34: board_v1.BoardService.RemoveBoardMember:input_type -> board_v1.RemoveBoardMemberRequest
+	19, // 35: board_v1.BoardService.ListBoardMembers:input_type -> board_v1.ListBoardMembersRequest
+	20, // 36: board_v1.BoardService.UpdateMemberRole:input_type -> board_v1.UpdateMemberRoleRequest
+	23, // 37: board_v1.BoardService.CreateColumn:input_type -> board_v1.CreateColumnRequest
+	26, // 38: board_v1.BoardService.UpdateColumn:input_type -> board_v1.UpdateColumnRequest
+	27, // 39: board_v1.BoardService.MoveColumn:input_type -> board_v1.MoveColumnRequest
+	25, // 40: board_v1.BoardService.DeleteColumn:input_type -> board_v1.DeleteColumnRequest
+	28, // 41: board_v1.BoardService.CreateTask:input_type -> board_v1.CreateTaskRequest
+	30, // 42: board_v1.BoardService.MoveTask:input_type -> board_v1.MoveTaskRequest
+	32, // 43: board_v1.BoardService.UpdateTask:input_type -> board_v1.UpdateTaskRequest
+	33, // 44: board_v1.BoardService.DeleteTask:input_type -> board_v1.DeleteTaskRequest
+	34, // 45: board_v1.BoardService.SearchTasks:input_type -> board_v1.SearchTasksRequest
+	12, // 46: board_v1.BoardService.CreateBoard:output_type -> board_v1.GetBoardInfoResponse
+	6,  // 47: board_v1.BoardService.GetBoards:output_type -> board_v1.BoardsListResponse
+	12, // 48: board_v1.BoardService.GetBoardInfo:output_type -> board_v1.GetBoardInfoResponse
+	12, // 49: board_v1.BoardService.UpdateBoard:output_type -> board_v1.GetBoardInfoResponse
+	41, // 50: board_v1.BoardService.DeleteBoard:output_type -> google.protobuf.Empty
+	16, // 51: board_v1.BoardService.WatchBoard:output_type -> board_v1.BoardChange
+	21, // 52: board_v1.BoardService.AddBoardMember:output_type -> board_v1.BoardMemberResponse
+	41, // 53: board_v1.BoardService.RemoveBoardMember:output_type -> google.protobuf.Empty
+	22, // 54: board_v1.BoardService.ListBoardMembers:output_type -> board_v1.BoardMembersListResponse
+	21, // 55: board_v1.BoardService.UpdateMemberRole:output_type -> board_v1.BoardMemberResponse
+	24, // 56: board_v1.BoardService.CreateColumn:output_type -> board_v1.ColumnResponse
+	24, // 57: board_v1.BoardService.UpdateColumn:output_type -> board_v1.ColumnResponse
+	24, // 58: board_v1.BoardService.MoveColumn:output_type -> board_v1.ColumnResponse
+	41, // 59: board_v1.BoardService.DeleteColumn:output_type -> google.protobuf.Empty
+	29, // 60: board_v1.BoardService.CreateTask:output_type -> board_v1.TaskResponse
+	31, // 61: board_v1.BoardService.MoveTask:output_type -> board_v1.MoveTaskResponse
+	29, // 62: board_v1.BoardService.UpdateTask:output_type -> board_v1.TaskResponse
+	41, // 63: board_v1.BoardService.DeleteTask:output_type -> google.protobuf.Empty
+	36, // 64: board_v1.BoardService.SearchTasks:output_type -> board_v1.SearchTasksResponse
+	46, // [46:65] is the sub-list for method output_type
+	27, // [27:46] is the sub-list for method input_type
+	27, // [27:27] is the sub-list for extension type_name
+	27, // [27:27] is the sub-list for extension extendee
+	0,  // [0:27] is the sub-list for field type_name
 }
 
 func init() { file_board_proto_init() }
@@ -1581,21 +2945,33 @@ func file_board_proto_init() {
 	if File_board_proto != nil {
 		return
 	}
+	file_board_proto_msgTypes[3].OneofWrappers = []any{}
 	file_board_proto_msgTypes[9].OneofWrappers = []any{}
-	file_board_proto_msgTypes[14].OneofWrappers = []any{}
-	file_board_proto_msgTypes[19].OneofWrappers = []any{}
+	file_board_proto_msgTypes[12].OneofWrappers = []any{
+		(*BoardChange_Column)(nil),
+		(*BoardChange_Task)(nil),
+	}
+	file_board_proto_msgTypes[22].OneofWrappers = []any{}
+	file_board_proto_msgTypes[26].OneofWrappers = []any{
+		(*MoveTaskRequest_Position)(nil),
+		(*MoveTaskRequest_BeforeTaskId)(nil),
+		(*MoveTaskRequest_AfterTaskId)(nil),
+	}
+	file_board_proto_msgTypes[28].OneofWrappers = []any{}
+	file_board_proto_msgTypes[30].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_board_proto_rawDesc), len(file_board_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   21,
+			NumEnums:      4,
+			NumMessages:   33,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_board_proto_goTypes,
 		DependencyIndexes: file_board_proto_depIdxs,
+		EnumInfos:         file_board_proto_enumTypes,
 		MessageInfos:      file_board_proto_msgTypes,
 	}.Build()
 	File_board_proto = out.File
@@ -20,18 +20,25 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	BoardService_CreateBoard_FullMethodName  = "/board_v1.BoardService/CreateBoard"
-	BoardService_GetBoards_FullMethodName    = "/board_v1.BoardService/GetBoards"
-	BoardService_GetBoardInfo_FullMethodName = "/board_v1.BoardService/GetBoardInfo"
-	BoardService_UpdateBoard_FullMethodName  = "/board_v1.BoardService/UpdateBoard"
-	BoardService_DeleteBoard_FullMethodName  = "/board_v1.BoardService/DeleteBoard"
-	BoardService_CreateColumn_FullMethodName = "/board_v1.BoardService/CreateColumn"
-	BoardService_UpdateColumn_FullMethodName = "/board_v1.BoardService/UpdateColumn"
-	BoardService_DeleteColumn_FullMethodName = "/board_v1.BoardService/DeleteColumn"
-	BoardService_CreateTask_FullMethodName   = "/board_v1.BoardService/CreateTask"
-	BoardService_MoveTask_FullMethodName     = "/board_v1.BoardService/MoveTask"
-	BoardService_UpdateTask_FullMethodName   = "/board_v1.BoardService/UpdateTask"
-	BoardService_DeleteTask_FullMethodName   = "/board_v1.BoardService/DeleteTask"
+	BoardService_CreateBoard_FullMethodName       = "/board_v1.BoardService/CreateBoard"
+	BoardService_GetBoards_FullMethodName         = "/board_v1.BoardService/GetBoards"
+	BoardService_GetBoardInfo_FullMethodName      = "/board_v1.BoardService/GetBoardInfo"
+	BoardService_UpdateBoard_FullMethodName       = "/board_v1.BoardService/UpdateBoard"
+	BoardService_DeleteBoard_FullMethodName       = "/board_v1.BoardService/DeleteBoard"
+	BoardService_WatchBoard_FullMethodName        = "/board_v1.BoardService/WatchBoard"
+	BoardService_AddBoardMember_FullMethodName    = "/board_v1.BoardService/AddBoardMember"
+	BoardService_RemoveBoardMember_FullMethodName = "/board_v1.BoardService/RemoveBoardMember"
+	BoardService_ListBoardMembers_FullMethodName  = "/board_v1.BoardService/ListBoardMembers"
+	BoardService_UpdateMemberRole_FullMethodName  = "/board_v1.BoardService/UpdateMemberRole"
+	BoardService_CreateColumn_FullMethodName      = "/board_v1.BoardService/CreateColumn"
+	BoardService_UpdateColumn_FullMethodName      = "/board_v1.BoardService/UpdateColumn"
+	BoardService_MoveColumn_FullMethodName        = "/board_v1.BoardService/MoveColumn"
+	BoardService_DeleteColumn_FullMethodName      = "/board_v1.BoardService/DeleteColumn"
+	BoardService_CreateTask_FullMethodName        = "/board_v1.BoardService/CreateTask"
+	BoardService_MoveTask_FullMethodName          = "/board_v1.BoardService/MoveTask"
+	BoardService_UpdateTask_FullMethodName        = "/board_v1.BoardService/UpdateTask"
+	BoardService_DeleteTask_FullMethodName        = "/board_v1.BoardService/DeleteTask"
+	BoardService_SearchTasks_FullMethodName       = "/board_v1.BoardService/SearchTasks"
 )
 
 // BoardServiceClient is the client API for BoardService service.
@@ -43,13 +50,23 @@ type BoardServiceClient interface {
 	GetBoardInfo(ctx context.Context, in *GetBoardInfoRequest, opts ...grpc.CallOption) (*GetBoardInfoResponse, error)
 	UpdateBoard(ctx context.Context, in *UpdateBoardRequest, opts ...grpc.CallOption) (*GetBoardInfoResponse, error)
 	DeleteBoard(ctx context.Context, in *DeleteBoardRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Streams the changes to the columns and tasks of a board as they happen.
+	WatchBoard(ctx context.Context, in *WatchBoardRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[BoardChange], error)
+	AddBoardMember(ctx context.Context, in *AddBoardMemberRequest, opts ...grpc.CallOption) (*BoardMemberResponse, error)
+	RemoveBoardMember(ctx context.Context, in *RemoveBoardMemberRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	ListBoardMembers(ctx context.Context, in *ListBoardMembersRequest, opts ...grpc.CallOption) (*BoardMembersListResponse, error)
+	UpdateMemberRole(ctx context.Context, in *UpdateMemberRoleRequest, opts ...grpc.CallOption) (*BoardMemberResponse, error)
 	CreateColumn(ctx context.Context, in *CreateColumnRequest, opts ...grpc.CallOption) (*ColumnResponse, error)
 	UpdateColumn(ctx context.Context, in *UpdateColumnRequest, opts ...grpc.CallOption) (*ColumnResponse, error)
+	MoveColumn(ctx context.Context, in *MoveColumnRequest, opts ...grpc.CallOption) (*ColumnResponse, error)
 	DeleteColumn(ctx context.Context, in *DeleteColumnRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	CreateTask(ctx context.Context, in *CreateTaskRequest, opts ...grpc.CallOption) (*TaskResponse, error)
 	MoveTask(ctx context.Context, in *MoveTaskRequest, opts ...grpc.CallOption) (*MoveTaskResponse, error)
 	UpdateTask(ctx context.Context, in *UpdateTaskRequest, opts ...grpc.CallOption) (*TaskResponse, error)
 	DeleteTask(ctx context.Context, in *DeleteTaskRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Searches the titles and descriptions of the tasks on the boards the
+	// caller can view.
+	SearchTasks(ctx context.Context, in *SearchTasksRequest, opts ...grpc.CallOption) (*SearchTasksResponse, error)
 }
 
 type boardServiceClient struct {
@@ -110,6 +127,65 @@ func (c *boardServiceClient) DeleteBoard(ctx context.Context, in *DeleteBoardReq
 	return out, nil
 }
 
+func (c *boardServiceClient) WatchBoard(ctx context.Context, in *WatchBoardRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[BoardChange], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &BoardService_ServiceDesc.Streams[0], BoardService_WatchBoard_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchBoardRequest, BoardChange]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type BoardService_WatchBoardClient = grpc.ServerStreamingClient[BoardChange]
+
+func (c *boardServiceClient) AddBoardMember(ctx context.Context, in *AddBoardMemberRequest, opts ...grpc.CallOption) (*BoardMemberResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BoardMemberResponse)
+	err := c.cc.Invoke(ctx, BoardService_AddBoardMember_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *boardServiceClient) RemoveBoardMember(ctx context.Context, in *RemoveBoardMemberRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, BoardService_RemoveBoardMember_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *boardServiceClient) ListBoardMembers(ctx context.Context, in *ListBoardMembersRequest, opts ...grpc.CallOption) (*BoardMembersListResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BoardMembersListResponse)
+	err := c.cc.Invoke(ctx, BoardService_ListBoardMembers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *boardServiceClient) UpdateMemberRole(ctx context.Context, in *UpdateMemberRoleRequest, opts ...grpc.CallOption) (*BoardMemberResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BoardMemberResponse)
+	err := c.cc.Invoke(ctx, BoardService_UpdateMemberRole_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *boardServiceClient) CreateColumn(ctx context.Context, in *CreateColumnRequest, opts ...grpc.CallOption) (*ColumnResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ColumnResponse)
@@ -130,6 +206,16 @@ func (c *boardServiceClient) UpdateColumn(ctx context.Context, in *UpdateColumnR
 	return out, nil
 }
 
+func (c *boardServiceClient) MoveColumn(ctx context.Context, in *MoveColumnRequest, opts ...grpc.CallOption) (*ColumnResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ColumnResponse)
+	err := c.cc.Invoke(ctx, BoardService_MoveColumn_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *boardServiceClient) DeleteColumn(ctx context.Context, in *DeleteColumnRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(emptypb.Empty)
@@ -180,6 +266,16 @@ func (c *boardServiceClient) DeleteTask(ctx context.Context, in *DeleteTaskReque
 	return out, nil
 }
 
+func (c *boardServiceClient) SearchTasks(ctx context.Context, in *SearchTasksRequest, opts ...grpc.CallOption) (*SearchTasksResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SearchTasksResponse)
+	err := c.cc.Invoke(ctx, BoardService_SearchTasks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // BoardServiceServer is the server API for BoardService service.
 // All implementations must embed UnimplementedBoardServiceServer
 // for forward compatibility.
@@ -189,13 +285,23 @@ type BoardServiceServer interface {
 	GetBoardInfo(context.Context, *GetBoardInfoRequest) (*GetBoardInfoResponse, error)
 	UpdateBoard(context.Context, *UpdateBoardRequest) (*GetBoardInfoResponse, error)
 	DeleteBoard(context.Context, *DeleteBoardRequest) (*emptypb.Empty, error)
+	// Streams the changes to the columns and tasks of a board as they happen.
+	WatchBoard(*WatchBoardRequest, grpc.ServerStreamingServer[BoardChange]) error
+	AddBoardMember(context.Context, *AddBoardMemberRequest) (*BoardMemberResponse, error)
+	RemoveBoardMember(context.Context, *RemoveBoardMemberRequest) (*emptypb.Empty, error)
+	ListBoardMembers(context.Context, *ListBoardMembersRequest) (*BoardMembersListResponse, error)
+	UpdateMemberRole(context.Context, *UpdateMemberRoleRequest) (*BoardMemberResponse, error)
 	CreateColumn(context.Context, *CreateColumnRequest) (*ColumnResponse, error)
 	UpdateColumn(context.Context, *UpdateColumnRequest) (*ColumnResponse, error)
+	MoveColumn(context.Context, *MoveColumnRequest) (*ColumnResponse, error)
 	DeleteColumn(context.Context, *DeleteColumnRequest) (*emptypb.Empty, error)
 	CreateTask(context.Context, *CreateTaskRequest) (*TaskResponse, error)
 	MoveTask(context.Context, *MoveTaskRequest) (*MoveTaskResponse, error)
 	UpdateTask(context.Context, *UpdateTaskRequest) (*TaskResponse, error)
 	DeleteTask(context.Context, *DeleteTaskRequest) (*emptypb.Empty, error)
+	// Searches the titles and descriptions of the tasks on the boards the
+	// caller can view.
+	SearchTasks(context.Context, *SearchTasksRequest) (*SearchTasksResponse, error)
 	mustEmbedUnimplementedBoardServiceServer()
 }
 
@@ -221,12 +327,30 @@ func (UnimplementedBoardServiceServer) UpdateBoard(context.Context, *UpdateBoard
 func (UnimplementedBoardServiceServer) DeleteBoard(context.Context, *DeleteBoardRequest) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteBoard not implemented")
 }
+func (UnimplementedBoardServiceServer) WatchBoard(*WatchBoardRequest, grpc.ServerStreamingServer[BoardChange]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchBoard not implemented")
+}
+func (UnimplementedBoardServiceServer) AddBoardMember(context.Context, *AddBoardMemberRequest) (*BoardMemberResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddBoardMember not implemented")
+}
+func (UnimplementedBoardServiceServer) RemoveBoardMember(context.Context, *RemoveBoardMemberRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveBoardMember not implemented")
+}
+func (UnimplementedBoardServiceServer) ListBoardMembers(context.Context, *ListBoardMembersRequest) (*BoardMembersListResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListBoardMembers not implemented")
+}
+func (UnimplementedBoardServiceServer) UpdateMemberRole(context.Context, *UpdateMemberRoleRequest) (*BoardMemberResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateMemberRole not implemented")
+}
 func (UnimplementedBoardServiceServer) CreateColumn(context.Context, *CreateColumnRequest) (*ColumnResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CreateColumn not implemented")
 }
 func (UnimplementedBoardServiceServer) UpdateColumn(context.Context, *UpdateColumnRequest) (*ColumnResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateColumn not implemented")
 }
+func (UnimplementedBoardServiceServer) MoveColumn(context.Context, *MoveColumnRequest) (*ColumnResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MoveColumn not implemented")
+}
 func (UnimplementedBoardServiceServer) DeleteColumn(context.Context, *DeleteColumnRequest) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteColumn not implemented")
 }
@@ -242,6 +366,9 @@ func (UnimplementedBoardServiceServer) UpdateTask(context.Context, *UpdateTaskRe
 func (UnimplementedBoardServiceServer) DeleteTask(context.Context, *DeleteTaskRequest) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteTask not implemented")
 }
+func (UnimplementedBoardServiceServer) SearchTasks(context.Context, *SearchTasksRequest) (*SearchTasksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchTasks not implemented")
+}
 func (UnimplementedBoardServiceServer) mustEmbedUnimplementedBoardServiceServer() {}
 func (UnimplementedBoardServiceServer) testEmbeddedByValue()                      {}
 
@@ -353,6 +480,89 @@ func _BoardService_DeleteBoard_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _BoardService_WatchBoard_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchBoardRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(BoardServiceServer).WatchBoard(m, &grpc.GenericServerStream[WatchBoardRequest, BoardChange]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type BoardService_WatchBoardServer = grpc.ServerStreamingServer[BoardChange]
+
+func _BoardService_AddBoardMember_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddBoardMemberRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BoardServiceServer).AddBoardMember(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BoardService_AddBoardMember_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BoardServiceServer).AddBoardMember(ctx, req.(*AddBoardMemberRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BoardService_RemoveBoardMember_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveBoardMemberRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BoardServiceServer).RemoveBoardMember(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BoardService_RemoveBoardMember_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BoardServiceServer).RemoveBoardMember(ctx, req.(*RemoveBoardMemberRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BoardService_ListBoardMembers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListBoardMembersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BoardServiceServer).ListBoardMembers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BoardService_ListBoardMembers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BoardServiceServer).ListBoardMembers(ctx, req.(*ListBoardMembersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BoardService_UpdateMemberRole_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateMemberRoleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BoardServiceServer).UpdateMemberRole(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BoardService_UpdateMemberRole_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BoardServiceServer).UpdateMemberRole(ctx, req.(*UpdateMemberRoleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _BoardService_CreateColumn_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(CreateColumnRequest)
 	if err := dec(in); err != nil {
@@ -389,6 +599,24 @@ func _BoardService_UpdateColumn_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _BoardService_MoveColumn_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MoveColumnRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BoardServiceServer).MoveColumn(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BoardService_MoveColumn_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BoardServiceServer).MoveColumn(ctx, req.(*MoveColumnRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _BoardService_DeleteColumn_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(DeleteColumnRequest)
 	if err := dec(in); err != nil {
@@ -479,6 +707,24 @@ func _BoardService_DeleteTask_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _BoardService_SearchTasks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchTasksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BoardServiceServer).SearchTasks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BoardService_SearchTasks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BoardServiceServer).SearchTasks(ctx, req.(*SearchTasksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // BoardService_ServiceDesc is the grpc.ServiceDesc for BoardService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -506,6 +752,22 @@ var BoardService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteBoard",
 			Handler:    _BoardService_DeleteBoard_Handler,
 		},
+		{
+			MethodName: "AddBoardMember",
+			Handler:    _BoardService_AddBoardMember_Handler,
+		},
+		{
+			MethodName: "RemoveBoardMember",
+			Handler:    _BoardService_RemoveBoardMember_Handler,
+		},
+		{
+			MethodName: "ListBoardMembers",
+			Handler:    _BoardService_ListBoardMembers_Handler,
+		},
+		{
+			MethodName: "UpdateMemberRole",
+			Handler:    _BoardService_UpdateMemberRole_Handler,
+		},
 		{
 			MethodName: "CreateColumn",
 			Handler:    _BoardService_CreateColumn_Handler,
@@ -514,6 +776,10 @@ var BoardService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "UpdateColumn",
 			Handler:    _BoardService_UpdateColumn_Handler,
 		},
+		{
+			MethodName: "MoveColumn",
+			Handler:    _BoardService_MoveColumn_Handler,
+		},
 		{
 			MethodName: "DeleteColumn",
 			Handler:    _BoardService_DeleteColumn_Handler,
@@ -534,7 +800,17 @@ var BoardService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteTask",
 			Handler:    _BoardService_DeleteTask_Handler,
 		},
+		{
+			MethodName: "SearchTasks",
+			Handler:    _BoardService_SearchTasks_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchBoard",
+			Handler:       _BoardService_WatchBoard_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "board.proto",
 }